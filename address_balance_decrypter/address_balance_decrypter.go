@@ -93,6 +93,7 @@ func newAddressBalanceDecrypter(useStore bool) (*AddressBalanceDecrypter, error)
 	for _, worker := range addressBalanceDecrypter.workers {
 		worker.start()
 	}
+	addressBalanceDecrypter.initMetrics()
 
 	if useStore {
 		go addressBalanceDecrypter.saveToStore()
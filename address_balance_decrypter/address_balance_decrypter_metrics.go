@@ -0,0 +1,25 @@
+package address_balance_decrypter
+
+import (
+	"pandora-pay/helpers/metrics"
+	"sync/atomic"
+)
+
+var (
+	decryptedBalancesMetric = metrics.NewCounter("pandora_balance_decrypter_decrypted_total", "Number of balances decrypted by the workers")
+	failedBalancesMetric    = metrics.NewCounter("pandora_balance_decrypter_failed_total", "Number of balances the workers failed to decrypt")
+	decryptionTimeMetric    = metrics.NewHistogramVec("pandora_balance_decrypter_duration_seconds", "Time spent decrypting a balance", nil, []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 300})
+)
+
+func (self *AddressBalanceDecrypter) initMetrics() {
+	metrics.NewGaugeFunc("pandora_balance_decrypter_queue", "Number of balances waiting to be decrypted", func() float64 {
+		count := 0
+		self.all.Range(func(key string, work *addressBalanceDecrypterWork) bool {
+			if atomic.LoadInt32(&work.status) != ADDRESS_BALANCE_DECRYPTED_PROCESSED {
+				count += 1
+			}
+			return true
+		})
+		return float64(count)
+	})
+}
@@ -22,7 +22,14 @@ func (self *AddressBalanceDecrypterWorker) run() {
 
 		foundWork.result = &addressBalanceDecrypterWorkResult{}
 
+		start := time.Now()
 		foundWork.result.decryptedBalance, foundWork.result.err = self.processWork(foundWork)
+		decryptionTimeMetric.Observe(time.Since(start).Seconds())
+		if foundWork.result.err != nil {
+			failedBalancesMetric.Inc()
+		} else {
+			decryptedBalancesMetric.Inc()
+		}
 
 		foundWork.time = time.Now().Unix()
 		atomic.StoreInt32(&foundWork.status, ADDRESS_BALANCE_DECRYPTED_PROCESSED)
@@ -7,6 +7,7 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/bn256"
 	"pandora-pay/cryptography/crypto"
 	"pandora-pay/helpers/advanced_buffers"
 )
@@ -249,8 +250,26 @@ func (a *Address) IsIntegratedPaymentAsset() bool {
 	return len(a.PaymentAsset) > 0
 }
 
+// EncryptMessage encrypts the message using an ephemeral ECDH key. Output: ephemeral public key + encrypted (message + checksum)
 func (a *Address) EncryptMessage(message []byte) ([]byte, error) {
-	panic("not implemented")
+
+	point, err := a.GetPoint()
+	if err != nil {
+		return nil, err
+	}
+
+	r := crypto.RandomScalar()
+	sharedKey, err := crypto.GenerateSharedSecret(r, point.G1())
+	if err != nil {
+		return nil, err
+	}
+
+	data := append(append([]byte{}, message...), cryptography.GetChecksum(message)...)
+	if err = crypto.EncryptDecryptUserData(cryptography.SHA3(append(sharedKey, a.PublicKey...)), data); err != nil {
+		return nil, err
+	}
+
+	return append(new(bn256.G1).ScalarMult(crypto.G, r).EncodeCompressed(), data...), nil
 }
 
 func (a *Address) VerifySignedMessage(message, signature []byte) bool {
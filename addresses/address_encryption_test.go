@@ -0,0 +1,30 @@
+package addresses
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/helpers"
+	"testing"
+)
+
+func Test_EncryptMessage(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+
+		privateKey := GenerateNewPrivateKey()
+		address, err := privateKey.GenerateAddress(false, nil, false, nil, 0, nil)
+		assert.Nil(t, err, "Error generating key")
+
+		message := helpers.RandomBytes(32 + i)
+		encrypted, err := address.EncryptMessage(message)
+		assert.Nil(t, err, "Error encrypting")
+		assert.NotEqual(t, encrypted[len(encrypted)-len(message)-4:len(encrypted)-4], message, "Message was not encrypted")
+
+		decrypted, err := privateKey.Decrypt(encrypted)
+		assert.Nil(t, err, "Error decrypting")
+		assert.Equal(t, decrypted, message, "Decrypted message is different")
+
+		_, err = GenerateNewPrivateKey().Decrypt(encrypted)
+		assert.NotNil(t, err, "Message was decrypted by a different key")
+	}
+
+}
@@ -1,6 +1,7 @@
 package addresses
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"pandora-pay/config"
@@ -57,8 +58,35 @@ func (pk *PrivateKey) Sign(message []byte) ([]byte, error) {
 	return crypto.SignMessage(message, pk.Key)
 }
 
+// Decrypt decrypts a message encrypted by Address.EncryptMessage
 func (pk *PrivateKey) Decrypt(message []byte) ([]byte, error) {
-	return nil, errors.New("Encryption is not supported right now")
+
+	if len(message) < cryptography.PublicKeySize+cryptography.ChecksumSize {
+		return nil, errors.New("Encrypted message is too short")
+	}
+
+	var ephemeral bn256.G1
+	if err := ephemeral.DecodeCompressed(message[:cryptography.PublicKeySize]); err != nil {
+		return nil, err
+	}
+
+	priv := new(crypto.BNRed).SetBytes(pk.Key)
+	sharedKey, err := crypto.GenerateSharedSecret(priv.BigInt(), &ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	data := append([]byte{}, message[cryptography.PublicKeySize:]...)
+	if err = crypto.EncryptDecryptUserData(cryptography.SHA3(append(sharedKey, pk.GeneratePublicKey()...)), data); err != nil {
+		return nil, err
+	}
+
+	out := data[:len(data)-cryptography.ChecksumSize]
+	if !bytes.Equal(cryptography.GetChecksum(out), data[len(data)-cryptography.ChecksumSize:]) {
+		return nil, errors.New("Message could not be decrypted")
+	}
+
+	return out, nil
 }
 
 func (pk *PrivateKey) DecryptBalance(balance *crypto.ElGamal, tryPreviousValue bool, previousValue uint64, ctx context.Context, statusCallback func(string)) (uint64, error) {
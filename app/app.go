@@ -5,6 +5,7 @@ import (
 	"pandora-pay/blockchain/forging"
 	"pandora-pay/gui"
 	"pandora-pay/mempool"
+	"pandora-pay/network"
 	"pandora-pay/store"
 	"pandora-pay/wallet"
 )
@@ -20,9 +21,13 @@ func Close() {
 
 		mempool.Mempool.Close()
 
+		if network.Network != nil {
+			network.Network.Close()
+		}
+
 		forging.Forging.Close()
 		blockchain.Blockchain.Close()
-		wallet.Wallet.Close()
+		wallet.Wallets.Close()
 
 		if err := store.DBClose(); err != nil {
 			gui.GUI.Error("Error closing DB", err)
@@ -19,6 +19,7 @@ import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
 	"pandora-pay/config"
+	"pandora-pay/config/config_block"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/config/config_stake"
 	"pandora-pay/gui"
@@ -27,10 +28,12 @@ import (
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"pandora-pay/txs_validator"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -45,7 +48,9 @@ type blockchain struct {
 	UpdateNewChainUpdate                    *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]
 	UpdateSocketsSubscriptionsTransactions  *multicast.MulticastChannel[[]*blockchain_types.BlockchainTransactionUpdate]
 	UpdateSocketsSubscriptionsNotifications *multicast.MulticastChannel[*data_storage.DataStorage]
+	UpdateSocketsSubscriptionsBlocks        *multicast.MulticastChannel[*blockchain_types.BlockchainBlocksUpdate]
 	NextBlockCreatedCn                      chan *forging_block_work.ForgingWork
+	PrunedHeight                            *atomic.Uint64 //first block height whose transactions are still stored
 }
 
 var Blockchain *blockchain
@@ -102,10 +107,12 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 	var insertedTxsList []*transaction.Transaction //ordered list
 
 	removedBlocksHeights := []uint64{}
+	removedBlocksHashes := [][]byte{} //ordered by height
 	removedBlocksTransactionsCount := uint64(0)
 
 	var dataStorage *data_storage.DataStorage
 	var newChainData *BlockchainData
+	var prunedHeight uint64
 
 	err = func() (err error) {
 
@@ -158,6 +165,10 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 					copy(removedBlocksHeights[1:], removedBlocksHeights)
 					removedBlocksHeights[0] = index
 
+					removedBlocksHashes = append(removedBlocksHashes, nil)
+					copy(removedBlocksHashes[1:], removedBlocksHashes)
+					removedBlocksHashes[0] = writer.Get("blockHash_ByHeight" + strconv.FormatUint(index, 10))
+
 					if allTransactionsChanges, err = self.removeBlockComplete(writer, index, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
 						return
 					}
@@ -289,6 +300,10 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 						return errors.New("PrevHash doesn't match Genesis prevKernelHash")
 					}
 
+					if blkComplete.Block.Version != config_block.GetBlockVersion(blkComplete.Block.Height) {
+						return errors.New("Block version is invalid")
+					}
+
 					if blkComplete.Block.Timestamp < newChainData.Timestamp {
 						return errors.New("Timestamp has to be greater than the last timestmap")
 					}
@@ -312,7 +327,7 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 					//to detect if the savedBlock was done correctly
 					savedBlock = false
 
-					if allTransactionsChanges, err = self.saveBlockComplete(writer, blkComplete, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
+					if allTransactionsChanges, err = self.saveBlockComplete(writer, blkComplete, calledByForging, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
 						return errors.New("Error saving block complete: " + err.Error())
 					}
 
@@ -326,6 +341,10 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 					newChainData.KernelHash = blkComplete.Block.Bloom.KernelHash
 					newChainData.Timestamp = blkComplete.Block.Timestamp
 
+					if newChainData.StateRoot, err = state_tree.GetRoot(writer); err != nil {
+						return
+					}
+
 					difficultyBigInt := difficulty.ConvertTargetToDifficulty(newChainData.Target)
 					newChainData.BigTotalDifficulty = new(big.Int).Add(newChainData.BigTotalDifficulty, difficultyBigInt)
 
@@ -378,6 +397,10 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 					return errors.New("Error saving Blockchain " + err.Error())
 				}
 
+				if prunedHeight, err = self.pruneBlocksComplete(writer, newChainData.Height); err != nil {
+					return errors.New("Error pruning blocks " + err.Error())
+				}
+
 				if len(removedBlocksHeights) > 0 {
 
 					//remove unused blocks
@@ -453,6 +476,7 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 	if err == nil && newChainData != nil {
 		kernelHash = newChainData.KernelHash
 		self.ChainData.Store(newChainData)
+		self.PrunedHeight.Store(prunedHeight)
 		mempool.Mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_NO_ERROR
 	} else {
 		mempool.Mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_ERROR
@@ -472,6 +496,7 @@ func (self *blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete
 		update.insertedTxs = insertedTxs
 		update.insertedTxsList = insertedTxsList
 		update.insertedBlocks = insertedBlocks
+		update.removedBlocksHashes = removedBlocksHashes
 		update.allTransactionsChanges = allTransactionsChanges
 	}
 
@@ -491,12 +516,35 @@ func (self *blockchain) InitializeChain() (err error) {
 		if err.Error() != "Chain not found" {
 			return
 		}
-		if _, err = self.init(); err != nil {
-			return
-		}
-		if err = self.saveBlockchain(); err != nil {
-			return
+		if config.IMPORT_SNAPSHOT != "" {
+			if _, err = self.ImportSnapshot(config.IMPORT_SNAPSHOT, config.IMPORT_SNAPSHOT_COMMITMENT); err != nil {
+				return
+			}
+			if err = self.loadBlockchain(); err != nil {
+				return
+			}
+		} else {
+			if _, err = self.init(); err != nil {
+				return
+			}
+			if err = self.saveBlockchain(); err != nil {
+				return
+			}
 		}
+	} else if config.IMPORT_SNAPSHOT != "" {
+		gui.GUI.Warning("Snapshot was not imported because the chain store is not empty")
+	}
+
+	if err = self.loadPrunedHeight(); err != nil {
+		return
+	}
+
+	if err = self.initializeStateTree(); err != nil {
+		return
+	}
+
+	if err = self.initializeConditionalPaymentsIndex(); err != nil {
+		return
 	}
 
 	chainData := self.GetChainData()
@@ -527,7 +575,9 @@ func Initialize() error {
 		multicast.NewMulticastChannel[*blockchain_types.BlockchainUpdates](),
 		multicast.NewMulticastChannel[[]*blockchain_types.BlockchainTransactionUpdate](),
 		multicast.NewMulticastChannel[*data_storage.DataStorage](),
+		multicast.NewMulticastChannel[*blockchain_types.BlockchainBlocksUpdate](),
 		make(chan *forging_block_work.ForgingWork),
+		&atomic.Uint64{},
 	}
 
 	Blockchain.updatesQueue.chain = Blockchain
@@ -535,6 +585,7 @@ func Initialize() error {
 	Blockchain.updatesQueue.processBlockchainUpdateMempool()
 	Blockchain.updatesQueue.processBlockchainUpdateNotifications()
 	Blockchain.initBlockchainCLI()
+	Blockchain.initBlockchainMetrics()
 
 	return nil
 }
@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/blockchain/blocks/block_complete"
@@ -12,6 +13,7 @@ import (
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -20,7 +22,7 @@ func (self *blockchain) CliNewBlockchainTop(cmd string, ctx context.Context) (er
 	c := self.GetChainData()
 
 	newTop := gui.GUI.OutputReadUint64("New blockchain top ?", false, 0, func(v uint64) bool {
-		return v < c.Height
+		return v < c.Height && !self.IsBlockPruned(v)
 	})
 
 	batches := gui.GUI.OutputReadUint64("Batches. Leave empty for 5000", true, 5000, func(v uint64) bool {
@@ -97,6 +99,10 @@ func (self *blockchain) CliNewBlockchainTop(cmd string, ctx context.Context) (er
 				}
 			}
 
+			if newChainData.StateRoot, err = state_tree.GetRoot(writer); err != nil {
+				return
+			}
+
 			//removing unused transactions
 			if config.NODE_PROVIDE_EXTENDED_INFO_APP {
 				removeUnusedTransactions(writer, newChainData.TransactionsCount, removedBlocksTransactionsCount)
@@ -160,6 +166,23 @@ func (self *blockchain) CliNewBlockchainTop(cmd string, ctx context.Context) (er
 	return
 }
 
+func (self *blockchain) cliExportSnapshot(cmd string, ctx context.Context) (err error) {
+
+	filename := gui.GUI.OutputReadFilename("Path to export snapshot", "snapshot", false)
+
+	snapshot, err := self.ExportSnapshot(filename)
+	if err != nil {
+		return
+	}
+
+	gui.GUI.OutputWrite("Height", snapshot.Height)
+	gui.GUI.OutputWrite("Hash", base64.StdEncoding.EncodeToString(snapshot.Hash))
+	gui.GUI.OutputWrite("Commitment", base64.StdEncoding.EncodeToString(snapshot.Commitment))
+	gui.GUI.OutputWrite("Exported successfully to: ", filename)
+	return
+}
+
 func (self *blockchain) initBlockchainCLI() {
 	gui.GUI.CommandDefineCallback("New Blockchain Top", self.CliNewBlockchainTop, true)
+	gui.GUI.CommandDefineCallback("Export Snapshot", self.cliExportSnapshot, true)
 }
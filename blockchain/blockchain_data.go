@@ -26,6 +26,7 @@ type BlockchainData struct {
 	AssetsCount           uint64   `json:"assetsCount" msgpack:"assetsCount"`             //count of the number of assets
 	Supply                uint64   `json:"supply" msgpack:"supply"`
 	ConsecutiveSelfForged uint64   `json:"consecutiveSelfForged" msgpack:"consecutiveSelfForged"`
+	StateRoot             []byte   `json:"stateRoot" msgpack:"stateRoot"` //32
 }
 
 func (self *BlockchainData) computeNextTargetBig(reader store_db_interface.StoreDBTransactionInterface) (*big.Int, error) {
@@ -76,6 +77,7 @@ func (self *BlockchainData) clone() *BlockchainData {
 		self.AccountsCount,                        //atomic copy
 		self.AssetsCount,                          //atomic copy
 		self.Supply,
-		self.ConsecutiveSelfForged, //atomic copy
+		self.ConsecutiveSelfForged,         //atomic copy
+		helpers.CloneBytes(self.StateRoot), //atomic copy
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"pandora-pay/blockchain/genesis"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config"
+	"pandora-pay/config/config_block"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/config/config_forging"
 	"pandora-pay/config/config_stake"
@@ -35,7 +36,7 @@ func (self *blockchain) GetChainData() *BlockchainData {
 }
 
 func (self *blockchain) GetChainDataUpdate() *BlockchainDataUpdate {
-	return &BlockchainDataUpdate{self.ChainData.Load(), self.Sync.GetSyncData()}
+	return &BlockchainDataUpdate{self.ChainData.Load(), self.Sync.GetSyncData(), nil}
 }
 
 func (self *blockchain) createGenesisBlockchainData() *BlockchainData {
@@ -53,6 +54,7 @@ func (self *blockchain) createGenesisBlockchainData() *BlockchainData {
 		0,
 		0,
 		0,
+		nil,
 	}
 }
 
@@ -110,6 +112,8 @@ func (self *blockchain) initializeNewChain(chainData *BlockchainData, dataStorag
 		false,
 		false,
 		false,
+		false,
+		false,
 		byte(config_coins.DECIMAL_SEPARATOR),
 		config_coins.MAX_SUPPLY_COINS_UNITS,
 		supply,
@@ -200,7 +204,7 @@ func (self *blockchain) createNextBlockForForging(chainData *BlockchainData, new
 		} else {
 			blk = &block.Block{
 				BlockHeader: &block.BlockHeader{
-					Version: 0,
+					Version: config_block.GetBlockVersion(chainData.Height),
 					Height:  chainData.Height,
 				},
 				MerkleHash:     cryptography.SHA3([]byte{}),
@@ -208,6 +212,9 @@ func (self *blockchain) createNextBlockForForging(chainData *BlockchainData, new
 				PrevKernelHash: chainData.KernelHash,
 				Timestamp:      chainData.Timestamp,
 			}
+			if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+				blk.StateRoot = make([]byte, cryptography.HashSize) //it will be computed by the blockchain once the block is forged
+			}
 		}
 
 		blk.StakingNonce = make([]byte, 32)
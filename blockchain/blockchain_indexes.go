@@ -0,0 +1,37 @@
+package blockchain
+
+import (
+	"pandora-pay/blockchain/data_storage/conditional_payments_list"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+// initializeConditionalPaymentsIndex builds the multisig index of the conditional payments stored before the index was introduced
+func (self *blockchain) initializeConditionalPaymentsIndex() error {
+
+	if config.NODE_CONSENSUS != config.NODE_CONSENSUS_TYPE_FULL {
+		return nil
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Exists("conditionalPaymentsMultisigIndexed") {
+			return
+		}
+
+		var count int
+		if count, err = conditional_payments_list.ReindexMultisig(writer); err != nil {
+			return
+		}
+
+		if count > 0 {
+			gui.GUI.Info("Indexed " + strconv.Itoa(count) + " conditional payments by their multisig members")
+		}
+
+		writer.Put("conditionalPaymentsMultisigIndexed", []byte{1})
+		return
+	})
+}
@@ -0,0 +1,100 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestBlockchain_InitializeConditionalPaymentsIndex(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(nil)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+
+	multisig1 := helpers.RandomBytes(cryptography.PublicKeySize)
+	multisig2 := helpers.RandomBytes(cryptography.PublicKeySize)
+
+	addPayment := func(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64, multisigPublicKeys [][]byte) []byte {
+
+		txId := helpers.RandomBytes(cryptography.HashSize)
+		key := conditional_payments_list.GetConditionalPaymentKey(txId, 0)
+
+		condPayment := conditional_payment.NewConditionalPayment([]byte(key), 0, blockHeight)
+		condPayment.TxId = txId
+		condPayment.Asset = config_coins.NATIVE_ASSET_FULL
+		condPayment.ReceiverPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+		condPayment.ReceiverAmounts = [][]byte{helpers.RandomBytes(66)}
+		condPayment.SenderPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+		condPayment.SenderAmounts = [][]byte{helpers.RandomBytes(66)}
+		condPayment.MultisigThreshold = 1
+		condPayment.MultisigPublicKeys = multisigPublicKeys
+
+		condPayments := conditional_payments_list.NewConditionalPaymentsHashMap(writer, blockHeight)
+		assert.NoError(t, condPayments.Create(key, condPayment))
+		assert.NoError(t, condPayments.CommitChanges())
+		return []byte(key)
+	}
+
+	getIndex := func(publicKey []byte) (keys [][]byte) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			keys, err = conditional_payments_list.GetMultisigIndex(reader, publicKey)
+			return
+		}))
+		return
+	}
+
+	var key1, key2 []byte
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		key1 = addPayment(writer, 10, [][]byte{multisig1, multisig2})
+		key2 = addPayment(writer, 12, [][]byte{multisig1})
+		return nil
+	}))
+	assert.Equal(t, [][]byte{key1, key2}, getIndex(multisig1))
+
+	//the payments stored before the multisig index was introduced
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Delete("conditionalPayments:multisig:" + string(multisig1))
+		writer.Delete("conditionalPayments:multisig:" + string(multisig2))
+		writer.Delete("conditionalPayments:multisigKeys:" + string(key1))
+		writer.Delete("conditionalPayments:multisigKeys:" + string(key2))
+		return nil
+	}))
+	assert.Nil(t, getIndex(multisig1))
+
+	chain := &blockchain{}
+	assert.NoError(t, chain.initializeConditionalPaymentsIndex())
+
+	assert.ElementsMatch(t, [][]byte{key1, key2}, getIndex(multisig1))
+	assert.Equal(t, [][]byte{key1}, getIndex(multisig2))
+
+	//a payment that is already indexed is not indexed twice
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Delete("conditionalPaymentsMultisigIndexed")
+		return nil
+	}))
+	assert.NoError(t, chain.initializeConditionalPaymentsIndex())
+	assert.Equal(t, 2, len(getIndex(multisig1)))
+
+	//the index is built only once
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Delete("conditionalPayments:multisig:" + string(multisig2))
+		writer.Delete("conditionalPayments:multisigKeys:" + string(key1))
+		return nil
+	}))
+	assert.NoError(t, chain.initializeConditionalPaymentsIndex())
+	assert.Nil(t, getIndex(multisig2))
+}
@@ -0,0 +1,42 @@
+package blockchain
+
+import (
+	"math/big"
+	"pandora-pay/helpers/metrics"
+)
+
+func (self *blockchain) initBlockchainMetrics() {
+
+	chainDataMetric := func(callback func(chainData *BlockchainData) float64) func() float64 {
+		return func() float64 {
+			if chainData := self.GetChainData(); chainData != nil {
+				return callback(chainData)
+			}
+			return 0
+		}
+	}
+
+	metrics.NewGaugeFunc("pandora_chain_height", "Height of the chain", chainDataMetric(func(chainData *BlockchainData) float64 {
+		return float64(chainData.Height)
+	}))
+
+	metrics.NewGaugeFunc("pandora_chain_total_difficulty", "Total difficulty of the chain", chainDataMetric(func(chainData *BlockchainData) float64 {
+		value, _ := new(big.Float).SetInt(chainData.BigTotalDifficulty).Float64()
+		return value
+	}))
+
+	metrics.NewGaugeFunc("pandora_chain_timestamp", "Timestamp of the last block", chainDataMetric(func(chainData *BlockchainData) float64 {
+		return float64(chainData.Timestamp)
+	}))
+
+	metrics.NewGaugeFunc("pandora_chain_transactions", "Number of transactions included in the chain", chainDataMetric(func(chainData *BlockchainData) float64 {
+		return float64(chainData.TransactionsCount)
+	}))
+
+	metrics.NewGaugeFunc("pandora_chain_sync", "1 if the node is synchronized with the network", func() float64 {
+		if syncData := self.Sync.GetSyncData(); syncData != nil && syncData.Sync {
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,74 @@
+package blockchain
+
+import (
+	"errors"
+	"pandora-pay/config"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+func (self *blockchain) loadPrunedHeight() error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("prunedHeight")
+		if data == nil {
+			return
+		}
+
+		var prunedHeight uint64
+		if prunedHeight, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return
+		}
+
+		self.PrunedHeight.Store(prunedHeight)
+		return
+	})
+}
+
+// IsBlockPruned returns true if the transactions of the block were already discarded
+func (self *blockchain) IsBlockPruned(blockHeight uint64) bool {
+	return blockHeight < self.PrunedHeight.Load()
+}
+
+// pruneBlocksComplete discards the transactions of the blocks older than the last config.PRUNE_BLOCKS blocks.
+// Block headers, kernel hashes and the txHash: markers are kept
+func (self *blockchain) pruneBlocksComplete(writer store_db_interface.StoreDBTransactionInterface, chainHeight uint64) (prunedHeight uint64, err error) {
+
+	prunedHeight = self.PrunedHeight.Load()
+
+	if config.PRUNE_BLOCKS == 0 || chainHeight <= config.PRUNE_BLOCKS {
+		return
+	}
+
+	end := chainHeight - config.PRUNE_BLOCKS
+	if end > prunedHeight+config.PRUNE_BLOCKS_MAX_PER_UPDATE {
+		end = prunedHeight + config.PRUNE_BLOCKS_MAX_PER_UPDATE
+	}
+
+	for ; prunedHeight < end; prunedHeight++ {
+
+		blockHeightStr := strconv.FormatUint(prunedHeight, 10)
+
+		data := writer.Get("blockTxs" + blockHeightStr)
+		if data == nil {
+			return 0, errors.New("blockTxs was not found")
+		}
+
+		txHashes := [][]byte{}
+		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
+			return
+		}
+
+		for _, txHash := range txHashes {
+			writer.Delete("tx:" + string(txHash))
+			writer.Delete("txBlock:" + string(txHash))
+		}
+		writer.Delete("blockTxs" + blockHeightStr)
+	}
+
+	writer.Put("prunedHeight", []byte(strconv.FormatUint(prunedHeight, 10)))
+
+	return
+}
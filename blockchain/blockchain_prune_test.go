@@ -0,0 +1,113 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBlockchain_PruneBlocksComplete(t *testing.T) {
+
+	oldPruneBlocks, oldMaxPerUpdate := config.PRUNE_BLOCKS, config.PRUNE_BLOCKS_MAX_PER_UPDATE
+	config.PRUNE_BLOCKS, config.PRUNE_BLOCKS_MAX_PER_UPDATE = 5, 3
+	defer func() {
+		config.PRUNE_BLOCKS, config.PRUNE_BLOCKS_MAX_PER_UPDATE = oldPruneBlocks, oldMaxPerUpdate
+	}()
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+
+	const blocks = 20
+	txHashes := make([][][]byte, blocks)
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for height := range txHashes {
+			txHashes[height] = [][]byte{helpers.RandomBytes(cryptography.HashSize), helpers.RandomBytes(cryptography.HashSize)}
+
+			var marshal []byte
+			if marshal, err = msgpack.Marshal(txHashes[height]); err != nil {
+				return
+			}
+			writer.Put("blockTxs"+strconv.Itoa(height), marshal)
+
+			for _, txHash := range txHashes[height] {
+				writer.Put("tx:"+string(txHash), []byte{1})
+				writer.Put("txBlock:"+string(txHash), []byte{1})
+				writer.Put("txHash:"+string(txHash), []byte{1})
+			}
+		}
+		return
+	}))
+
+	chain := &blockchain{PrunedHeight: &atomic.Uint64{}}
+
+	prune := func(chainHeight uint64) (prunedHeight uint64) {
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			prunedHeight, err = chain.pruneBlocksComplete(writer, chainHeight)
+			return
+		}))
+		chain.PrunedHeight.Store(prunedHeight)
+		return
+	}
+
+	isStored := func(height int) (txs, markers bool) {
+		assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			txs = reader.Get("blockTxs"+strconv.Itoa(height)) != nil && reader.Get("tx:"+string(txHashes[height][0])) != nil && reader.Get("txBlock:"+string(txHashes[height][1])) != nil
+			markers = reader.Get("txHash:"+string(txHashes[height][0])) != nil && reader.Get("txHash:"+string(txHashes[height][1])) != nil
+			return nil
+		}))
+		return
+	}
+
+	//the chain is not longer than the blocks to keep
+	assert.Equal(t, uint64(0), prune(5))
+
+	//only the blocks before chainHeight - PRUNE_BLOCKS are pruned
+	assert.Equal(t, uint64(2), prune(7))
+	assert.True(t, chain.IsBlockPruned(1))
+	assert.False(t, chain.IsBlockPruned(2))
+	for height := 0; height < blocks; height++ {
+		txs, markers := isStored(height)
+		assert.Equal(t, height >= 2, txs, "height %d", height)
+		assert.True(t, markers, "height %d", height)
+	}
+
+	//pruning again at the same height does nothing
+	assert.Equal(t, uint64(2), prune(7))
+
+	//at most PRUNE_BLOCKS_MAX_PER_UPDATE blocks are pruned at once
+	assert.Equal(t, uint64(5), prune(blocks))
+	assert.Equal(t, uint64(8), prune(blocks))
+	assert.Equal(t, uint64(11), prune(blocks))
+	assert.Equal(t, uint64(14), prune(blocks))
+	assert.Equal(t, uint64(blocks-5), prune(blocks))
+	assert.Equal(t, uint64(blocks-5), prune(blocks))
+
+	txs, _ := isStored(blocks - 6)
+	assert.False(t, txs)
+	txs, _ = isStored(blocks - 5)
+	assert.True(t, txs)
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, strconv.Itoa(blocks-5), string(reader.Get("prunedHeight")))
+		return nil
+	}))
+
+	//a missing block is an error
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Delete("blockTxs" + strconv.Itoa(blocks-5))
+		return nil
+	}))
+	db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		_, err = chain.pruneBlocksComplete(writer, blocks+1)
+		assert.Error(t, err)
+		return
+	})
+}
@@ -0,0 +1,276 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/config"
+	"pandora-pay/config/config_block"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const SNAPSHOT_VERSION = uint64(0)
+
+type SnapshotEntry struct {
+	Key   []byte `json:"key" msgpack:"key"`
+	Value []byte `json:"value" msgpack:"value"`
+}
+
+type Snapshot struct {
+	Version    uint64           `json:"version" msgpack:"version"`
+	Network    uint64           `json:"network" msgpack:"network"`
+	Height     uint64           `json:"height" msgpack:"height"`
+	Hash       []byte           `json:"hash" msgpack:"hash"`
+	Commitment []byte           `json:"commitment" msgpack:"commitment"`
+	Entries    []*SnapshotEntry `json:"entries" msgpack:"entries"`
+}
+
+// the keys used by the store hash maps (registrations, accounts, plain accounts, assets, pending stakes, conditional payments and the asset fee liquidity heaps)
+var snapshotHashMapMarkers = []string{":map:", ":exists:", ":list:", ":listKeys:"}
+
+var snapshotExcludedPrefixes = []string{"tx:", "txHash:", "txBlock:", "blockTxs", "block_ByHash", "blockHeight_ByHash", "blockHash_ByHeight", "blockKernelHash_ByHeight", "blockInfo_ByHash", "txInfo_ByHash", "txPreview_ByHash", "txKeys:", "addrTx"}
+
+func isSnapshotStateKey(key string) bool {
+
+	for _, prefix := range snapshotExcludedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+
+	if strings.Contains(key, ":transitions:") {
+		return false
+	}
+	if strings.HasPrefix(key, "conditionalPayments:") { //conditional payments indexes
+		return true
+	}
+	if strings.HasSuffix(key, ":count") {
+		return true
+	}
+	for _, marker := range snapshotHashMapMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// computeCommitment hashes the sorted entries together with the height and the hash of the chain
+func (snapshot *Snapshot) computeCommitment() []byte {
+
+	w := advanced_buffers.NewBufferWriter()
+	w.WriteUvarint(snapshot.Version)
+	w.WriteUvarint(snapshot.Network)
+	w.WriteUvarint(snapshot.Height)
+	w.WriteVariableBytes(snapshot.Hash)
+	w.WriteUvarint(uint64(len(snapshot.Entries)))
+	for _, entry := range snapshot.Entries {
+		w.WriteVariableBytes(entry.Key)
+		w.WriteVariableBytes(entry.Value)
+	}
+
+	return cryptography.SHA3(w.Bytes())
+}
+
+func (snapshot *Snapshot) verify() error {
+
+	if snapshot.Version != SNAPSHOT_VERSION {
+		return errors.New("Snapshot version is not supported")
+	}
+	if snapshot.Network != config.NETWORK_SELECTED {
+		return errors.New("Snapshot was created for a different network")
+	}
+	if snapshot.Height == 0 {
+		return errors.New("Snapshot height is invalid")
+	}
+	if len(snapshot.Hash) != cryptography.HashSize {
+		return errors.New("Snapshot hash is invalid")
+	}
+
+	for i := 1; i < len(snapshot.Entries); i++ {
+		if bytes.Compare(snapshot.Entries[i-1].Key, snapshot.Entries[i].Key) >= 0 {
+			return errors.New("Snapshot entries are not sorted")
+		}
+	}
+
+	if !bytes.Equal(snapshot.computeCommitment(), snapshot.Commitment) {
+		return errors.New("Snapshot commitment doesn't match")
+	}
+
+	return nil
+}
+
+// getBlock returns the header of the last block of the snapshot. Its hash has to be the hash of the snapshot
+func (snapshot *Snapshot) getBlock() (*block.Block, error) {
+
+	key := []byte("block_ByHash" + string(snapshot.Hash))
+	index := sort.Search(len(snapshot.Entries), func(i int) bool {
+		return bytes.Compare(snapshot.Entries[i].Key, key) >= 0
+	})
+	if index == len(snapshot.Entries) || !bytes.Equal(snapshot.Entries[index].Key, key) {
+		return nil, errors.New("Snapshot block was not found")
+	}
+
+	blk := block.CreateEmptyBlock()
+	if err := blk.Deserialize(advanced_buffers.NewBufferReader(snapshot.Entries[index].Value)); err != nil {
+		return nil, err
+	}
+	if err := blk.BloomNow(); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(blk.Bloom.Hash, snapshot.Hash) {
+		return nil, errors.New("Snapshot block hash doesn't match")
+	}
+
+	return blk, nil
+}
+
+// ExportSnapshot writes the chain state at the current height. Besides the state hash maps, it includes the last
+// config.DIFFICULTY_BLOCK_WINDOW block headers required to compute the next target and to detect forks
+func (self *blockchain) ExportSnapshot(path string) (snapshot *Snapshot, err error) {
+
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		iterable, ok := reader.(store_db_interface.StoreDBIterableTransactionInterface)
+		if !ok {
+			return errors.New("Store doesn't support exporting snapshots")
+		}
+
+		chainData := &BlockchainData{}
+		if err = msgpack.Unmarshal(reader.Get("blockchainInfo"), chainData); err != nil {
+			return
+		}
+
+		snapshot = &Snapshot{SNAPSHOT_VERSION, config.NETWORK_SELECTED, chainData.Height, chainData.Hash, nil, nil}
+
+		iterable.Range(func(key string, value []byte) bool {
+			if isSnapshotStateKey(key) {
+				snapshot.Entries = append(snapshot.Entries, &SnapshotEntry{[]byte(key), value})
+			}
+			return true
+		})
+
+		keys := []string{"blockchainInfo", "chainHeight", "chainHash", "chainPrevHash", "chainKernelHash", "chainPrevKernelHash"}
+
+		start := uint64(0)
+		if chainData.Height > config.DIFFICULTY_BLOCK_WINDOW+1 {
+			start = chainData.Height - config.DIFFICULTY_BLOCK_WINDOW - 1
+		}
+
+		for height := start; height <= chainData.Height; height++ {
+			heightStr := strconv.FormatUint(height, 10)
+			keys = append(keys, "totalDifficulty"+heightStr, "blockchainInfo_"+heightStr)
+			if height < chainData.Height {
+				hash := reader.Get("blockHash_ByHeight" + heightStr)
+				keys = append(keys, "blockHash_ByHeight"+heightStr, "blockKernelHash_ByHeight"+heightStr, "block_ByHash"+string(hash), "blockHeight_ByHash"+string(hash))
+			}
+		}
+
+		for _, key := range keys {
+			if value := reader.Get(key); value != nil {
+				snapshot.Entries = append(snapshot.Entries, &SnapshotEntry{[]byte(key), value})
+			}
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	sort.Slice(snapshot.Entries, func(i, j int) bool {
+		return bytes.Compare(snapshot.Entries[i].Key, snapshot.Entries[j].Key) < 0
+	})
+	snapshot.Commitment = snapshot.computeCommitment()
+
+	var data []byte
+	if data, err = msgpack.Marshal(snapshot); err != nil {
+		return
+	}
+
+	err = os.WriteFile(path, data, 0644)
+	return
+}
+
+// ImportSnapshot loads a snapshot into an empty chain store. The transactions of the blocks before the snapshot are not available.
+// The state is verified against the StateRoot of the last block. Blocks without a StateRoot require the expected commitment
+func (self *blockchain) ImportSnapshot(path string, commitment []byte) (snapshot *Snapshot, err error) {
+
+	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
+		return nil, errors.New("Snapshots can not be imported by nodes providing extended info")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	snapshot = &Snapshot{}
+	if err = msgpack.Unmarshal(data, snapshot); err != nil {
+		return
+	}
+
+	if err = snapshot.verify(); err != nil {
+		return
+	}
+	if len(commitment) > 0 && !bytes.Equal(commitment, snapshot.Commitment) {
+		return nil, errors.New("Snapshot commitment is not the expected one")
+	}
+
+	blk, err := snapshot.getBlock()
+	if err != nil {
+		return
+	}
+	if blk.Version < config_block.BLOCK_VERSION_STATE_ROOT && len(commitment) == 0 {
+		return nil, errors.New("Snapshot block has no StateRoot, so the snapshot commitment is required")
+	}
+
+	if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Exists("blockchainInfo") {
+			return errors.New("Snapshot can be imported only in an empty chain store")
+		}
+
+		for _, entry := range snapshot.Entries {
+			writer.Put(string(entry.Key), entry.Value)
+		}
+
+		chainData := &BlockchainData{}
+		if err = msgpack.Unmarshal(writer.Get("blockchainInfo"), chainData); err != nil {
+			return
+		}
+		if chainData.Height != snapshot.Height || !bytes.Equal(chainData.Hash, snapshot.Hash) {
+			return errors.New("Snapshot chain info doesn't match")
+		}
+
+		//the state tree is built from the imported state, so it has to match the StateRoot committed by the block hash
+		if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+			var stateRoot []byte
+			if stateRoot, err = buildStateTree(writer); err != nil {
+				return
+			}
+			if !bytes.Equal(stateRoot, blk.StateRoot) {
+				return errors.New("Snapshot state doesn't match the block StateRoot")
+			}
+			writer.Put("stateTreeInitialized", []byte{1})
+		}
+
+		writer.Put("prunedHeight", []byte(strconv.FormatUint(snapshot.Height, 10)))
+		return
+	}); err != nil {
+		return
+	}
+
+	gui.GUI.Info("Snapshot imported at height", snapshot.Height, "commitment", base64.StdEncoding.EncodeToString(snapshot.Commitment))
+	return
+}
@@ -0,0 +1,190 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/config/config_block"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_bunt"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"path/filepath"
+	"testing"
+)
+
+// createTestSnapshotBlock returns the last block of the chain. Version BLOCK_VERSION_STATE_ROOT commits the stateRoot
+func createTestSnapshotBlock(t *testing.T, version uint64, stateRoot []byte) *block.Block {
+
+	blk := &block.Block{
+		BlockHeader:    &block.BlockHeader{Version: version, Height: 4},
+		MerkleHash:     cryptography.SHA3([]byte("MerkleHash")),
+		PrevHash:       cryptography.SHA3([]byte("PrevHash")),
+		PrevKernelHash: cryptography.SHA3([]byte("PrevKernelHash")),
+		StakingAmount:  config_stake.GetRequiredStake(4),
+		StakingNonce:   cryptography.SHA3([]byte("StakingNonce")),
+		StateRoot:      stateRoot,
+	}
+	assert.NoError(t, blk.BloomNow())
+	return blk
+}
+
+func TestBlockchain_SnapshotExportImport(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(nil)
+	assert.NoError(t, err)
+
+	accountKey := string(helpers.RandomBytes(cryptography.PublicKeySize))
+
+	state := map[string][]byte{
+		"plainAccs:map:" + accountKey:    {1, 2, 3},
+		"plainAccs:exists:" + accountKey: {1},
+		"plainAccs:count":                []byte("1"),
+		"conditionalPayments:all:test":   []byte("10"),
+		"chainHeight":                    []byte{5},
+	}
+	excluded := []string{"tx:abc", "txHash:abc", "blockTxs1", "plainAccs:transitions:4", "mempool-key"}
+
+	//the state root of the state hash maps
+	tree, err := store_db_memory.CreateStoreDBMemory("tree")
+	assert.NoError(t, err)
+	var stateRoot []byte
+	assert.NoError(t, tree.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		if err = state_tree.Update(writer, "plainAccs:map:"+accountKey, state["plainAccs:map:"+accountKey]); err != nil {
+			return
+		}
+		stateRoot, err = state_tree.GetRoot(writer)
+		return
+	}))
+
+	chain := &blockchain{}
+
+	exportSnapshot := func(blk *block.Block) *Snapshot {
+
+		chainData := &BlockchainData{Hash: blk.Bloom.Hash, Height: 5}
+
+		source, err := store_db_bunt.CreateStoreDBBunt("source", true)
+		assert.NoError(t, err)
+		assert.NoError(t, source.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			var marshal []byte
+			if marshal, err = msgpack.Marshal(chainData); err != nil {
+				return
+			}
+			writer.Put("blockchainInfo", marshal)
+			writer.Put("blockHash_ByHeight4", blk.Bloom.Hash)
+			writer.Put("block_ByHash"+string(blk.Bloom.Hash), helpers.SerializeToBytes(blk))
+			for key, value := range state {
+				writer.Put(key, value)
+			}
+			for _, key := range excluded {
+				writer.Put(key, []byte{1})
+			}
+			return
+		}))
+
+		store.StoreBlockchain = &store.Store{"source", true, source}
+		snapshot, err := chain.ExportSnapshot(filepath.Join(t.TempDir(), "snapshot"))
+		assert.NoError(t, err)
+		assert.Equal(t, snapshot.computeCommitment(), snapshot.Commitment)
+		assert.Equal(t, len(state)+3, len(snapshot.Entries))
+		return snapshot
+	}
+
+	writeSnapshot := func(snapshot *Snapshot) string {
+		data, err := msgpack.Marshal(snapshot)
+		assert.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "snapshot")
+		assert.NoError(t, os.WriteFile(path, data, 0644))
+		return path
+	}
+
+	importSnapshot := func(path string, commitment []byte) (store_db_interface.StoreDBInterface, error) {
+		destination, err := store_db_bunt.CreateStoreDBBunt("destination", true)
+		assert.NoError(t, err)
+		store.StoreBlockchain = &store.Store{"destination", true, destination}
+		_, err = chain.ImportSnapshot(path, commitment)
+		return destination, err
+	}
+
+	//the block has no StateRoot, so the snapshot is verified by the commitment
+	snapshot := exportSnapshot(createTestSnapshotBlock(t, config_block.BLOCK_VERSION_INITIAL, nil))
+	path := writeSnapshot(snapshot)
+
+	destination, err := importSnapshot(path, snapshot.Commitment)
+	assert.NoError(t, err)
+	assert.NoError(t, destination.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for key, value := range state {
+			assert.Equal(t, value, reader.Get(key), key)
+		}
+		for _, key := range excluded {
+			assert.Nil(t, reader.Get(key), key)
+		}
+		assert.Equal(t, "5", string(reader.Get("prunedHeight")))
+		assert.Nil(t, reader.Get("stateTreeInitialized"))
+		return nil
+	}))
+
+	_, err = importSnapshot(path, nil)
+	assert.EqualError(t, err, "Snapshot block has no StateRoot, so the snapshot commitment is required")
+
+	_, err = importSnapshot(path, helpers.RandomBytes(cryptography.HashSize))
+	assert.EqualError(t, err, "Snapshot commitment is not the expected one")
+
+	//a snapshot can be imported only in an empty store
+	store.StoreBlockchain = &store.Store{"destination", true, destination}
+	_, err = chain.ImportSnapshot(path, snapshot.Commitment)
+	assert.EqualError(t, err, "Snapshot can be imported only in an empty chain store")
+
+	//tampering an entry breaks the commitment
+	for _, entry := range snapshot.Entries {
+		if string(entry.Key) == "plainAccs:count" {
+			entry.Value = []byte("2")
+		}
+	}
+	tamperedPath := writeSnapshot(snapshot)
+
+	_, err = importSnapshot(tamperedPath, nil)
+	assert.EqualError(t, err, "Snapshot commitment doesn't match")
+	_, err = importSnapshot(tamperedPath, snapshot.Commitment)
+	assert.EqualError(t, err, "Snapshot commitment doesn't match")
+
+	//the block commits the StateRoot, so the commitment is optional
+	snapshot = exportSnapshot(createTestSnapshotBlock(t, config_block.BLOCK_VERSION_STATE_ROOT, stateRoot))
+
+	destination, err = importSnapshot(writeSnapshot(snapshot), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, destination.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, []byte{1}, reader.Get("stateTreeInitialized"))
+		root, err := state_tree.GetRoot(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, stateRoot, root)
+		return nil
+	}))
+
+	//the state was changed and the commitment recomputed by the one who created the snapshot
+	for _, entry := range snapshot.Entries {
+		if string(entry.Key) == "plainAccs:map:"+accountKey {
+			entry.Value = []byte{3, 2, 1}
+		}
+	}
+	snapshot.Commitment = snapshot.computeCommitment()
+
+	_, err = importSnapshot(writeSnapshot(snapshot), snapshot.Commitment)
+	assert.EqualError(t, err, "Snapshot state doesn't match the block StateRoot")
+
+	//the block is not the one of the snapshot hash
+	snapshot = exportSnapshot(createTestSnapshotBlock(t, config_block.BLOCK_VERSION_STATE_ROOT, helpers.RandomBytes(cryptography.HashSize)))
+	snapshot.Hash = helpers.RandomBytes(cryptography.HashSize)
+	snapshot.Commitment = snapshot.computeCommitment()
+
+	_, err = importSnapshot(writeSnapshot(snapshot), nil)
+	assert.EqualError(t, err, "Snapshot block was not found")
+}
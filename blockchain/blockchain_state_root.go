@@ -0,0 +1,108 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"strings"
+)
+
+// processBlockStateRoot fills the state root of the blocks forged by us and verifies it for the blocks received from the network.
+// The state root is not part of the kernel hash, so computing it after forging doesn't change the staking
+func (self *blockchain) processBlockStateRoot(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, calledByForging bool) error {
+
+	stateRoot, err := state_tree.GetRoot(writer)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(blkComplete.Block.StateRoot, stateRoot) {
+		return nil
+	}
+
+	if !calledByForging {
+		return errors.New("Block StateRoot doesn't match")
+	}
+
+	blkComplete.Block.StateRoot = stateRoot
+	blkComplete.Block.Bloom = nil
+	blkComplete.BloomBlkComplete = nil
+
+	if err = blkComplete.Block.BloomNow(); err != nil {
+		return err
+	}
+	return blkComplete.BloomNow()
+}
+
+// initializeStateTree builds the state tree for the chain stores created before the state root was introduced or imported from a snapshot without a StateRoot
+func (self *blockchain) initializeStateTree() error {
+
+	if config.NODE_CONSENSUS != config.NODE_CONSENSUS_TYPE_FULL {
+		return nil
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Exists("stateTreeInitialized") {
+			return
+		}
+
+		chainData := self.GetChainData().clone()
+
+		var stateRoot []byte
+		if stateRoot, err = buildStateTree(writer); err != nil {
+			return
+		}
+
+		if chainData.StateRoot != nil && !bytes.Equal(chainData.StateRoot, stateRoot) {
+			return errors.New("State tree doesn't match the chain StateRoot")
+		}
+
+		chainData.StateRoot = stateRoot
+		if err = chainData.saveBlockchain(writer); err != nil {
+			return
+		}
+		self.ChainData.Store(chainData)
+
+		writer.Put("stateTreeInitialized", []byte{1})
+		return
+	})
+}
+
+// buildStateTree inserts all the elements of the state hash maps in the state tree and returns its root
+func buildStateTree(writer store_db_interface.StoreDBTransactionInterface) ([]byte, error) {
+
+	iterable, ok := writer.(store_db_interface.StoreDBIterableTransactionInterface)
+	if !ok {
+		return nil, errors.New("Store doesn't support building the state tree")
+	}
+
+	type element struct {
+		key   string
+		value []byte
+	}
+
+	elements := []*element{}
+	iterable.Range(func(key string, value []byte) bool {
+		if isSnapshotStateKey(key) && strings.Contains(key, ":map:") {
+			elements = append(elements, &element{key, value})
+		}
+		return true
+	})
+
+	gui.GUI.Info("Building state tree for " + strconv.Itoa(len(elements)) + " elements")
+
+	for _, it := range elements {
+		if err := state_tree.Update(writer, it.key, it.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return state_tree.GetRoot(writer)
+}
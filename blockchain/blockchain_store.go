@@ -7,6 +7,7 @@ import (
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/config"
+	"pandora-pay/config/config_block"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store"
@@ -96,6 +97,9 @@ func (self *blockchain) removeBlockComplete(writer store_db_interface.StoreDBTra
 	writer.Delete("blockKernelHash_ByHeight" + string(blockHeightStr))
 
 	data := writer.Get("blockTxs" + blockHeightStr)
+	if data == nil {
+		return allTransactionsChanges, errors.New("Block transactions were pruned")
+	}
 	txHashes := [][]byte{} //32 byte
 
 	if err := msgpack.Unmarshal(data, &txHashes); err != nil {
@@ -126,7 +130,7 @@ func (self *blockchain) removeBlockComplete(writer store_db_interface.StoreDBTra
 	return allTransactionsChangesFinal, nil
 }
 
-func (self *blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
+func (self *blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, calledByForging bool, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
 
 	allTransactionsChanges2 := allTransactionsChanges
 
@@ -139,6 +143,12 @@ func (self *blockchain) saveBlockComplete(writer store_db_interface.StoreDBTrans
 		return allTransactionsChanges, err
 	}
 
+	if blkComplete.Block.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+		if err := self.processBlockStateRoot(writer, blkComplete, calledByForging); err != nil {
+			return allTransactionsChanges, err
+		}
+	}
+
 	writer.Put("block_ByHash"+string(blkComplete.Block.Bloom.Hash), helpers.SerializeToBytes(blkComplete.Block))
 	writer.Put("blockHash_ByHeight"+blockHeightStr, blkComplete.Block.Bloom.Hash)
 	writer.Put("blockKernelHash_ByHeight"+blockHeightStr, blkComplete.Block.Bloom.KernelHash)
@@ -32,6 +32,8 @@ type MempoolTransactionUpdate struct {
 	Tx                               *transaction.Transaction
 	IncludedInBlockchainNotification bool
 	Keys                             map[string]bool
+	Evicted                          bool                     //removed because the mempool was full
+	ReplacedBy                       *transaction.Transaction //removed because it was replaced by a higher fee transaction
 }
 
 type BlockchainUpdates struct {
@@ -43,6 +45,21 @@ type BlockchainUpdates struct {
 	BlockHash      []byte
 }
 
+type BlockchainBlockUpdate struct {
+	Height    uint64
+	Hash      []byte
+	Timestamp uint64
+	TxsCount  uint64
+}
+
+// BlockchainBlocksUpdate describes the blocks inserted in the chain. RemovedBlocksHashes are the blocks removed by a reorganization (ascending height)
+type BlockchainBlocksUpdate struct {
+	Height              uint64
+	Hash                []byte
+	InsertedBlocks      []*BlockchainBlockUpdate
+	RemovedBlocksHashes [][]byte
+}
+
 type BlockchainSolutionAnswer struct {
 	Err             error
 	ChainKernelHash []byte
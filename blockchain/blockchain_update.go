@@ -13,8 +13,9 @@ import (
 )
 
 type BlockchainDataUpdate struct {
-	Update        *BlockchainData
-	ChainSyncData *blockchain_sync.BlockchainSyncData
+	Update         *BlockchainData
+	ChainSyncData  *blockchain_sync.BlockchainSyncData
+	InsertedBlocks []*block_complete.BlockComplete //nil when it is not an update
 }
 
 type blockchainUpdate struct {
@@ -27,6 +28,7 @@ type blockchainUpdate struct {
 	insertedTxs            map[string]*transaction.Transaction
 	insertedTxsList        []*transaction.Transaction
 	insertedBlocks         []*block_complete.BlockComplete
+	removedBlocksHashes    [][]byte
 	calledByForging        bool
 	exceptSocketUUID       advanced_connection_types.UUID
 }
@@ -90,6 +92,7 @@ func (self *blockchainUpdatesQueue) executeUpdate(update *blockchainUpdate) (err
 	self.chain.UpdateNewChainDataUpdate.Broadcast(&BlockchainDataUpdate{
 		update.newChainData,
 		chainSyncData,
+		update.insertedBlocks,
 	})
 
 	return nil
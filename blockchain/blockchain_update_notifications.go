@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"bytes"
+	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/recovery"
@@ -19,6 +20,22 @@ func (queue *blockchainUpdatesQueue) processBlockchainUpdateNotifications() {
 			update := <-updatesNotificationsCn
 
 			queue.chain.UpdateSocketsSubscriptionsNotifications.Broadcast(update.dataStorage)
+
+			blocksUpdate := &blockchain_types.BlockchainBlocksUpdate{
+				update.newChainData.Height,
+				update.newChainData.Hash,
+				make([]*blockchain_types.BlockchainBlockUpdate, len(update.insertedBlocks)),
+				update.removedBlocksHashes,
+			}
+			for i, blkComplete := range update.insertedBlocks {
+				blocksUpdate.InsertedBlocks[i] = &blockchain_types.BlockchainBlockUpdate{
+					blkComplete.Block.Height,
+					blkComplete.Block.Bloom.Hash,
+					blkComplete.Block.Timestamp,
+					uint64(len(blkComplete.Txs)),
+				}
+			}
+			queue.chain.UpdateSocketsSubscriptionsBlocks.Broadcast(blocksUpdate)
 		}
 
 	})
@@ -1,6 +1,8 @@
 package block
 
 import (
+	"errors"
+	"pandora-pay/config/config_block"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
@@ -8,9 +10,10 @@ import (
 
 type Block struct {
 	*BlockHeader
-	MerkleHash     []byte      `json:"merkleHash" msgpack:"merkleHash"`          //32 byte
-	PrevHash       []byte      `json:"prevHash"  msgpack:"prevHash"`             //32 byte
-	PrevKernelHash []byte      `json:"prevKernelHash"  msgpack:"prevKernelHash"` //32 byte
+	MerkleHash     []byte      `json:"merkleHash" msgpack:"merkleHash"`                   //32 byte
+	StateRoot      []byte      `json:"stateRoot,omitempty" msgpack:"stateRoot,omitempty"` //32 byte, root of the state after including the block. Only from BLOCK_VERSION_STATE_ROOT
+	PrevHash       []byte      `json:"prevHash"  msgpack:"prevHash"`                      //32 byte
+	PrevKernelHash []byte      `json:"prevKernelHash"  msgpack:"prevKernelHash"`          //32 byte
 	Timestamp      uint64      `json:"timestamp" msgpack:"timestamp"`
 	StakingAmount  uint64      `json:"stakingAmount" msgpack:"stakingAmount"`
 	StakingNonce   []byte      `json:"stakingNonce" msgpack:"stakingNonce"` // 33 byte public key can also be found into the accounts tree
@@ -28,6 +31,14 @@ func (blk *Block) Validate() error {
 		return err
 	}
 
+	if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+		if len(blk.StateRoot) != cryptography.HashSize {
+			return errors.New("Block StateRoot is invalid")
+		}
+	} else if blk.StateRoot != nil {
+		return errors.New("Block StateRoot is not allowed")
+	}
+
 	return nil
 }
 
@@ -57,6 +68,9 @@ func (blk *Block) AdvancedSerialization(w *advanced_buffers.BufferWriter, kernel
 
 	if !kernelHash {
 		w.Write(blk.MerkleHash)
+		if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+			w.Write(blk.StateRoot)
+		}
 		w.Write(blk.PrevHash)
 	}
 
@@ -96,6 +110,11 @@ func (blk *Block) Deserialize(r *advanced_buffers.BufferReader) (err error) {
 	if blk.MerkleHash, err = r.ReadHash(); err != nil {
 		return
 	}
+	if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+		if blk.StateRoot, err = r.ReadHash(); err != nil {
+			return
+		}
+	}
 	if blk.PrevHash, err = r.ReadHash(); err != nil {
 		return
 	}
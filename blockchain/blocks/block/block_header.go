@@ -2,6 +2,7 @@ package block
 
 import (
 	"errors"
+	"pandora-pay/config/config_block"
 	"pandora-pay/helpers/advanced_buffers"
 )
 
@@ -11,7 +12,7 @@ type BlockHeader struct {
 }
 
 func (blockHeader *BlockHeader) Validate() error {
-	if blockHeader.Version != 0 {
+	if blockHeader.Version > config_block.BLOCK_VERSION_STATE_ROOT {
 		return errors.New("Invalid Block")
 	}
 	return nil
@@ -15,6 +15,11 @@ import (
 	"strings"
 )
 
+const (
+	ASSET_VERSION_INITIAL      uint64 = 0
+	ASSET_VERSION_PAUSE_FREEZE uint64 = 1 //adds Paused and Frozen
+)
+
 var regexAssetName = regexp.MustCompile("^([a-zA-Z0-9]+ )+[a-zA-Z0-9]+$|^[a-zA-Z0-9]+")
 var regexAssetTicker = regexp.MustCompile("^[A-Z0-9]+$") // only lowercase ascii is allowed. No space allowed
 var regexAssetDescription = regexp.MustCompile("[\\w|\\W]+")
@@ -30,6 +35,8 @@ type Asset struct {
 	CanChangeSupplyPublicKey bool   `json:"canChangeSupplyPublicKey,omitempty" msgpack:"canChangeSupplyPublicKey,omitempty"` //can change supply key
 	CanPause                 bool   `json:"canPause,omitempty" msgpack:"canPause,omitempty"`                                 //can pause (suspend transactions)
 	CanFreeze                bool   `json:"canFreeze,omitempty" msgpack:"canFreeze,omitempty"`                               //freeze supply changes
+	Paused                   bool   `json:"paused,omitempty" msgpack:"paused,omitempty"`                                     //transactions are suspended
+	Frozen                   bool   `json:"frozen,omitempty" msgpack:"frozen,omitempty"`                                     //supply can not be changed anymore
 	DecimalSeparator         byte   `json:"decimalSeparator,omitempty" msgpack:"decimalSeparator,omitempty"`
 	MaxSupply                uint64 `json:"maxSupply,omitempty" msgpack:"maxSupply,omitempty"`
 	Supply                   uint64 `json:"supply,omitempty" msgpack:"supply,omitempty"`
@@ -62,32 +69,27 @@ func (asset *Asset) GetIndex() uint64 {
 }
 
 func (asset *Asset) Validate() error {
+	if asset.Version > ASSET_VERSION_PAUSE_FREEZE {
+		return errors.New("asset version is invalid")
+	}
+	if asset.Version < ASSET_VERSION_PAUSE_FREEZE && (asset.Paused || asset.Frozen) {
+		return errors.New("asset version doesn't support paused and frozen")
+	}
+
 	if asset.DecimalSeparator > config_assets.ASSETS_DECIMAL_SEPARATOR_MAX_BYTE {
 		return errors.New("asset decimal separator is invalid")
 	}
 
-	if len(asset.Name) > 15 || len(asset.Name) < 3 {
-		return errors.New("asset name length is invalid")
+	if err := ValidateDetails(asset.Name, asset.Description, asset.Data); err != nil {
+		return err
 	}
+
 	if len(asset.Ticker) > 10 || len(asset.Ticker) < 2 {
 		return errors.New("asset ticker length is invalid")
 	}
-	if len(asset.Description) > 1024 {
-		return errors.New("asset description length is invalid")
-	}
-	if len(asset.Data) > 5120 {
-		return errors.New("asset data length is invalid")
-	}
-
-	if !regexAssetName.MatchString(asset.Name) {
-		return errors.New("Asset name is invalid")
-	}
 	if !regexAssetTicker.MatchString(asset.Ticker) {
 		return errors.New("Asset ticker is invalid")
 	}
-	if !regexAssetDescription.MatchString(asset.Description) {
-		return errors.New("Asset description is invalid")
-	}
 
 	if len(asset.PublicKeyHash) != cryptography.PublicKeyHashSize {
 		return errors.New("Asset Public key is invalid")
@@ -120,6 +122,26 @@ func (asset *Asset) Validate() error {
 	return nil
 }
 
+// ValidateDetails verifies the fields that can be changed later on by the Update Public Key
+func ValidateDetails(name, description string, data []byte) error {
+	if len(name) > 15 || len(name) < 3 {
+		return errors.New("asset name length is invalid")
+	}
+	if len(description) > 1024 {
+		return errors.New("asset description length is invalid")
+	}
+	if len(data) > 5120 {
+		return errors.New("asset data length is invalid")
+	}
+	if !regexAssetName.MatchString(name) {
+		return errors.New("Asset name is invalid")
+	}
+	if !regexAssetDescription.MatchString(description) {
+		return errors.New("Asset description is invalid")
+	}
+	return nil
+}
+
 func (asset *Asset) ConvertToUnits(amount float64) (uint64, error) {
 	COIN_DENOMINATION := math.Pow10(int(asset.DecimalSeparator))
 	if amount < float64(math.MaxUint64)/COIN_DENOMINATION {
@@ -152,6 +174,9 @@ func (asset *Asset) AddSupply(sign bool, amount uint64) error {
 	if bytes.Equal(asset.SupplyPublicKey, config_coins.BURN_PUBLIC_KEY) {
 		return errors.New("BURN PUBLIC KEY")
 	}
+	if asset.Frozen {
+		return errors.New("Asset supply is frozen")
+	}
 
 	if sign {
 		if !asset.CanMint {
@@ -183,6 +208,10 @@ func (asset *Asset) Serialize(w *advanced_buffers.BufferWriter) {
 	w.WriteBool(asset.CanChangeSupplyPublicKey)
 	w.WriteBool(asset.CanPause)
 	w.WriteBool(asset.CanFreeze)
+	if asset.Version >= ASSET_VERSION_PAUSE_FREEZE {
+		w.WriteBool(asset.Paused)
+		w.WriteBool(asset.Frozen)
+	}
 	w.WriteByte(asset.DecimalSeparator)
 
 	w.WriteUvarint(asset.MaxSupply)
@@ -231,6 +260,14 @@ func (asset *Asset) Deserialize(r *advanced_buffers.BufferReader) (err error) {
 	if asset.CanFreeze, err = r.ReadBool(); err != nil {
 		return
 	}
+	if asset.Version >= ASSET_VERSION_PAUSE_FREEZE {
+		if asset.Paused, err = r.ReadBool(); err != nil {
+			return
+		}
+		if asset.Frozen, err = r.ReadBool(); err != nil {
+			return
+		}
+	}
 	if asset.DecimalSeparator, err = r.ReadByte(); err != nil {
 		return
 	}
@@ -0,0 +1,117 @@
+package asset
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+func createTestAsset(version uint64) *Asset {
+	ast := NewAsset(helpers.RandomBytes(cryptography.PublicKeyHashSize), 0)
+	ast.Version = version
+	ast.CanMint = true
+	ast.CanBurn = true
+	ast.DecimalSeparator = 5
+	ast.MaxSupply = 1000
+	ast.Supply = 100
+	ast.UpdatePublicKey = helpers.RandomBytes(cryptography.PublicKeySize)
+	ast.SupplyPublicKey = helpers.RandomBytes(cryptography.PublicKeySize)
+	ast.Name = "My Asset"
+	ast.Ticker = "AST"
+	ast.Description = "My simple Asset"
+	ast.setIdentification()
+	return ast
+}
+
+// createTestAssetGolden returns the serialization of the asset of version 0 in the baseline layout, followed by the Paused and Frozen bytes from version 1
+func createTestAssetGolden(version byte, updatePublicKey, supplyPublicKey []byte) []byte {
+
+	golden := []byte{version, 1, 1, 0, 0, 1, 1, 0} //version, canUpgrade, canMint, canBurn, canChangeUpdatePublicKey, canChangeSupplyPublicKey, canPause, canFreeze
+	if version >= byte(ASSET_VERSION_PAUSE_FREEZE) {
+		golden = append(golden, 0, 1) //paused, frozen
+	}
+	golden = append(golden, 5, 0xe8, 0x07, 100) //decimalSeparator, maxSupply 1000, supply 100
+	golden = append(golden, updatePublicKey...)
+	golden = append(golden, supplyPublicKey...)
+	golden = append(golden, 8)
+	golden = append(golden, "My Asset"...)
+	golden = append(golden, 3)
+	golden = append(golden, "AST"...)
+	golden = append(golden, 4)
+	golden = append(golden, "Desc"...)
+	return append(golden, 0) //data
+}
+
+func TestAsset_SerializeVersions(t *testing.T) {
+
+	updatePublicKey := bytes.Repeat([]byte{2}, cryptography.PublicKeySize)
+	supplyPublicKey := bytes.Repeat([]byte{3}, cryptography.PublicKeySize)
+
+	initial := NewAsset(helpers.RandomBytes(cryptography.PublicKeyHashSize), 0)
+	initial.CanUpgrade = true
+	initial.CanMint = true
+	initial.CanChangeSupplyPublicKey = true
+	initial.CanPause = true
+	initial.DecimalSeparator = 5
+	initial.MaxSupply = 1000
+	initial.Supply = 100
+	initial.UpdatePublicKey = updatePublicKey
+	initial.SupplyPublicKey = supplyPublicKey
+	initial.Name = "My Asset"
+	initial.Ticker = "AST"
+	initial.Description = "Desc"
+	initial.Data = []byte{}
+	initial.setIdentification()
+
+	//version 0 keeps the initial format, including the pause and freeze permissions
+	assert.NoError(t, initial.Validate())
+	golden := createTestAssetGolden(0, updatePublicKey, supplyPublicKey)
+	assert.Equal(t, golden, helpers.SerializeToBytes(initial))
+
+	deserialized := NewAsset(initial.PublicKeyHash, 0)
+	assert.NoError(t, deserialized.Deserialize(advanced_buffers.NewBufferReader(golden)))
+	assert.Equal(t, initial, deserialized)
+
+	initial.Frozen = true
+	assert.Error(t, initial.Validate(), "version 0 can't be frozen")
+	initial.Frozen = false
+
+	pauseFreeze := NewAsset(initial.PublicKeyHash, 0)
+	*pauseFreeze = *initial
+	pauseFreeze.Version = ASSET_VERSION_PAUSE_FREEZE
+	pauseFreeze.Frozen = true
+	assert.NoError(t, pauseFreeze.Validate())
+
+	golden = createTestAssetGolden(byte(ASSET_VERSION_PAUSE_FREEZE), updatePublicKey, supplyPublicKey)
+	assert.Equal(t, golden, helpers.SerializeToBytes(pauseFreeze))
+
+	deserialized = NewAsset(pauseFreeze.PublicKeyHash, 0)
+	assert.NoError(t, deserialized.Deserialize(advanced_buffers.NewBufferReader(golden)))
+	assert.Equal(t, pauseFreeze, deserialized)
+
+	pauseFreeze.Version = ASSET_VERSION_PAUSE_FREEZE + 1
+	assert.Error(t, pauseFreeze.Validate())
+}
+
+func TestAsset_AddSupply(t *testing.T) {
+
+	ast := createTestAsset(ASSET_VERSION_PAUSE_FREEZE)
+
+	assert.NoError(t, ast.AddSupply(false, 40))
+	assert.Equal(t, uint64(60), ast.Supply)
+
+	assert.Error(t, ast.AddSupply(false, 61), "supply would become negative")
+	assert.Equal(t, uint64(60), ast.Supply)
+
+	ast.CanBurn = false
+	assert.Error(t, ast.AddSupply(false, 1))
+	ast.CanBurn = true
+
+	ast.Frozen = true
+	assert.Error(t, ast.AddSupply(false, 1))
+	assert.Error(t, ast.AddSupply(true, 1))
+	assert.Equal(t, uint64(60), ast.Supply)
+}
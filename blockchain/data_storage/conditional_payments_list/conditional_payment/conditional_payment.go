@@ -6,6 +6,11 @@ import (
 	"pandora-pay/helpers/advanced_buffers"
 )
 
+const (
+	CONDITIONAL_PAYMENT_VERSION_MULTISIG uint64 = 0
+	CONDITIONAL_PAYMENT_VERSION_HASHLOCK uint64 = 1
+)
+
 type ConditionalPayment struct {
 	Key                []byte   `json:"-" msgpack:"-"` //hashmap key
 	BlockHeight        uint64   `json:"-" msgpack:"-"` //collection height
@@ -22,6 +27,7 @@ type ConditionalPayment struct {
 	SenderAmounts      [][]byte `json:"senderAmounts" msgpack:"senderAmounts"`
 	MultisigThreshold  byte     `json:"multisigThreshold" msgpack:"multisigThreshold"`
 	MultisigPublicKeys [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
+	Hashlock           []byte   `json:"hashlock,omitempty" msgpack:"hashlock,omitempty"` //SHA256 of the preimage, only for CONDITIONAL_PAYMENT_VERSION_HASHLOCK
 }
 
 func (this *ConditionalPayment) IsDeletable() bool {
@@ -46,7 +52,7 @@ func (this *ConditionalPayment) GetIndex() uint64 {
 
 func (this *ConditionalPayment) Validate() error {
 	switch this.Version {
-	case 0:
+	case CONDITIONAL_PAYMENT_VERSION_MULTISIG, CONDITIONAL_PAYMENT_VERSION_HASHLOCK:
 	default:
 		return errors.New("Invalid Version")
 	}
@@ -75,8 +81,18 @@ func (this *ConditionalPayment) Validate() error {
 				return errors.New("PendingStake PublicKey size is invalid")
 			}
 		}
-		if this.MultisigThreshold == 0 || int(this.MultisigThreshold) > len(this.MultisigPublicKeys) {
-			return errors.New("Invalid Multisig threshold")
+		switch this.Version {
+		case CONDITIONAL_PAYMENT_VERSION_MULTISIG:
+			if this.MultisigThreshold == 0 || int(this.MultisigThreshold) > len(this.MultisigPublicKeys) {
+				return errors.New("Invalid Multisig threshold")
+			}
+		case CONDITIONAL_PAYMENT_VERSION_HASHLOCK:
+			if len(this.Hashlock) != cryptography.HashlockSize {
+				return errors.New("Invalid Hashlock")
+			}
+			if this.MultisigThreshold != 0 || len(this.MultisigPublicKeys) > 0 {
+				return errors.New("Hashlock conditional payment should not have a multisig")
+			}
 		}
 	}
 
@@ -111,10 +127,14 @@ func (this *ConditionalPayment) Serialize(w *advanced_buffers.BufferWriter) {
 		for _, p := range this.SenderAmounts {
 			w.Write(p)
 		}
-		w.WriteByte(this.MultisigThreshold)
-		w.WriteByte(byte(len(this.MultisigPublicKeys)))
-		for _, pb := range this.MultisigPublicKeys {
-			w.Write(pb)
+		if this.Version == CONDITIONAL_PAYMENT_VERSION_HASHLOCK {
+			w.Write(this.Hashlock)
+		} else {
+			w.WriteByte(this.MultisigThreshold)
+			w.WriteByte(byte(len(this.MultisigPublicKeys)))
+			for _, pb := range this.MultisigPublicKeys {
+				w.Write(pb)
+			}
 		}
 	}
 }
@@ -171,6 +191,11 @@ func (this *ConditionalPayment) Deserialize(r *advanced_buffers.BufferReader) (e
 			}
 		}
 
+		if this.Version == CONDITIONAL_PAYMENT_VERSION_HASHLOCK {
+			this.Hashlock, err = r.ReadBytes(cryptography.HashlockSize)
+			return
+		}
+
 		if this.MultisigThreshold, err = r.ReadByte(); err != nil {
 			return
 		}
@@ -197,6 +222,6 @@ func NewConditionalPayment(key []byte, index uint64, blockHeight uint64) *Condit
 		index,
 		0,
 		nil, 0,
-		false, nil, false, nil, nil, nil, nil, 0, nil,
+		false, nil, false, nil, nil, nil, nil, 0, nil, nil,
 	}
 }
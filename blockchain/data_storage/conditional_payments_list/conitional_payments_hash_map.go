@@ -1,10 +1,16 @@
 package conditional_payments_list
 
 import (
+	"bytes"
+	"errors"
 	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store/hash_map"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
+	"strings"
 )
 
 type ConditionalPaymentsHashMap struct {
@@ -12,6 +18,105 @@ type ConditionalPaymentsHashMap struct {
 	BlockHeight uint64
 }
 
+func GetConditionalPaymentKey(txId []byte, payloadIndex byte) string {
+	return string(txId) + "_" + strconv.Itoa(int(payloadIndex))
+}
+
+// GetMultisigIndex returns the keys of the conditional payments having publicKey as multisig member
+func GetMultisigIndex(reader store_db_interface.StoreDBTransactionInterface, publicKey []byte) (keys [][]byte, err error) {
+	data := reader.Get("conditionalPayments:multisig:" + string(publicKey))
+	if data == nil {
+		return
+	}
+	err = msgpack.Unmarshal(data, &keys)
+	return
+}
+
+func setMultisigIndex(writer store_db_interface.StoreDBTransactionInterface, publicKey []byte, keys [][]byte) error {
+	if len(keys) == 0 {
+		writer.Delete("conditionalPayments:multisig:" + string(publicKey))
+		return nil
+	}
+	data, err := msgpack.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	writer.Put("conditionalPayments:multisig:"+string(publicKey), data)
+	return nil
+}
+
+func addMultisigIndex(writer store_db_interface.StoreDBTransactionInterface, key []byte, multisigPublicKeys [][]byte) (err error) {
+
+	if len(multisigPublicKeys) == 0 {
+		return
+	}
+
+	var keys [][]byte
+	for _, publicKey := range multisigPublicKeys {
+		if keys, err = GetMultisigIndex(writer, publicKey); err != nil {
+			return
+		}
+		if err = setMultisigIndex(writer, publicKey, append(keys, key)); err != nil {
+			return
+		}
+	}
+	writer.Put("conditionalPayments:multisigKeys:"+string(key), bytes.Join(multisigPublicKeys, nil))
+
+	return
+}
+
+// ReindexMultisig indexes by their multisig members the conditional payments stored before the multisig index was introduced
+func ReindexMultisig(writer store_db_interface.StoreDBTransactionInterface) (count int, err error) {
+
+	iterable, ok := writer.(store_db_interface.StoreDBIterableTransactionInterface)
+	if !ok {
+		return 0, errors.New("Store doesn't support building the multisig index")
+	}
+
+	type element struct {
+		key         string
+		blockHeight uint64
+	}
+
+	elements := []*element{}
+	iterable.Range(func(key string, value []byte) bool {
+		if strings.HasPrefix(key, "conditionalPayments:all:") {
+			blockHeight, err2 := strconv.ParseUint(string(value), 10, 64)
+			if err2 != nil {
+				err = err2
+				return false
+			}
+			elements = append(elements, &element{strings.TrimPrefix(key, "conditionalPayments:all:"), blockHeight})
+		}
+		return true
+	})
+	if err != nil {
+		return
+	}
+
+	for _, it := range elements {
+
+		if writer.Exists("conditionalPayments:multisigKeys:" + it.key) { //already indexed
+			continue
+		}
+
+		var condPayment *conditional_payment.ConditionalPayment
+		if condPayment, err = NewConditionalPaymentsHashMap(writer, it.blockHeight).Get(it.key); err != nil {
+			return
+		}
+		if condPayment == nil || len(condPayment.MultisigPublicKeys) == 0 {
+			continue
+		}
+
+		if err = addMultisigIndex(writer, []byte(it.key), condPayment.MultisigPublicKeys); err != nil {
+			return
+		}
+		count++
+	}
+
+	return
+}
+
 func NewConditionalPaymentsHashMap(tx store_db_interface.StoreDBTransactionInterface, blockHeight uint64) (this *ConditionalPaymentsHashMap) {
 
 	this = &ConditionalPaymentsHashMap{
@@ -29,7 +134,9 @@ func NewConditionalPaymentsHashMap(tx store_db_interface.StoreDBTransactionInter
 		}
 
 		this.Tx.Put("conditionalPayments:all:"+string(key), []byte(strconv.FormatUint(committed.Element.BlockHeight, 10)))
-		return
+
+		//index the payment by its multisig members
+		return addMultisigIndex(this.Tx, key, committed.Element.MultisigPublicKeys)
 	}
 
 	this.HashMap.DeletedEvent = func(key []byte) (err error) {
@@ -38,6 +145,27 @@ func NewConditionalPaymentsHashMap(tx store_db_interface.StoreDBTransactionInter
 		}
 
 		this.Tx.Delete("conditionalPayments:all:" + string(key))
+
+		if data := helpers.CloneBytes(this.Tx.Get("conditionalPayments:multisigKeys:" + string(key))); data != nil {
+			var keys [][]byte
+			for i := 0; i+cryptography.PublicKeySize <= len(data); i += cryptography.PublicKeySize {
+				publicKey := data[i : i+cryptography.PublicKeySize]
+				if keys, err = GetMultisigIndex(this.Tx, publicKey); err != nil {
+					return
+				}
+				for j := range keys {
+					if bytes.Equal(keys[j], key) {
+						keys = append(keys[:j], keys[j+1:]...)
+						break
+					}
+				}
+				if err = setMultisigIndex(this.Tx, publicKey, keys); err != nil {
+					return
+				}
+			}
+			this.Tx.Delete("conditionalPayments:multisigKeys:" + string(key))
+		}
+
 		return
 	}
 
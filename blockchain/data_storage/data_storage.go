@@ -215,7 +215,7 @@ func (dataStorage *DataStorage) ProcessPendingStakes(blockHeight uint64) error {
 	return nil
 }
 
-func (dataStorage *DataStorage) AddConditionalPayment(blockHeight uint64, txId []byte, payloadIndex byte, asset []byte, defaultResolution bool, parity bool, publicKeyList [][]byte, echangesAll []*crypto.ElGamal, multisigThreshold byte, multisigPublicKeys [][]byte) error {
+func (dataStorage *DataStorage) AddConditionalPayment(blockHeight uint64, txId []byte, payloadIndex byte, asset []byte, defaultResolution bool, parity bool, publicKeyList [][]byte, echangesAll []*crypto.ElGamal, multisigThreshold byte, multisigPublicKeys [][]byte, hashlock []byte) error {
 
 	for i, publicKey := range publicKeyList {
 		reg, err := dataStorage.Regs.Get(string(publicKey))
@@ -267,8 +267,13 @@ func (dataStorage *DataStorage) AddConditionalPayment(blockHeight uint64, txId [
 		}
 	}
 
-	condPayment.MultisigThreshold = multisigThreshold
-	condPayment.MultisigPublicKeys = multisigPublicKeys
+	if hashlock != nil {
+		condPayment.Version = conditional_payment.CONDITIONAL_PAYMENT_VERSION_HASHLOCK
+		condPayment.Hashlock = hashlock
+	} else {
+		condPayment.MultisigThreshold = multisigThreshold
+		condPayment.MultisigPublicKeys = multisigPublicKeys
+	}
 
 	return conditionalPaymentsMap.Update(key, condPayment)
 }
@@ -328,6 +333,7 @@ func (dataStorage *DataStorage) ProceedConditionalPayment(resolution bool, condP
 	condPayment.ReceiverAmounts = make([][]byte, 0)
 	condPayment.MultisigPublicKeys = make([][]byte, 0)
 	condPayment.MultisigThreshold = 0
+	condPayment.Hashlock = nil
 
 	return nil
 }
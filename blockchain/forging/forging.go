@@ -13,12 +13,15 @@ import (
 	"pandora-pay/helpers/recovery"
 )
 
+var logger = gui.NewSubsystemLogger("forging")
+
 type forging struct {
 	Wallet             *forgingWallet
 	started            *abool.AtomicBool
 	forgingThread      *forgingThread
 	nextBlockCreatedCn <-chan *forging_block_work.ForgingWork
 	forgingSolutionCn  chan<- *blockchain_types.BlockchainSolution
+	ForgedBlocks       *multicast.MulticastChannel[*ForgedBlock] //blocks forged by this node and accepted in the chain
 }
 
 var Forging *forging
@@ -29,7 +32,7 @@ func (self *forging) InitializeForging(createForgingTransactions func(*block_com
 	self.Wallet.updateNewChainUpdate = updateNewChainUpdate
 	self.forgingSolutionCn = forgingSolutionCn
 
-	self.forgingThread = createForgingThread(config.CPU_THREADS, createForgingTransactions, self.forgingSolutionCn, self.nextBlockCreatedCn)
+	self.forgingThread = createForgingThread(config.CPU_THREADS, createForgingTransactions, self.forgingSolutionCn, self.nextBlockCreatedCn, self.ForgedBlocks)
 	self.Wallet.workersCreatedCn = self.forgingThread.workersCreatedCn
 	self.Wallet.workersDestroyedCn = self.forgingThread.workersDestroyedCn
 
@@ -42,7 +45,7 @@ func (self *forging) InitializeForging(createForgingTransactions func(*block_com
 func (self *forging) StartForging() bool {
 
 	if config.NODE_CONSENSUS != config.NODE_CONSENSUS_TYPE_FULL {
-		gui.GUI.Warning(`Staking was not started as "--node-consensus=full" is missing`)
+		logger.Warn(`Staking was not started as "--node-consensus=full" is missing`)
 		return false
 	}
 
@@ -83,6 +86,7 @@ func Initialize() error {
 		},
 		abool.New(),
 		nil, nil, nil,
+		multicast.NewMulticastChannel[*ForgedBlock](),
 	}
 	Forging.Wallet.forging = Forging
 
@@ -0,0 +1,9 @@
+package forging
+
+import "pandora-pay/helpers/metrics"
+
+var (
+	forgingHashesMetric          = metrics.NewCounter("pandora_forging_hashes_total", "Number of forging attempts")
+	forgingHashesPerSecondMetric = metrics.NewGauge("pandora_forging_hashes_per_second", "Forging attempts in the last second")
+	forgedBlocksMetric           = metrics.NewCounter("pandora_forging_blocks_total", "Number of blocks forged by this node")
+)
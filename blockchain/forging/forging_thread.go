@@ -2,7 +2,6 @@ package forging
 
 import (
 	"bytes"
-	"fmt"
 	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/forging/forging_block_work"
@@ -10,6 +9,7 @@ import (
 	"pandora-pay/gui"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/multicast"
 	"pandora-pay/helpers/recovery"
 	"pandora-pay/mempool"
 	"strconv"
@@ -17,6 +17,13 @@ import (
 	"time"
 )
 
+type ForgedBlock struct {
+	PublicKey []byte
+	Height    uint64
+	Hash      []byte
+	Reward    uint64
+}
+
 type forgingThread struct {
 	threads                   int                                         //number of threads
 	solutionCn                chan<- *blockchain_types.BlockchainSolution //broadcasting that a solution thread was received
@@ -26,6 +33,7 @@ type forgingThread struct {
 	workersDestroyedCn        chan struct{}
 	lastPrevKernelHash        *generics.Value[[]byte]
 	createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error)
+	forgedBlocks              *multicast.MulticastChannel[*ForgedBlock]
 }
 
 func (self *forgingThread) stopForging() {
@@ -50,11 +58,15 @@ func (self *forgingThread) startForging() {
 		for {
 
 			s := ""
+			total := uint64(0)
 			for i := 0; i < self.threads; i++ {
 				hashesPerSecond := atomic.SwapUint32(&self.workers[i].hashes, 0)
 				s += strconv.FormatUint(uint64(hashesPerSecond), 10) + " "
+				total += uint64(hashesPerSecond)
 			}
 			gui.GUI.InfoUpdate("Hashes/s", s)
+			forgingHashesMetric.Add(total)
+			forgingHashesPerSecondMetric.Set(float64(total))
 
 			time.Sleep(time.Second)
 		}
@@ -76,9 +88,9 @@ func (self *forgingThread) startForging() {
 			}
 
 			if newKernelHash, err = self.publishSolution(solution); err != nil {
-				gui.GUI.Error(fmt.Errorf("Error publishing solution: %d error: %s ", solution.blkComplete.Height, err))
+				logger.Error("Error publishing solution", "height", solution.blkComplete.Height, "err", err)
 			} else {
-				gui.GUI.Info(fmt.Errorf("Block was forged! %d ", solution.blkComplete.Height))
+				logger.Info("Block was forged!", "height", solution.blkComplete.Height)
 				self.lastPrevKernelHash.Store(newKernelHash)
 			}
 
@@ -139,10 +151,24 @@ func (self *forgingThread) publishSolution(solution *ForgingSolution) ([]byte, e
 	}
 
 	res := <-result
+	if res.Err == nil {
+		_, reward, err := blockchain_types.ComputeBlockReward(newBlk.Height, txs)
+		if err != nil {
+			return nil, err
+		}
+		forgedBlocksMetric.Inc()
+		self.forgedBlocks.Broadcast(&ForgedBlock{
+			solution.publicKey,
+			newBlk.Height,
+			newBlk.Bloom.Hash,
+			reward,
+		})
+	}
+
 	return res.ChainKernelHash, res.Err
 }
 
-func createForgingThread(threads int, createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error), solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork) *forgingThread {
+func createForgingThread(threads int, createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error), solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork, forgedBlocks *multicast.MulticastChannel[*ForgedBlock]) *forgingThread {
 	return &forgingThread{
 		threads,
 		solutionCn,
@@ -152,5 +178,6 @@ func createForgingThread(threads int, createForgingTransactions func(*block_comp
 		make(chan struct{}),
 		&generics.Value[[]byte]{},
 		createForgingTransactions,
+		forgedBlocks,
 	}
 }
@@ -14,7 +14,6 @@ import (
 	"pandora-pay/config/config_coins"
 	"pandora-pay/config/config_forging"
 	"pandora-pay/cryptography/crypto"
-	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/multicast"
 	"pandora-pay/store"
@@ -226,7 +225,7 @@ func (self *forgingWallet) runProcessUpdates() {
 					return
 				}(); err != nil {
 					self.deleteAccount(key)
-					gui.GUI.Error(err)
+					logger.Error("Error loading forging account", "err", err)
 				}
 
 			}
@@ -261,7 +260,7 @@ func (self *forgingWallet) runProcessUpdates() {
 
 					} else if v.Stored == "delete" {
 						self.deleteAccount(k)
-						gui.GUI.Error("Account was deleted from Forging")
+						logger.Warn("Account was deleted from Forging", "key", k)
 					}
 
 				}
@@ -11,7 +11,6 @@ import (
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/bn256"
 	"pandora-pay/cryptography/crypto"
-	"pandora-pay/gui"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"strconv"
@@ -240,7 +239,7 @@ func (worker *ForgingWorkerThread) forge() {
 
 						requireStakingAmount := new(big.Int).Div(new(big.Int).SetBytes(kernelHash), work.Target)
 
-						gui.GUI.Log("forged", worker.index, " -> ", work.BlkHeight, work.BlkComplete.PrevHash, address.walletAdr.decryptedStakingBalance)
+						logger.Debug("Forged", "worker", worker.index, "height", work.BlkHeight, "prevHash", work.BlkComplete.PrevHash, "stakingBalance", address.walletAdr.decryptedStakingBalance)
 
 						solution := &ForgingSolution{
 							localTimestamp,
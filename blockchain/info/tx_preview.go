@@ -56,6 +56,15 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 				txBaseExtra.PayloadIndex,
 				txBaseExtra.Resolution,
 			}
+		case transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaimConditionalPayment)
+
+			previewBase.Extra = &TxPreviewSimpleExtraClaimConditionalPayment{
+				txBaseExtra.TxId,
+				txBaseExtra.PayloadIndex,
+				txBaseExtra.Preimage,
+			}
 		}
 
 		base = previewBase
@@ -87,6 +96,9 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment)
 				payloadExtra = &TxPreviewZetherPayloadExtraPayToScript{txPayloadExtra.Deadline, txPayloadExtra.DefaultResolution, txPayloadExtra.MultisigThreshold}
+			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock)
+				payloadExtra = &TxPreviewZetherPayloadExtraPayToHashlock{txPayloadExtra.Deadline, txPayloadExtra.Hashlock}
 			}
 
 			payloads[i] = &TxPreviewZetherPayload{
@@ -11,6 +11,12 @@ type TxPreviewSimpleExtraResolutionConditionalPayment struct {
 	Resolution   bool   `json:"resolution" msgpack:"resolution"`
 }
 
+type TxPreviewSimpleExtraClaimConditionalPayment struct {
+	TxId         []byte `json:"txId" msgpack:"txId"`
+	PayloadIndex byte   `json:"payloadIndex" msgpack:"payloadIndex"`
+	Preimage     []byte `json:"preimage" msgpack:"preimage"`
+}
+
 type TxPreviewSimple struct {
 	TxScript    transaction_simple.ScriptType           `json:"txScript" msgpack:"txScript"`
 	DataVersion transaction_data.TransactionDataVersion `json:"dataVersion" msgpack:"dataVersion"`
@@ -21,6 +21,11 @@ type TxPreviewZetherPayloadExtraPayToScript struct {
 	Threshold         byte   `json:"threshold" msgpack:"threshold"`
 }
 
+type TxPreviewZetherPayloadExtraPayToHashlock struct {
+	Deadline uint64 `json:"deadline" msgpack:"deadline"`
+	Hashlock []byte `json:"hashlock" msgpack:"hashlock"`
+}
+
 type TxPreviewZetherPayload struct {
 	PayloadScript transaction_zether_payload_script.PayloadScriptType `json:"payloadScript" msgpack:"payloadScript"`
 	Asset         []byte                                              `json:"asset" msgpack:"asset"`
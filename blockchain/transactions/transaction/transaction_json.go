@@ -69,6 +69,12 @@ type json_Only_TransactionSimpleExtraResolutionConditionalPayment struct {
 	Signatures         [][]byte `json:"signatures"`
 }
 
+type json_Only_TransactionSimpleExtraClaimConditionalPayment struct {
+	TxId         []byte `json:"txId"`
+	PayloadIndex byte   `json:"payloadIndex"`
+	Preimage     []byte `json:"preimage"`
+}
+
 type json_Only_TransactionZether struct {
 	ChainHeight     uint64                          `json:"chainHeight"  msgpack:"chainHeight"`
 	ChainKernelHash []byte                          `json:"chainKernelHash"  msgpack:"chainKernelHash"`
@@ -111,6 +117,46 @@ type json_Only_TransactionZetherPayloadExtraConditionalPayment struct {
 	MultisigPublicKeys [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
 }
 
+type json_Only_TransactionZetherPayloadExtraConditionalPaymentHashlock struct {
+	Deadline uint64 `json:"deadline" msgpack:"deadline"`
+	Hashlock []byte `json:"hashlock" msgpack:"hashlock"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease struct {
+	AssetSupplyPublicKey []byte `json:"assetSupplyPublicKey"  msgpack:"assetSupplyPublicKey"`
+	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetPause struct {
+	AssetId              []byte `json:"assetId"  msgpack:"assetId"`
+	Paused               bool   `json:"paused"  msgpack:"paused"`
+	AssetUpdatePublicKey []byte `json:"assetUpdatePublicKey"  msgpack:"assetUpdatePublicKey"`
+	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetFreeze struct {
+	AssetId              []byte `json:"assetId"  msgpack:"assetId"`
+	AssetUpdatePublicKey []byte `json:"assetUpdatePublicKey"  msgpack:"assetUpdatePublicKey"`
+	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetChangePublicKey struct {
+	AssetId               []byte `json:"assetId"  msgpack:"assetId"`
+	ChangeSupplyPublicKey bool   `json:"changeSupplyPublicKey"  msgpack:"changeSupplyPublicKey"`
+	NewPublicKey          []byte `json:"newPublicKey"  msgpack:"newPublicKey"`
+	AssetUpdatePublicKey  []byte `json:"assetUpdatePublicKey"  msgpack:"assetUpdatePublicKey"`
+	AssetSignature        []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetUpdate struct {
+	AssetId              []byte `json:"assetId"  msgpack:"assetId"`
+	Name                 string `json:"name"  msgpack:"name"`
+	Description          string `json:"description"  msgpack:"description"`
+	Data                 []byte `json:"data"  msgpack:"data"`
+	AssetUpdatePublicKey []byte `json:"assetUpdatePublicKey"  msgpack:"assetUpdatePublicKey"`
+	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
 type json_Only_TransactionZetherStatement struct {
 	RingSize      int      `json:"ringSize"  msgpack:"ringSize"`
 	CLn           [][]byte `json:"cLn"  msgpack:"cLn"`
@@ -193,6 +239,13 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 				extra.MultisigPublicKeys,
 				extra.Signatures,
 			}
+		case transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaimConditionalPayment)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraClaimConditionalPayment{
+				extra.TxId,
+				extra.PayloadIndex,
+				extra.Preimage,
+			}
 		default:
 			return nil, errors.New("Invalid simple.TxScript")
 		}
@@ -278,6 +331,52 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 					payloadExtra.MultisigThreshold,
 					payloadExtra.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock)
+				extra = &json_Only_TransactionZetherPayloadExtraConditionalPaymentHashlock{
+					payloadExtra.Deadline,
+					payloadExtra.Hashlock,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease{
+					payloadExtra.AssetSupplyPublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_PAUSE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetPause)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetPause{
+					payloadExtra.AssetId,
+					payloadExtra.Paused,
+					payloadExtra.AssetUpdatePublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_FREEZE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetFreeze)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetFreeze{
+					payloadExtra.AssetId,
+					payloadExtra.AssetUpdatePublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetChangePublicKey)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetChangePublicKey{
+					payloadExtra.AssetId,
+					payloadExtra.ChangeSupplyPublicKey,
+					payloadExtra.NewPublicKey,
+					payloadExtra.AssetUpdatePublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetUpdate{
+					payloadExtra.AssetId,
+					payloadExtra.Name,
+					payloadExtra.Description,
+					payloadExtra.Data,
+					payloadExtra.AssetUpdatePublicKey,
+					payloadExtra.AssetSignature,
+				}
 			default:
 				return nil, errors.New("Invalid zether.TxScript")
 			}
@@ -410,6 +509,17 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 				extraJson.MultisigPublicKeys,
 				extraJson.Signatures,
 			}
+		case transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT:
+			extraJson := &json_Only_TransactionSimpleExtraClaimConditionalPayment{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraClaimConditionalPayment{nil,
+				extraJson.TxId,
+				extraJson.PayloadIndex,
+				extraJson.Preimage,
+			}
 		default:
 			return errors.New("Invalid json Simple TxScript")
 		}
@@ -568,6 +678,76 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 					extraJson.MultisigThreshold,
 					extraJson.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+				extraJson := &json_Only_TransactionZetherPayloadExtraConditionalPaymentHashlock{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock{
+					nil,
+					extraJson.Deadline,
+					extraJson.Hashlock,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{
+					nil,
+					extraJson.AssetSupplyPublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_PAUSE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetPause{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetPause{
+					nil,
+					extraJson.AssetId,
+					extraJson.Paused,
+					extraJson.AssetUpdatePublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_FREEZE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetFreeze{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetFreeze{
+					nil,
+					extraJson.AssetId,
+					extraJson.AssetUpdatePublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetChangePublicKey{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetChangePublicKey{
+					nil,
+					extraJson.AssetId,
+					extraJson.ChangeSupplyPublicKey,
+					extraJson.NewPublicKey,
+					extraJson.AssetUpdatePublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetUpdate{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{
+					nil,
+					extraJson.AssetId,
+					extraJson.Name,
+					extraJson.Description,
+					extraJson.Data,
+					extraJson.AssetUpdatePublicKey,
+					extraJson.AssetSignature,
+				}
 			default:
 				return errors.New("Invalid Zether TxScript")
 			}
@@ -0,0 +1,101 @@
+package transaction_simple_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+	"strconv"
+)
+
+// TransactionSimpleExtraClaimConditionalPayment pays a hashlock conditional payment to its receivers by revealing the preimage.
+// Anyone knowing the preimage can submit it, the funds always go to the receivers
+type TransactionSimpleExtraClaimConditionalPayment struct {
+	TransactionSimpleExtraInterface
+	TxId         []byte
+	PayloadIndex byte
+	Preimage     []byte
+}
+
+func (this *TransactionSimpleExtraClaimConditionalPayment) IncludeTransactionVin0(blockHeight uint64, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	key := string(this.TxId) + "_" + strconv.Itoa(int(this.PayloadIndex))
+
+	val := dataStorage.DBTx.Get("conditionalPayments:all:" + string(key))
+	if val == nil {
+		return errors.New("Pending Future not found by key")
+	}
+
+	txBlockHeight, err := strconv.ParseUint(string(val), 10, 64)
+	if err != nil {
+		return
+	}
+
+	if txBlockHeight < blockHeight+1 {
+		return errors.New("Pending Future Expired")
+	}
+
+	conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(txBlockHeight)
+	if err != nil {
+		return err
+	}
+
+	condPayment, err := conditionalPaymentsMap.Get(key)
+	if err != nil {
+		return
+	}
+
+	if condPayment == nil {
+		return errors.New("Pending Future not found")
+	}
+
+	if condPayment.Processed {
+		return errors.New("Pending Future was already processed")
+	}
+
+	if condPayment.Version != conditional_payment.CONDITIONAL_PAYMENT_VERSION_HASHLOCK {
+		return errors.New("Pending Future is not hash locked")
+	}
+
+	if !bytes.Equal(cryptography.SHA256(this.Preimage), condPayment.Hashlock) {
+		return errors.New("Preimage doesn't match the Hashlock")
+	}
+
+	if err = dataStorage.ProceedConditionalPayment(true, condPayment); err != nil {
+		return
+	}
+
+	return conditionalPaymentsMap.Update(key, condPayment)
+}
+
+func (this *TransactionSimpleExtraClaimConditionalPayment) Validate(fee uint64) (err error) {
+	if len(this.Preimage) == 0 || len(this.Preimage) > cryptography.HashlockPreimageMaxSize {
+		return errors.New("Invalid Preimage length")
+	}
+	if fee != 0 {
+		return errors.New("Fee should be zero")
+	}
+	return
+}
+
+func (this *TransactionSimpleExtraClaimConditionalPayment) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.TxId)
+	w.WriteByte(this.PayloadIndex)
+	w.WriteVariableBytes(this.Preimage)
+}
+
+func (this *TransactionSimpleExtraClaimConditionalPayment) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.TxId, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	if this.PayloadIndex, err = r.ReadByte(); err != nil {
+		return
+	}
+	if this.Preimage, err = r.ReadVariableBytes(cryptography.HashlockPreimageMaxSize); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package transaction_simple_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+type testHashlockPayment struct {
+	txId     []byte
+	preimage []byte
+	sender   []byte
+	receiver []byte
+	echanges []*crypto.ElGamal
+}
+
+func createTestHashlockPayment(t *testing.T, dataStorage *data_storage.DataStorage, deadlineHeight uint64) *testHashlockPayment {
+
+	payment := &testHashlockPayment{
+		txId:     helpers.RandomBytes(cryptography.HashSize),
+		preimage: helpers.RandomBytes(32),
+		sender:   addresses.GenerateNewPrivateKey().GeneratePublicKey(),
+		receiver: addresses.GenerateNewPrivateKey().GeneratePublicKey(),
+	}
+
+	for i, publicKey := range [][]byte{payment.sender, payment.receiver} {
+		_, err := dataStorage.CreateRegistration(publicKey, false, nil)
+		assert.NoError(t, err)
+
+		var point crypto.Point
+		assert.NoError(t, point.DecodeCompressed(publicKey))
+		payment.echanges = append(payment.echanges, crypto.CommitElGamal(point.G1(), big.NewInt(int64(100+i))))
+	}
+
+	assert.NoError(t, dataStorage.AddConditionalPayment(deadlineHeight, payment.txId, 0, config_coins.NATIVE_ASSET_FULL, false, true, [][]byte{payment.sender, payment.receiver}, payment.echanges, 0, nil, cryptography.SHA256(payment.preimage)))
+	assert.NoError(t, dataStorage.CommitChanges())
+
+	return payment
+}
+
+// checkTestBalance verifies that only the given account received its amount of the conditional payment
+func checkTestBalance(t *testing.T, dataStorage *data_storage.DataStorage, publicKey []byte, amount *crypto.ElGamal) {
+
+	accs, err := dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL)
+	assert.NoError(t, err)
+
+	acc, err := accs.Get(string(publicKey))
+	assert.NoError(t, err)
+
+	if amount == nil {
+		assert.Nil(t, acc)
+		return
+	}
+
+	expected, err := account.NewAccount(publicKey, 0, config_coins.NATIVE_ASSET_FULL)
+	assert.NoError(t, err)
+	expected.Balance.AddEchanges(amount)
+
+	if assert.NotNil(t, acc) {
+		assert.Equal(t, expected.Balance.Amount.Serialize(), acc.Balance.Amount.Serialize())
+	}
+}
+
+func TestClaimConditionalPayment(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		const deadlineHeight = 20
+		payment := createTestHashlockPayment(t, dataStorage, deadlineHeight)
+
+		claim := &TransactionSimpleExtraClaimConditionalPayment{nil, payment.txId, 0, payment.preimage}
+		assert.NoError(t, claim.Validate(0))
+		assert.EqualError(t, claim.Validate(1), "Fee should be zero")
+
+		wrongPreimage := &TransactionSimpleExtraClaimConditionalPayment{nil, payment.txId, 0, helpers.RandomBytes(32)}
+		assert.EqualError(t, wrongPreimage.IncludeTransactionVin0(10, nil, dataStorage), "Preimage doesn't match the Hashlock")
+		dataStorage.Rollback()
+
+		unknown := &TransactionSimpleExtraClaimConditionalPayment{nil, payment.txId, 1, payment.preimage}
+		assert.EqualError(t, unknown.IncludeTransactionVin0(10, nil, dataStorage), "Pending Future not found by key")
+
+		//the timelock expired
+		assert.EqualError(t, claim.IncludeTransactionVin0(deadlineHeight, nil, dataStorage), "Pending Future Expired")
+		dataStorage.Rollback()
+
+		assert.NoError(t, claim.IncludeTransactionVin0(deadlineHeight-1, nil, dataStorage))
+		assert.NoError(t, dataStorage.CommitChanges())
+
+		checkTestBalance(t, dataStorage, payment.receiver, payment.echanges[1])
+		checkTestBalance(t, dataStorage, payment.sender, nil)
+
+		assert.EqualError(t, claim.IncludeTransactionVin0(deadlineHeight-1, nil, dataStorage), "Pending Future was already processed")
+		dataStorage.Rollback()
+
+		//a claimed payment is not refunded at the deadline
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(deadlineHeight))
+		checkTestBalance(t, dataStorage, payment.receiver, payment.echanges[1])
+		checkTestBalance(t, dataStorage, payment.sender, nil)
+
+		return
+	}))
+}
+
+func TestRefundConditionalPayment(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		const deadlineHeight = 20
+		payment := createTestHashlockPayment(t, dataStorage, deadlineHeight)
+
+		//nothing happens before the deadline
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(deadlineHeight-1))
+		checkTestBalance(t, dataStorage, payment.sender, nil)
+
+		//the expired payment goes back to the sender
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(deadlineHeight))
+		assert.NoError(t, dataStorage.CommitChanges())
+
+		checkTestBalance(t, dataStorage, payment.sender, payment.echanges[0].Neg())
+		checkTestBalance(t, dataStorage, payment.receiver, nil)
+
+		//the preimage can't claim it anymore
+		claim := &TransactionSimpleExtraClaimConditionalPayment{nil, payment.txId, 0, payment.preimage}
+		assert.Error(t, claim.IncludeTransactionVin0(deadlineHeight-1, nil, dataStorage))
+
+		return
+	}))
+}
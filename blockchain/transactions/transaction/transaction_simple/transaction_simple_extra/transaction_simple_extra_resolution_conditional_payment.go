@@ -3,6 +3,7 @@ package transaction_simple_extra
 import (
 	"errors"
 	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
@@ -55,6 +56,10 @@ func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransacti
 		return errors.New("Pending Future was already processed")
 	}
 
+	if condPayment.Version != conditional_payment.CONDITIONAL_PAYMENT_VERSION_MULTISIG {
+		return errors.New("Pending Future can not be resolved by multisig")
+	}
+
 	if int(condPayment.MultisigThreshold) > len(this.MultisigPublicKeys) {
 		return errors.New("Threshold not met")
 	}
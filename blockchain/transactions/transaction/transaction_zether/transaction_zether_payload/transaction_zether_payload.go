@@ -7,6 +7,7 @@ import (
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
@@ -103,6 +104,16 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 	var balance *crypto.ElGamal
 
 	if !bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) {
+		var ast *asset.Asset
+		if ast, err = dataStorage.Asts.Get(string(payload.Asset)); err != nil {
+			return
+		}
+		if ast == nil {
+			return errors.New("Asset was not found")
+		}
+		if ast.Paused {
+			return errors.New("Asset is paused")
+		}
 		if err = payload.processAssetFee(payload.Asset, payload.Statement.Fee, payload.FeeRate, payload.FeeLeadingZeros, blockHeight, dataStorage); err != nil {
 			return
 		}
@@ -193,7 +204,7 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 					update = true
 				}
 			} else { //recipient
-				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT { //nothing
+				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK { //nothing
 
 				} else if bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) && (reg.Staked || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD) {
 					if err = dataStorage.AddPendingStake(publicKey, echanges, blockHeight+config_stake.GetPendingStakeWindow(blockHeight)); err != nil {
@@ -217,7 +228,14 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 
 	if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT {
 		extra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment)
-		if err = dataStorage.AddConditionalPayment(blockHeight+extra.Deadline, txHash, payloadIndex, payload.Asset, extra.DefaultResolution, payload.Parity, publicKeyList, echangesAll, extra.MultisigThreshold, extra.MultisigPublicKeys); err != nil {
+		if err = dataStorage.AddConditionalPayment(blockHeight+extra.Deadline, txHash, payloadIndex, payload.Asset, extra.DefaultResolution, payload.Parity, publicKeyList, echangesAll, extra.MultisigThreshold, extra.MultisigPublicKeys, nil); err != nil {
+			return
+		}
+	}
+
+	if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK {
+		extra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock)
+		if err = dataStorage.AddConditionalPayment(blockHeight+extra.Deadline, txHash, payloadIndex, payload.Asset, false, payload.Parity, publicKeyList, echangesAll, 0, nil, extra.Hashlock); err != nil {
 			return
 		}
 	}
@@ -278,7 +296,8 @@ func (payload *TransactionZetherPayload) Validate(payloadIndex byte) (err error)
 
 	switch payload.PayloadScript {
 	case transaction_zether_payload_script.SCRIPT_TRANSFER:
-	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
+	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT,
+		transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE, transaction_zether_payload_script.SCRIPT_ASSET_PAUSE, transaction_zether_payload_script.SCRIPT_ASSET_FREEZE, transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY, transaction_zether_payload_script.SCRIPT_ASSET_UPDATE, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
 		if payload.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -357,6 +376,18 @@ func (payload *TransactionZetherPayload) Deserialize(r *advanced_buffers.BufferR
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend{}
 	case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment{}
+	case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_PAUSE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetPause{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_FREEZE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetFreeze{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetChangePublicKey{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
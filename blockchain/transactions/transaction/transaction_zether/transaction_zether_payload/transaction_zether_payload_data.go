@@ -0,0 +1,23 @@
+package transaction_zether_payload
+
+import (
+	"errors"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// PadEncryptedData prefixes the data with its length and fills the rest of the PAYLOAD_LIMIT with zeros. The length keeps the trailing zeros of binary data
+func PadEncryptedData(data []byte) ([]byte, error) {
+
+	w := advanced_buffers.NewBufferWriter()
+	w.WriteVariableBytes(data)
+	if w.Length() > PAYLOAD_LIMIT {
+		return nil, errors.New("Data final exceeds")
+	}
+
+	return append(w.Bytes(), make([]byte, PAYLOAD_LIMIT-w.Length())...), nil
+}
+
+// UnpadEncryptedData returns the data of a decrypted payload padded by PadEncryptedData
+func UnpadEncryptedData(data []byte) ([]byte, error) {
+	return advanced_buffers.NewBufferReader(data).ReadVariableBytes(PAYLOAD_LIMIT)
+}
@@ -0,0 +1,26 @@
+package transaction_zether_payload
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPadEncryptedData(t *testing.T) {
+
+	//binary data ending with zeros is kept as it was sent
+	for _, data := range [][]byte{{}, {1, 0, 0}, {0, 0, 0}, make([]byte, PAYLOAD_LIMIT-2)} {
+		padded, err := PadEncryptedData(data)
+		assert.NoError(t, err)
+		assert.Equal(t, PAYLOAD_LIMIT, len(padded))
+
+		unpadded, err := UnpadEncryptedData(padded)
+		assert.NoError(t, err)
+		assert.Equal(t, data, unpadded)
+	}
+
+	_, err := PadEncryptedData(make([]byte, PAYLOAD_LIMIT-1))
+	assert.EqualError(t, err, "Data final exceeds")
+
+	_, err = UnpadEncryptedData(append([]byte{10}, make([]byte, 5)...))
+	assert.Error(t, err)
+}
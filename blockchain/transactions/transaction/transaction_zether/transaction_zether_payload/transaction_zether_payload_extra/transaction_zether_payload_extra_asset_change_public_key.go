@@ -0,0 +1,101 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// TransactionZetherPayloadExtraAssetChangePublicKey rotates the Update Public Key or the Supply Public Key of an asset. It is signed by the current Update Public Key
+type TransactionZetherPayloadExtraAssetChangePublicKey struct {
+	TransactionZetherPayloadExtraInterface
+	AssetId               []byte
+	ChangeSupplyPublicKey bool //true to change the SupplyPublicKey, false to change the UpdatePublicKey
+	NewPublicKey          []byte
+	AssetUpdatePublicKey  []byte
+	AssetSignature        []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	ast, err := getAssetByUpdatePublicKey(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, dataStorage)
+	if err != nil {
+		return
+	}
+
+	if payloadExtra.ChangeSupplyPublicKey {
+		if !ast.CanChangeSupplyPublicKey {
+			return errors.New("Can't change Supply Public Key")
+		}
+		if bytes.Equal(ast.SupplyPublicKey, payloadExtra.NewPublicKey) {
+			return errors.New("Supply Public Key is identical")
+		}
+		ast.SupplyPublicKey = payloadExtra.NewPublicKey
+	} else {
+		if !ast.CanChangeUpdatePublicKey {
+			return errors.New("Can't change Update Public Key")
+		}
+		if bytes.Equal(ast.UpdatePublicKey, payloadExtra.NewPublicKey) {
+			return errors.New("Update Public Key is identical")
+		}
+		ast.UpdatePublicKey = payloadExtra.NewPublicKey
+	}
+
+	return dataStorage.Asts.Update(string(payloadExtra.AssetId), ast)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return crypto.VerifySignature(hashForSignature, payloadExtra.AssetSignature, payloadExtra.AssetUpdatePublicKey)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if len(payloadExtra.NewPublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid New Public Key")
+	}
+	return validateAssetUpdateExtra(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, payloadExtra.AssetSignature, payloadAsset)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.AssetId)
+	w.WriteBool(payloadExtra.ChangeSupplyPublicKey)
+	w.Write(payloadExtra.NewPublicKey)
+	w.Write(payloadExtra.AssetUpdatePublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.AssetSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if payloadExtra.ChangeSupplyPublicKey, err = r.ReadBool(); err != nil {
+		return
+	}
+	if payloadExtra.NewPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetUpdatePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetChangePublicKey) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package transaction_zether_payload_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// TransactionZetherPayloadExtraAssetFreeze freezes the supply of the asset. Freezing can not be reverted
+type TransactionZetherPayloadExtraAssetFreeze struct {
+	TransactionZetherPayloadExtraInterface
+	AssetId              []byte
+	AssetUpdatePublicKey []byte
+	AssetSignature       []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	ast, err := getAssetByUpdatePublicKey(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, dataStorage)
+	if err != nil {
+		return
+	}
+
+	if !ast.CanFreeze {
+		return errors.New("Can't freeze")
+	}
+	if ast.Frozen {
+		return errors.New("Asset is already frozen")
+	}
+
+	if ast.Version < asset.ASSET_VERSION_PAUSE_FREEZE { //the initial version doesn't store the frozen state
+		ast.Version = asset.ASSET_VERSION_PAUSE_FREEZE
+	}
+	ast.Frozen = true
+
+	return dataStorage.Asts.Update(string(payloadExtra.AssetId), ast)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return crypto.VerifySignature(hashForSignature, payloadExtra.AssetSignature, payloadExtra.AssetUpdatePublicKey)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	return validateAssetUpdateExtra(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, payloadExtra.AssetSignature, payloadAsset)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.AssetId)
+	w.Write(payloadExtra.AssetUpdatePublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.AssetSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if payloadExtra.AssetUpdatePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetFreeze) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
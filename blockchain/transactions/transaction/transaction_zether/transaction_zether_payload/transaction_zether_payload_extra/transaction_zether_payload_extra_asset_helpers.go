@@ -0,0 +1,49 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+)
+
+// getAssetByUpdatePublicKey returns the asset only if the provided key is the current Update Public Key of the asset
+func getAssetByUpdatePublicKey(assetId, assetUpdatePublicKey []byte, dataStorage *data_storage.DataStorage) (*asset.Asset, error) {
+
+	ast, err := dataStorage.Asts.Get(string(assetId))
+	if err != nil {
+		return nil, err
+	}
+
+	if ast == nil {
+		return nil, errors.New("Asset was not found")
+	}
+
+	if bytes.Equal(ast.UpdatePublicKey, config_coins.BURN_PUBLIC_KEY) {
+		return nil, errors.New("BURN PUBLIC KEY")
+	}
+
+	if !bytes.Equal(assetUpdatePublicKey, ast.UpdatePublicKey) {
+		return nil, errors.New("Asset UpdatePublicKey is not matching")
+	}
+
+	return ast, nil
+}
+
+func validateAssetUpdateExtra(assetId, assetUpdatePublicKey, assetSignature, payloadAsset []byte) error {
+	if len(assetId) != config_coins.ASSET_LENGTH || bytes.Equal(assetId, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("Invalid AssetId")
+	}
+	if !bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("payloadAsset must be NATIVE_ASSET_FULL")
+	}
+	if len(assetUpdatePublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(assetSignature) != cryptography.SignatureSize {
+		return errors.New("Invalid Signature")
+	}
+	return nil
+}
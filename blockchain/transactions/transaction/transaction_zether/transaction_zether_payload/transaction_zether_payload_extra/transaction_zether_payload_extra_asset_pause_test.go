@@ -0,0 +1,54 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestAssetPauseFreeze_InitialVersion(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assetId := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+	updatePublicKey := helpers.RandomBytes(cryptography.PublicKeySize)
+
+	err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		//an asset of version 0 created with the pause and freeze permissions
+		ast := asset.NewAsset(assetId, 0)
+		ast.CanPause = true
+		ast.CanFreeze = true
+		ast.UpdatePublicKey = updatePublicKey
+		if err = dataStorage.Asts.Update(string(assetId), ast); err != nil {
+			return
+		}
+
+		pause := &TransactionZetherPayloadExtraAssetPause{AssetId: assetId, Paused: true, AssetUpdatePublicKey: updatePublicKey}
+		assert.NoError(t, pause.AfterIncludeTxPayload(nil, nil, 0, nil, 0, nil, nil, 0, dataStorage))
+
+		freeze := &TransactionZetherPayloadExtraAssetFreeze{AssetId: assetId, AssetUpdatePublicKey: updatePublicKey}
+		assert.NoError(t, freeze.AfterIncludeTxPayload(nil, nil, 0, nil, 0, nil, nil, 0, dataStorage))
+
+		//the asset is stored with the version which keeps the state
+		ast, err = dataStorage.Asts.Get(string(assetId))
+		assert.NoError(t, err)
+		assert.Equal(t, asset.ASSET_VERSION_PAUSE_FREEZE, ast.Version)
+		assert.True(t, ast.Paused)
+		assert.True(t, ast.Frozen)
+		assert.True(t, ast.CanPause)
+		assert.True(t, ast.CanFreeze)
+
+		return
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,89 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// TransactionZetherPayloadExtraAssetSupplyDecrease burns the payload BurnValue of the payload Asset and decreases its supply
+type TransactionZetherPayloadExtraAssetSupplyDecrease struct {
+	TransactionZetherPayloadExtraInterface
+	AssetSupplyPublicKey []byte
+	AssetSignature       []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	ast, err := dataStorage.Asts.Get(string(payloadAsset))
+	if err != nil {
+		return
+	}
+
+	if ast == nil {
+		return errors.New("Asset was not found")
+	}
+
+	if !bytes.Equal(payloadExtra.AssetSupplyPublicKey, ast.SupplyPublicKey) {
+		return errors.New("Asset SupplyPublicKey is not matching")
+	}
+
+	if err = ast.AddSupply(false, payloadBurnValue); err != nil {
+		return
+	}
+
+	return dataStorage.Asts.Update(string(payloadAsset), ast)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return crypto.VerifySignature(hashForSignature, payloadExtra.AssetSignature, payloadExtra.AssetSupplyPublicKey)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if payloadBurnValue == 0 {
+		return errors.New("Payload Burn value must be greater than zero")
+	}
+	if bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("payloadAsset must NOT be NATIVE_ASSET_FULL")
+	}
+	if len(payloadExtra.AssetSupplyPublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(payloadExtra.AssetSignature) != cryptography.SignatureSize {
+		return errors.New("Invalid Signature")
+	}
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.AssetSupplyPublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.AssetSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.AssetSupplyPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyDecrease) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestAssetSupplyDecrease(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assetId := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+	supplyPublicKey := helpers.RandomBytes(cryptography.PublicKeySize)
+
+	extra := &TransactionZetherPayloadExtraAssetSupplyDecrease{
+		AssetSupplyPublicKey: supplyPublicKey,
+		AssetSignature:       helpers.RandomBytes(cryptography.SignatureSize),
+	}
+
+	assert.NoError(t, extra.Validate(nil, 0, assetId, 10, nil, false))
+	assert.Error(t, extra.Validate(nil, 0, assetId, 0, nil, false), "burn value must be greater than zero")
+	assert.Error(t, extra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 10, nil, false), "native asset can't be burned")
+
+	err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		ast := asset.NewAsset(assetId, 0)
+		ast.CanBurn = true
+		ast.MaxSupply = 1000
+		ast.Supply = 100
+		ast.SupplyPublicKey = supplyPublicKey
+		if err = dataStorage.Asts.Update(string(assetId), ast); err != nil {
+			return
+		}
+
+		assert.NoError(t, extra.AfterIncludeTxPayload(nil, nil, 0, assetId, 30, nil, nil, 0, dataStorage))
+
+		ast, err = dataStorage.Asts.Get(string(assetId))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(70), ast.Supply)
+
+		assert.Error(t, extra.AfterIncludeTxPayload(nil, nil, 0, assetId, 71, nil, nil, 0, dataStorage), "supply would become negative")
+
+		wrongKey := &TransactionZetherPayloadExtraAssetSupplyDecrease{AssetSupplyPublicKey: helpers.RandomBytes(cryptography.PublicKeySize)}
+		assert.Error(t, wrongKey.AfterIncludeTxPayload(nil, nil, 0, assetId, 1, nil, nil, 0, dataStorage), "supply key is not matching")
+
+		assert.Error(t, extra.AfterIncludeTxPayload(nil, nil, 0, helpers.RandomBytes(config_coins.ASSET_LENGTH), 1, nil, nil, 0, dataStorage), "asset doesn't exist")
+
+		ast, err = dataStorage.Asts.Get(string(assetId))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(70), ast.Supply)
+
+		return
+	})
+	assert.NoError(t, err)
+}
@@ -39,6 +39,10 @@ func (payloadExtra *TransactionZetherPayloadExtraAssetSupplyIncrease) AfterInclu
 		return errors.New("Asset SupplyPublicKey is not matching")
 	}
 
+	if ast.Paused {
+		return errors.New("Asset is paused")
+	}
+
 	accs, acc, err := dataStorage.GetOrCreateAccount(payloadExtra.AssetId, payloadExtra.ReceiverPublicKey, true)
 	if err != nil {
 		return
@@ -0,0 +1,96 @@
+package transaction_zether_payload_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// TransactionZetherPayloadExtraAssetUpdate replaces the name, description and data of an asset
+type TransactionZetherPayloadExtraAssetUpdate struct {
+	TransactionZetherPayloadExtraInterface
+	AssetId              []byte
+	Name                 string
+	Description          string
+	Data                 []byte
+	AssetUpdatePublicKey []byte
+	AssetSignature       []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	ast, err := getAssetByUpdatePublicKey(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, dataStorage)
+	if err != nil {
+		return
+	}
+
+	if !ast.CanUpgrade {
+		return errors.New("Can't upgrade")
+	}
+
+	ast.Name = payloadExtra.Name
+	ast.Description = payloadExtra.Description
+	ast.Data = payloadExtra.Data
+
+	return dataStorage.Asts.Update(string(payloadExtra.AssetId), ast)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return crypto.VerifySignature(hashForSignature, payloadExtra.AssetSignature, payloadExtra.AssetUpdatePublicKey)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if err := asset.ValidateDetails(payloadExtra.Name, payloadExtra.Description, payloadExtra.Data); err != nil {
+		return err
+	}
+	return validateAssetUpdateExtra(payloadExtra.AssetId, payloadExtra.AssetUpdatePublicKey, payloadExtra.AssetSignature, payloadAsset)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.AssetId)
+	w.WriteString(payloadExtra.Name)
+	w.WriteString(payloadExtra.Description)
+	w.WriteVariableBytes(payloadExtra.Data)
+	w.Write(payloadExtra.AssetUpdatePublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.AssetSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+	if payloadExtra.Name, err = r.ReadString(15); err != nil {
+		return
+	}
+	if payloadExtra.Description, err = r.ReadString(1024); err != nil {
+		return
+	}
+	if payloadExtra.Data, err = r.ReadVariableBytes(5120); err != nil {
+		return
+	}
+	if payloadExtra.AssetUpdatePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+package transaction_zether_payload_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// TransactionZetherPayloadExtraConditionalPaymentHashlock is a conditional payment claimed by the receiver revealing the preimage
+// of the Hashlock before the Deadline. After the Deadline, the payment is refunded to the sender
+type TransactionZetherPayloadExtraConditionalPaymentHashlock struct {
+	TransactionZetherPayloadExtraInterface
+	Deadline uint64
+	Hashlock []byte //SHA256(preimage)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	//to pay for registering accounts
+	for _, publicKey := range publicKeyList {
+		if _, _, err = dataStorage.GetOrCreateAccount(payloadAsset, publicKey, true); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return false
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if payloadExtra.Deadline > 100000 {
+		return errors.New("Deadline should be smaller than 100000")
+	}
+	if payloadExtra.Deadline < 10 {
+		return errors.New("Deadline should be greater than 10")
+	}
+	if payloadBurnValue != 0 {
+		return errors.New("Payload burn value must be zero")
+	}
+	if payloadStatement.Fee != 0 {
+		return errors.New("Payload Fee must be zero")
+	}
+	if len(payloadExtra.Hashlock) != cryptography.HashlockSize {
+		return errors.New("Hashlock length is invalid")
+	}
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.WriteUvarint(payloadExtra.Deadline)
+	w.Write(payloadExtra.Hashlock)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.Deadline, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if payloadExtra.Hashlock, err = r.ReadBytes(cryptography.HashlockSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraConditionalPaymentHashlock) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -11,6 +11,12 @@ const (
 	SCRIPT_ASSET_SUPPLY_INCREASE
 	SCRIPT_PLAIN_ACCOUNT_FUND
 	SCRIPT_CONDITIONAL_PAYMENT
+	SCRIPT_ASSET_SUPPLY_DECREASE
+	SCRIPT_ASSET_PAUSE
+	SCRIPT_ASSET_FREEZE
+	SCRIPT_ASSET_CHANGE_PUBLIC_KEY
+	SCRIPT_ASSET_UPDATE
+	SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK
 )
 
 func (t PayloadScriptType) String() string {
@@ -31,6 +37,18 @@ func (t PayloadScriptType) String() string {
 		return "SCRIPT_PLAIN_ACCOUNT_FUND"
 	case SCRIPT_CONDITIONAL_PAYMENT:
 		return "SCRIPT_CONDITIONAL_PAYMENT"
+	case SCRIPT_ASSET_SUPPLY_DECREASE:
+		return "SCRIPT_ASSET_SUPPLY_DECREASE"
+	case SCRIPT_ASSET_PAUSE:
+		return "SCRIPT_ASSET_PAUSE"
+	case SCRIPT_ASSET_FREEZE:
+		return "SCRIPT_ASSET_FREEZE"
+	case SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+		return "SCRIPT_ASSET_CHANGE_PUBLIC_KEY"
+	case SCRIPT_ASSET_UPDATE:
+		return "SCRIPT_ASSET_UPDATE"
+	case SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+		return "SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK"
 	default:
 		return "Unknown ScriptType"
 	}
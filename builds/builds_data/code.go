@@ -48,6 +48,18 @@ func PrepareData(data []byte) (txData *TransactionsBuilderCreateZetherTxReq, tra
 			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraPlainAccountFund{}
 		case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraConditionalPayment{}
+		case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraAssetSupplyDecrease{}
+		case transaction_zether_payload_script.SCRIPT_ASSET_PAUSE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraAssetPause{}
+		case transaction_zether_payload_script.SCRIPT_ASSET_FREEZE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraAssetFreeze{}
+		case transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraAssetChangePublicKey{}
+		case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraAssetUpdate{}
+		case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraConditionalPaymentHashlock{}
 		default:
 			err = errors.New("Invalid PayloadScriptType")
 			return
@@ -2,6 +2,7 @@ package builds_data
 
 import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
+	"pandora-pay/network/api_implementation/api_common/api_types"
 	"pandora-pay/txs_builder/txs_builder_zether_helper"
 	"pandora-pay/txs_builder/wizard"
 )
@@ -18,6 +19,12 @@ type WalletDecryptBalanceReq struct {
 	Asset         []byte `json:"asset"`
 }
 
+type StateProofsVerifyReq struct {
+	BlockHash []byte                            `json:"blockHash"`
+	Block     *api_types.APIStateProofBlock     `json:"block"`
+	Proofs    []*api_types.APIStateElementProof `json:"proofs"`
+}
+
 type zetherTxDataSender struct {
 	PrivateKey       []byte `json:"privateKey"`
 	SpendPrivateKey  []byte `json:"spendPrivateKey"`
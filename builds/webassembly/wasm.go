@@ -139,18 +139,25 @@ func initialize() {
 					"ScriptType": js.ValueOf(map[string]any{
 						"SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY":     js.ValueOf(uint64(transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY)),
 						"SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT": js.ValueOf(uint64(transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT)),
+						"SCRIPT_CLAIM_CONDITIONAL_PAYMENT":      js.ValueOf(uint64(transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT)),
 					}),
 				}),
 				"transactionZether": js.ValueOf(map[string]any{
 					"PayloadScriptType": js.ValueOf(map[string]any{
-						"SCRIPT_TRANSFER":              js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_TRANSFER)),
-						"SCRIPT_STAKING":               js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_STAKING)),
-						"SCRIPT_STAKING_REWARD":        js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_STAKING_REWARD)),
-						"SCRIPT_SPEND":                 js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_SPEND)),
-						"SCRIPT_ASSET_CREATE":          js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_CREATE)),
-						"SCRIPT_ASSET_SUPPLY_INCREASE": js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE)),
-						"SCRIPT_PLAIN_ACCOUNT_FUND":    js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND)),
-						"SCRIPT_CONDITIONAL_PAYMENT":   js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT)),
+						"SCRIPT_TRANSFER":                     js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_TRANSFER)),
+						"SCRIPT_STAKING":                      js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_STAKING)),
+						"SCRIPT_STAKING_REWARD":               js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_STAKING_REWARD)),
+						"SCRIPT_SPEND":                        js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_SPEND)),
+						"SCRIPT_ASSET_CREATE":                 js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_CREATE)),
+						"SCRIPT_ASSET_SUPPLY_INCREASE":        js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE)),
+						"SCRIPT_PLAIN_ACCOUNT_FUND":           js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND)),
+						"SCRIPT_CONDITIONAL_PAYMENT":          js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT)),
+						"SCRIPT_ASSET_SUPPLY_DECREASE":        js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE)),
+						"SCRIPT_ASSET_PAUSE":                  js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_PAUSE)),
+						"SCRIPT_ASSET_FREEZE":                 js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_FREEZE)),
+						"SCRIPT_ASSET_CHANGE_PUBLIC_KEY":      js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY)),
+						"SCRIPT_ASSET_UPDATE":                 js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_UPDATE)),
+						"SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK": js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK)),
 					}),
 				}),
 			}),
@@ -177,6 +184,9 @@ func initialize() {
 						"SUBSCRIPTION_ASSET":                js.ValueOf(int(api_code_types.SUBSCRIPTION_ASSET)),
 						"SUBSCRIPTION_REGISTRATION":         js.ValueOf(int(api_code_types.SUBSCRIPTION_REGISTRATION)),
 						"SUBSCRIPTION_TRANSACTION":          js.ValueOf(int(api_code_types.SUBSCRIPTION_TRANSACTION)),
+						"SUBSCRIPTION_BLOCKS":               js.ValueOf(int(api_code_types.SUBSCRIPTION_BLOCKS)),
+						"SUBSCRIPTION_CONDITIONAL_PAYMENT":  js.ValueOf(int(api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT)),
+						"SUBSCRIPTION_MEMPOOL":              js.ValueOf(int(api_code_types.SUBSCRIPTION_MEMPOOL)),
 					}),
 				}),
 			}),
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/builds/webassembly/webassembly_utils"
@@ -133,6 +134,20 @@ func listenNetworkNotifications(this js.Value, args []js.Value) interface{} {
 					case api_code_types.SUBSCRIPTION_TRANSACTION:
 						object = data.Data
 						extra = &api_types.APISubscriptionNotificationTxExtra{}
+					case api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT:
+						var condPayment *conditional_payment.ConditionalPayment
+						if data.Data != nil {
+							condPayment = conditional_payment.NewConditionalPayment(nil, 0, 0)
+							if err = condPayment.Deserialize(advanced_buffers.NewBufferReader(data.Data)); err != nil {
+								return
+							}
+						}
+						object = condPayment
+						extra = &api_types.APISubscriptionNotificationConditionalPaymentExtra{}
+					case api_code_types.SUBSCRIPTION_BLOCKS:
+						extra = &api_types.APISubscriptionNotificationBlocksExtra{}
+					case api_code_types.SUBSCRIPTION_MEMPOOL:
+						extra = &api_types.APISubscriptionNotificationTxExtraMempool{}
 					default:
 						return //invalid
 					}
@@ -20,10 +20,6 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			return nil, errors.New("Argument must be a string and a callback")
 		}
 
-		if err := wallet.Wallet.Encryption.CheckPassword(args[2].String(), false); err != nil {
-			return nil, err
-		}
-
 		txData := &struct {
 			TxScript   transaction_simple.ScriptType `json:"txScript"`
 			Sender     string                        `json:"sender"`
@@ -33,6 +29,7 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			Fee        *wizard.WizardTransactionFee  `json:"fee"`
 			FeeVersion bool                          `json:"feeVersion"`
 			Height     uint64                        `json:"height"`
+			Wallet     string                        `json:"wallet"`
 		}{}
 
 		//read txScript
@@ -49,6 +46,8 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			txData.Extra = &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{}
 		case transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
 			txData.Extra = &wizard.WizardTxSimpleExtraResolutionConditionalPayment{}
+		case transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT:
+			txData.Extra = &wizard.WizardTxSimpleExtraClaimConditionalPayment{}
 		default:
 			txData.Extra = nil
 			return nil, errors.New("Invalid Tx Simple Script")
@@ -58,6 +57,15 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
+		//the sender is searched only in the given wallet, or in the default wallet
+		w, err := wallet.Wallets.GetWallet(txData.Wallet)
+		if err != nil {
+			return nil, err
+		}
+		if err = w.Encryption.CheckPassword(args[2].String(), false); err != nil {
+			return nil, err
+		}
+
 		transfer := &wizard.WizardTxSimpleTransfer{
 			txData.Extra,
 			txData.Data,
@@ -67,12 +75,12 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 		}
 
 		if len(txData.Sender) > 0 {
-			senderWalletAddr, err := wallet.Wallet.GetWalletAddressByEncodedAddress(txData.Sender, true)
+			senderWalletAddr, err := wallet.Wallets.GetWalletAddress(txData.Wallet, txData.Sender)
 			if err != nil {
 				return nil, err
 			}
 
-			if senderWalletAddr.PrivateKey.Key == nil {
+			if senderWalletAddr.PrivateKey == nil {
 				return nil, errors.New("Can't be used for transactions as the private key is missing")
 			}
 			transfer.Key = senderWalletAddr.PrivateKey.Key
@@ -36,6 +36,9 @@ func main() {
 			"initializeBalanceDecrypter": js.FuncOf(initializeBalanceDecrypter),
 			"decryptBalance":             js.FuncOf(decryptBalance),
 		}),
+		"stateProofs": js.ValueOf(map[string]interface{}{
+			"verifyStateProofs": js.FuncOf(verifyStateProofs),
+		}),
 		"transactions": js.ValueOf(map[string]interface{}{
 			"builder": js.ValueOf(map[string]interface{}{
 				"createZetherTx": js.FuncOf(createZetherTx),
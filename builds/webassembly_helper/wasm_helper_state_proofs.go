@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"pandora-pay/builds/builds_data"
+	"pandora-pay/builds/webassembly/webassembly_utils"
+	"syscall/js"
+)
+
+// verifyStateProofs checks the proofs returned by the account/proof, accounts/by-keys/proof, asset/proof and state-proof APIs against the block header
+func verifyStateProofs(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		parameters := &builds_data.StateProofsVerifyReq{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], parameters); err != nil {
+			return nil, err
+		}
+
+		if parameters.Block == nil {
+			return nil, errors.New("Block is missing")
+		}
+
+		if err := parameters.Block.Verify(parameters.BlockHash); err != nil {
+			return nil, err
+		}
+
+		for _, proof := range parameters.Proofs {
+			if proof == nil {
+				return nil, errors.New("Proof is missing")
+			}
+			if err := proof.Verify(parameters.Block.StateRoot); err != nil {
+				return nil, err
+			}
+		}
+
+		return true, nil
+	})
+}
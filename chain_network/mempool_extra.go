@@ -3,11 +3,13 @@ package chain_network
 import (
 	"context"
 	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config"
 	"pandora-pay/helpers/recovery"
 	"pandora-pay/mempool"
 	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/api_implementation/api_websockets/consensus"
 	"pandora-pay/network/network_config"
 	"pandora-pay/network/server/node_http"
 	"pandora-pay/network/websocks"
@@ -15,8 +17,17 @@ import (
 	"time"
 )
 
-func broadcastChain(newChainData *blockchain.BlockchainData, ctxDuration time.Duration) {
-	websocks.Websockets.BroadcastJSON([]byte("chain-update"), node_http.HttpServer.ApiWebsockets.Consensus.GetUpdateNotification(newChainData), map[config.NodeConsensusType]bool{config.NODE_CONSENSUS_TYPE_FULL: true, config.NODE_CONSENSUS_TYPE_APP: true}, advanced_connection_types.UUID_ALL, ctxDuration)
+func broadcastChain(newChainData *blockchain.BlockchainData, insertedBlocks []*block_complete.BlockComplete, ctxDuration time.Duration) {
+
+	notification := node_http.HttpServer.ApiWebsockets.Consensus.GetUpdateNotification(newChainData)
+	websocks.Websockets.BroadcastJSON([]byte("chain-update"), notification, map[config.NodeConsensusType]bool{config.NODE_CONSENSUS_TYPE_APP: true}, advanced_connection_types.UUID_ALL, ctxDuration)
+
+	//full nodes receive the last block as a compact block
+	notificationFull := *notification
+	if len(insertedBlocks) > 0 && insertedBlocks[len(insertedBlocks)-1].Height+1 == newChainData.Height {
+		notificationFull.CompactBlock = consensus.NewCompactBlock(insertedBlocks[len(insertedBlocks)-1])
+	}
+	websocks.Websockets.BroadcastJSON([]byte("chain-update"), &notificationFull, map[config.NodeConsensusType]bool{config.NODE_CONSENSUS_TYPE_FULL: true}, advanced_connection_types.UUID_ALL, ctxDuration)
 }
 
 func BroadcastTxs(txs []*transaction.Transaction, justCreated, awaitPropagation bool, exceptSocketUUID advanced_connection_types.UUID, ctxParent context.Context) []error {
@@ -84,7 +95,7 @@ func initializeConsensus() {
 
 			//it is safe to read
 			recovery.SafeGo(func() {
-				broadcastChain(newChainDataUpdate.Update, 0)
+				broadcastChain(newChainDataUpdate.Update, newChainDataUpdate.InsertedBlocks, 0)
 			})
 		}
 
@@ -8,7 +8,7 @@ package arguments
 const commands = `PANDORA CASH.
 
 Usage:
-  pandorapay [--pprof] [--network=network] [--debug] [--gui-type=type] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--node-consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--node-provide-extended-info-app=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--light-computations] [--balance-decrypter-disable-init] [--balance-decrypter-table-size=size] [--tcp-connections-ready=threshold] [--exit] [--skip-init-sync] [--tcp-server-url=url] [--tcp-proxy=PROXY] [--blocks-sync=BLOCKS] [--tcp-proxy-bypass-localhost]
+  pandorapay [cmd <name> [--args-json=json] [--cmd-wait-sync]] [--pprof] [--network=network] [--debug] [--gui-type=type] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--node-consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--sse-max-streams=limit] [--node-provide-extended-info-app=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--delegator-fee=percentage] [--auth-users=args] [--light-computations] [--balance-decrypter-disable-init] [--balance-decrypter-table-size=size] [--tcp-connections-ready=threshold] [--exit] [--skip-init-sync] [--tcp-server-url=url] [--tcp-proxy=PROXY] [--blocks-sync=BLOCKS] [--tcp-proxy-bypass-localhost] [--mempool-max-txs=count] [--mempool-max-size=bytes] [--prune=blocks] [--import-snapshot=path] [--import-snapshot-commitment=hash] [--webhooks-config=path] [--log-dir=path] [--log-format=format] [--log-level=levels] [--log-max-size=MB] [--log-max-age=days] [--rate-limit=rate] [--rate-limit-burst=tokens] [--rate-limit-api-key=rate] [--rate-limit-costs=json] [--rate-limit-sync=rate] [--rate-limit-allowlist=ips]
   pandorapay -h | --help
   pandorapay -v | --version
 
@@ -34,6 +34,7 @@ Options:
   --tcp-server-port=port                             Change node tcp server port [default: 8080].
   --tcp-max-clients=limit                            Change limit of clients [default: 50].
   --tcp-max-server-sockets=limit                     Change limit of servers [default: 500].
+  --sse-max-streams=limit                            Change limit of concurrent Server-Sent Events streams [default: 100].
   --tcp-connections-ready=threshold                  Number of connections to become "ready" state [default: 1].
   --tcp-server-address=address                       Change node tcp address.
   --tcp-server-auto-tls-certificate                  If no certificate.crt is provided, this option will generate a valid TLS certificate via autocert package. You still need a valid domain provided and set --tcp-server-address.
@@ -52,11 +53,31 @@ Options:
   --delegator-enabled=bool                           Enable Delegator. Will allow other users to Delegate to the node. Use "true" to enable it
   --delegator-require-auth=bool                      Delegator will require authentication.
   --delegates-maximum=args                           Maximum number of Delegates
-  --auth-users=args                                  Credential for Authenticated Users. Arguments must be a JSON "[{'user': 'username', 'pass': 'secret'}]".
+  --delegator-fee=percentage                         Percentage of the forged rewards kept by the delegator node operator [default: 0].
+  --auth-users=args                                  Credential for Authenticated Users. Arguments must be a JSON "[{'user': 'username', 'pass': 'secret', 'scopes': ['read']}]". Without scopes the user is an admin.
   --light-computations                               Reduces the computations for a testnet node.
   --balance-decrypter-disable-init                   Disable first balance decrypter initialization. 
   --balance-decrypter-table-size=size                Balance Decrypter initial table size. [default: 23]
   --exit                                             Exit node.
   --skip-init-sync                                   Skip sync wait at when the node started. Useful when creating a new testnet.
   --blocks-sync=BLOCKS                               Number of blocks to download in a batch.
+  --mempool-max-txs=count                            Maximum number of transactions stored in mempool [default: 20000].
+  --mempool-max-size=bytes                           Maximum size in bytes of the transactions stored in mempool [default: 104857600].
+  --prune=blocks                                     Keep only the transactions of the last blocks. Accounts, assets and block headers are kept [default: 0 disabled].
+  --import-snapshot=path                             Bootstrap an empty chain store from a chain state snapshot.
+  --import-snapshot-commitment=hash                  Expected commitment (base64) of the imported snapshot. Required when the snapshot block has no StateRoot.
+  --webhooks-config=path                             Load webhooks from a JSON file "[{'type': 'account|asset|tx', 'address': '', 'key': 'base64', 'url': 'https://', 'secret': ''}]".
+  --log-dir=path                                     Directory of the log files [default: ./logs].
+  --log-format=format                                Log file format. Accepted values: "json|text" [default: json].
+  --log-level=levels                                 Log levels "debug|info|warn|error". Levels can be set by subsystem "info,mempool=debug,consensus=warn,forging=info,network=warn".
+  --log-max-size=MB                                  Rotate the log file when it becomes bigger [default: 100].
+  --log-max-age=days                                 Delete the rotated log files older than this [default: 7].
+  --rate-limit=rate                                  Requests per second allowed for each IP on the public API. 0 disables the limit [default: 0].
+  --rate-limit-burst=tokens                          Maximum requests a client can do at once before being limited [default: 50].
+  --rate-limit-api-key=rate                          Requests per second allowed for each API key. 0 disables the limit for API keys [default: 0].
+  --rate-limit-costs=json                            Cost of the methods. Arguments must be a JSON "{'accounts/by-keys': 5, 'block': 2}". The other methods cost 1.
+  --rate-limit-sync=rate                             Requests per second allowed for each IP on the routes used by the nodes to sync. They use a separate bucket. By default 10 times --rate-limit.
+  --rate-limit-allowlist=ips                         IPs which are never limited, like the own nodes. Arguments must be separated by comma "1.2.3.4,5.6.7.8".
+  --args-json=json                                   Answers for the prompts of "pandorapay cmd <name>". A JSON array answered in order or an object "{'prompt text or prefix': answer or [answers]}".
+  --cmd-wait-sync                                    "pandorapay cmd <name>" waits for the node to be in sync before running the command.
 `
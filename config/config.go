@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/base64"
 	"errors"
 	"github.com/blang/semver"
 	"math/big"
@@ -55,6 +56,7 @@ const (
 
 var (
 	BLOCKS_SYNC_MAX_DOWNLOAD uint64 = 20
+	BLOCKS_SYNC_PEER_TIMEOUT        = 10 * time.Second
 
 	NETWORK_SELECTED                 = MAIN_NET_NETWORK_BYTE
 	NETWORK_SELECTED_BYTE_PREFIX     = MAIN_NET_NETWORK_BYTE_PREFIX
@@ -63,10 +65,29 @@ var (
 	NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.MAIN_NET_DELEGATOR_NODES
 )
 
+var (
+	MEMPOOL_MAX_TXS                        = 20000
+	MEMPOOL_MAX_SIZE                uint64 = 100 * 1024 * 1024
+	MEMPOOL_REPLACE_BY_FEE_INCREASE        = uint64(10) //percentage
+)
+
+var (
+	PRUNE_BLOCKS                uint64 = 0 //0 disabled
+	PRUNE_BLOCKS_MIN            uint64 = 1000
+	PRUNE_BLOCKS_MAX_PER_UPDATE uint64 = 1000
+)
+
+var (
+	IMPORT_SNAPSHOT            = ""
+	IMPORT_SNAPSHOT_COMMITMENT []byte
+)
+
 var (
 	API_MEMPOOL_MAX_TRANSACTIONS = 50
 	API_ACCOUNT_MAX_TXS          = uint64(10)
 	API_ASSETS_INFO_MAX_RESULTS  = 10
+
+	API_CONDITIONAL_PAYMENTS_MAX_RESULTS = uint64(50)
 )
 
 var (
@@ -124,6 +145,37 @@ func InitConfig() (err error) {
 		}
 	}
 
+	if arguments.Arguments["--mempool-max-txs"] != nil {
+		if MEMPOOL_MAX_TXS, err = strconv.Atoi(arguments.Arguments["--mempool-max-txs"].(string)); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--mempool-max-size"] != nil {
+		if MEMPOOL_MAX_SIZE, err = strconv.ParseUint(arguments.Arguments["--mempool-max-size"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--prune"] != nil {
+		if PRUNE_BLOCKS, err = strconv.ParseUint(arguments.Arguments["--prune"].(string), 10, 64); err != nil {
+			return
+		}
+		if PRUNE_BLOCKS > 0 && PRUNE_BLOCKS < PRUNE_BLOCKS_MIN {
+			return errors.New("--prune needs to keep at least " + strconv.FormatUint(PRUNE_BLOCKS_MIN, 10) + " blocks")
+		}
+	}
+
+	if arguments.Arguments["--import-snapshot"] != nil {
+		IMPORT_SNAPSHOT = arguments.Arguments["--import-snapshot"].(string)
+	}
+
+	if arguments.Arguments["--import-snapshot-commitment"] != nil {
+		if IMPORT_SNAPSHOT_COMMITMENT, err = base64.StdEncoding.DecodeString(arguments.Arguments["--import-snapshot-commitment"].(string)); err != nil {
+			return
+		}
+	}
+
 	NODE_PROVIDE_EXTENDED_INFO_APP = false
 	switch arguments.Arguments["--node-consensus"] {
 	case "full":
@@ -139,6 +191,10 @@ func InitConfig() (err error) {
 		return errors.New("invalid consensus argument")
 	}
 
+	if PRUNE_BLOCKS > 0 && NODE_PROVIDE_EXTENDED_INFO_APP {
+		return errors.New("--prune can not be used together with --node-provide-extended-info-app")
+	}
+
 	if err = config_nodes.InitConfig(); err != nil {
 		return
 	}
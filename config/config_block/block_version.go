@@ -0,0 +1,31 @@
+package config_block
+
+import (
+	"math"
+	"pandora-pay/config/arguments"
+)
+
+// blocks from this height commit to the state root. It will be scheduled for mainnet and testnet.
+// Until then only --new-devnet commits to it and the state proof APIs return an error
+const STATE_ROOT_ACTIVATION_HEIGHT = uint64(math.MaxUint64)
+
+const (
+	BLOCK_VERSION_INITIAL    uint64 = 0
+	BLOCK_VERSION_STATE_ROOT uint64 = 1
+)
+
+func GetBlockVersion(blockHeight uint64) uint64 {
+
+	if arguments.Arguments["--new-devnet"] == true {
+		if blockHeight == 0 {
+			return BLOCK_VERSION_INITIAL
+		}
+		return BLOCK_VERSION_STATE_ROOT
+	}
+
+	if blockHeight >= STATE_ROOT_ACTIVATION_HEIGHT {
+		return BLOCK_VERSION_STATE_ROOT
+	}
+
+	return BLOCK_VERSION_INITIAL
+}
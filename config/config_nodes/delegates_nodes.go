@@ -1,6 +1,7 @@
 package config_nodes
 
 import (
+	"errors"
 	"pandora-pay/config/arguments"
 	"strconv"
 )
@@ -60,9 +61,11 @@ var (
 	/* DELEGATES_ALLOWED_ENABLES
 	this will enable accepting delegating for other users their delegated stakes
 	*/
-	DELEGATOR_ENABLED      = false
-	DELEGATOR_REQUIRE_AUTH = false
-	DELEGATES_MAXIMUM      = 10000
+	DELEGATOR_ENABLED               = false
+	DELEGATOR_REQUIRE_AUTH          = false
+	DELEGATES_MAXIMUM               = 10000
+	DELEGATOR_FEE                   = uint64(0)  //percentage of the forged rewards kept by the operator
+	DELEGATOR_REWARDS_CONFIRMATIONS = uint64(10) //blocks on top of a forged block before its reward is credited
 )
 
 func InitConfig() (err error) {
@@ -73,6 +76,15 @@ func InitConfig() (err error) {
 		}
 	}
 
+	if arguments.Arguments["--delegator-fee"] != nil {
+		if DELEGATOR_FEE, err = strconv.ParseUint(arguments.Arguments["--delegator-fee"].(string), 10, 64); err != nil {
+			return
+		}
+		if DELEGATOR_FEE > 100 {
+			return errors.New("--delegator-fee must be a percentage between 0 and 100")
+		}
+	}
+
 	if arguments.Arguments["--delegator-enabled"] == "true" {
 		DELEGATOR_ENABLED = true
 	}
@@ -1,6 +1,7 @@
 package cryptography
 
 import (
+	"crypto/sha256"
 	"errors"
 	"golang.org/x/crypto/ripemd160"
 	"golang.org/x/crypto/sha3"
@@ -13,6 +14,12 @@ func SHA3(b []byte) []byte {
 	return h.Sum(nil)
 }
 
+// SHA256 is used by the hash locks to be compatible with the HTLCs of other chains
+func SHA256(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
 func RIPEMD(b []byte) []byte {
 	h := ripemd160.New()
 	h.Write(b)
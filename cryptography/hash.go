@@ -13,6 +13,9 @@ const SeedSize = 64
 const PublicKeySize = 33
 const SignatureSize = 64
 
+const HashlockSize = 32
+const HashlockPreimageMaxSize = 64
+
 const RipemdSize = 20
 const PublicKeyHashSize = RipemdSize
 const ChecksumSize = 4
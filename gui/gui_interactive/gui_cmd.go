@@ -46,6 +46,7 @@ var commands = []Command{
 	{Name: "Wallet", Text: "Import Entropy"},
 	{Name: "Wallet", Text: "Show Address Secret Key"},
 	{Name: "Wallet", Text: "Import Address Secret Key"},
+	{Name: "Wallet", Text: "Import Watch-only Address"},
 	{Name: "Wallet", Text: "Remove Address"},
 	{Name: "Wallet", Text: "Export Shared Staked Address"},
 	{Name: "Wallet:TX", Text: "Private Transfer"},
@@ -53,10 +54,17 @@ var commands = []Command{
 	{Name: "Wallet:TX", Text: "Private Claim"},
 	{Name: "Wallet:TX", Text: "Private Asset Create"},
 	{Name: "Wallet:TX", Text: "Private Asset Supply Increase"},
+	{Name: "Wallet:TX", Text: "Private Asset Supply Decrease"},
+	{Name: "Wallet:TX", Text: "Private Asset Pause"},
+	{Name: "Wallet:TX", Text: "Private Asset Freeze Supply"},
+	{Name: "Wallet:TX", Text: "Private Asset Change Public Key"},
+	{Name: "Wallet:TX", Text: "Private Asset Update"},
 	{Name: "Wallet:TX", Text: "Private Plain Account Fund"},
 	{Name: "Wallet:TX", Text: "Private Conditional Payment"},
+	{Name: "Wallet:TX", Text: "Private Hashlock Conditional Payment"},
 	{Name: "Wallet:TX", Text: "Public Update Asset Fee Liquidity"},
 	{Name: "Wallet:TX", Text: "Public Resolution Conditional Payment"},
+	{Name: "Wallet:TX", Text: "Public Claim Conditional Payment"},
 	{Name: "Wallet", Text: "Export Addresses"},
 	{Name: "Wallet", Text: "Export Balances JSON"},
 	{Name: "Wallet", Text: "Export Address JSON"},
@@ -66,12 +74,24 @@ var commands = []Command{
 	{Name: "Wallet", Text: "Encrypt Wallet"},
 	{Name: "Wallet", Text: "Decrypt Wallet"},
 	{Name: "Wallet", Text: "Remove Encryption"},
+	{Name: "Wallets", Text: "List Wallets"},
+	{Name: "Wallets", Text: "Create Named Wallet"},
+	{Name: "Wallets", Text: "Select Wallet"},
+	{Name: "Wallets", Text: "Remove Named Wallet"},
 	{Name: "Utils", Text: "Create (PublicKey, PrivateKey) pair"},
 	{Name: "Utils", Text: "Sign message using PrivateKey"},
 	{Name: "Utils", Text: "Verify signed message using PublicKey"},
 	{Name: "Utils", Text: "Sign Resolution Conditional Payment"},
 	{Name: "Blockchain", Text: "New Blockchain Top"},
+	{Name: "Blockchain", Text: "Export Snapshot"},
 	{Name: "Mempool", Text: "Show Txs"},
+	{Name: "Network", Text: "List Known Nodes"},
+	{Name: "Network", Text: "List Banned Nodes"},
+	{Name: "Network", Text: "Ban Node"},
+	{Name: "Network", Text: "Unban Node"},
+	{Name: "API Keys", Text: "List API Keys"},
+	{Name: "API Keys", Text: "Create API Key"},
+	{Name: "API Keys", Text: "Remove API Key"},
 	{Name: "App", Text: "Exit"},
 }
 var commandsLock sync.Mutex
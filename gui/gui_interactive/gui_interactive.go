@@ -47,17 +47,12 @@ func (g *GUIInteractive) Close() {
 	g.tickerRender.Stop()
 	ui.Clear()
 	ui.Close()
-	g.logger.GeneralLog.Close()
+	g.logger.Close()
 }
 
-func CreateGUIInteractive() (*GUIInteractive, error) {
+func CreateGUIInteractive(logger *gui_logger.GUILogger) (g *GUIInteractive, err error) {
 
-	logger, err := gui_logger.CreateLogger()
-	if err != nil {
-		return nil, err
-	}
-
-	g := &GUIInteractive{
+	g = &GUIInteractive{
 		logger:   logger,
 		infoMap:  &generics.Map[string, string]{},
 		info2Map: &generics.Map[string, string]{},
@@ -4,6 +4,7 @@ import (
 	"github.com/gizak/termui/v3/widgets"
 	"pandora-pay/config"
 	"pandora-pay/gui/gui_interface"
+	"pandora-pay/gui/gui_logger"
 	"strings"
 	"time"
 )
@@ -19,9 +20,20 @@ func (g *GUIInteractive) logsRender() {
 	g.logs.Unlock()
 }
 
-func (g *GUIInteractive) message(prefix string, color string, any ...interface{}) {
+func (g *GUIInteractive) write(level gui_logger.Level, subsystem, color, text string, fields map[string]any) {
 
-	text := gui_interface.ProcessArgument(any...)
+	if !g.logger.Enabled(level, subsystem) {
+		return
+	}
+
+	g.logger.Write(level, subsystem, text, fields)
+
+	if subsystem != "" {
+		text = "[" + subsystem + "] " + text
+	}
+	if len(fields) > 0 {
+		text += " " + gui_logger.FormatText(fields)
+	}
 
 	if config.DEBUG {
 		text = time.Now().Format("2006-01-02 15:04:05  ") + text
@@ -29,34 +41,50 @@ func (g *GUIInteractive) message(prefix string, color string, any ...interface{}
 		text = time.Now().Format("15:04:05  ") + text
 	}
 
-	final1 := prefix + " " + text + config.LineBreak
-	final2 := "[" + text + "]" + color + config.LineBreak
+	//termui uses [text](style) and it would break on the brackets of the text
+	text = strings.NewReplacer("[", "(", "]", ")").Replace(text)
 
 	g.logs.Lock()
-	g.logger.GeneralLog.WriteString(final1)
-	g.logs.Text += final2
+	g.logs.Text += "[" + text + "]" + color + config.LineBreak
 	g.logs.Unlock()
 }
 
+func (g *GUIInteractive) message(level gui_logger.Level, color string, any ...interface{}) {
+	g.write(level, "", color, gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUIInteractive) LogFields(level gui_logger.Level, subsystem, message string, fields ...any) {
+	switch level {
+	case gui_logger.LEVEL_DEBUG:
+		g.write(level, subsystem, "(fg:white)", message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_INFO:
+		g.write(level, subsystem, "(fg:blue)", message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_WARN:
+		g.write(level, subsystem, "(fg:yellow)", message, gui_logger.FormatFields(fields))
+	default:
+		g.write(level, subsystem, "(fg:red)", message, gui_logger.FormatFields(fields))
+	}
+}
+
 func (g *GUIInteractive) Log(any ...interface{}) {
-	g.message("LOG", "()", any...)
+	g.message(gui_logger.LEVEL_INFO, "()", any...)
 }
 
 func (g *GUIInteractive) Info(any ...interface{}) {
-	g.message("INF", "(fg:blue)", any...)
+	g.message(gui_logger.LEVEL_INFO, "(fg:blue)", any...)
 }
 
 func (g *GUIInteractive) Warning(any ...interface{}) {
-	g.message("WARN", "(fg:yellow)", any...)
+	g.message(gui_logger.LEVEL_WARN, "(fg:yellow)", any...)
 }
 
 func (g *GUIInteractive) Fatal(any ...interface{}) {
-	g.message("FATAL", "(fg:red,fg:bold)", any...)
+	g.message(gui_logger.LEVEL_FATAL, "(fg:red,fg:bold)", any...)
 	panic(any)
 }
 
 func (g *GUIInteractive) Error(any ...interface{}) {
-	g.message("ERR", "(fg:red)", any...)
+	g.message(gui_logger.LEVEL_ERROR, "(fg:red)", any...)
 }
 
 func (g *GUIInteractive) logsInit() {
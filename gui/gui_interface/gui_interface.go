@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"pandora-pay/gui/gui_logger"
 	"strconv"
 )
 
@@ -17,6 +18,7 @@ type GUIInterface interface {
 	Warning(any ...any)
 	Fatal(any ...any)
 	Error(any ...any)
+	LogFields(level gui_logger.Level, subsystem, message string, fields ...any)
 	InfoUpdate(key string, text string)
 	Info2Update(key string, text string)
 	OutputWrite(any ...any)
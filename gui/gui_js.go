@@ -6,7 +6,7 @@ package gui
 import gui_non_interactive "pandora-pay/gui/gui_non_interactive"
 
 func create_gui() (err error) {
-	if GUI, err = gui_non_interactive.CreateGUINonInteractive(); err != nil {
+	if GUI, err = gui_non_interactive.CreateGUINonInteractive(nil); err != nil {
 		return
 	}
 	return
@@ -1,31 +1,311 @@
 package gui_logger
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+type Level uint8
+
+const (
+	LEVEL_DEBUG Level = iota
+	LEVEL_INFO
+	LEVEL_WARN
+	LEVEL_ERROR
+	LEVEL_FATAL
+)
+
+func (level Level) String() string {
+	switch level {
+	case LEVEL_DEBUG:
+		return "debug"
+	case LEVEL_INFO:
+		return "info"
+	case LEVEL_WARN:
+		return "warn"
+	case LEVEL_ERROR:
+		return "error"
+	case LEVEL_FATAL:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LEVEL_DEBUG, nil
+	case "info":
+		return LEVEL_INFO, nil
+	case "warn", "warning":
+		return LEVEL_WARN, nil
+	case "error":
+		return LEVEL_ERROR, nil
+	case "fatal":
+		return LEVEL_FATAL, nil
+	default:
+		return 0, errors.New("Invalid log level " + s)
+	}
+}
+
+type LoggerConfig struct {
+	Dir     string
+	Format  string           //json or text
+	Level   Level            //default level
+	Levels  map[string]Level //level by subsystem
+	MaxSize int64            //bytes. The file is rotated when it becomes bigger
+	MaxAge  time.Duration    //rotated files older than this are deleted
+}
+
+// ParseLevels parses "info,mempool=debug,consensus=warn". The entry without a subsystem is the default level
+func (config *LoggerConfig) ParseLevels(s string) error {
+	for _, it := range strings.Split(s, ",") {
+		if it = strings.TrimSpace(it); it == "" {
+			continue
+		}
+		subsystem, levelStr, found := strings.Cut(it, "=")
+		if !found {
+			levelStr = subsystem
+			subsystem = ""
+		}
+		level, err := ParseLevel(levelStr)
+		if err != nil {
+			return err
+		}
+		if subsystem == "" {
+			config.Level = level
+		} else {
+			config.Levels[subsystem] = level
+		}
+	}
+	return nil
+}
+
+func NewLoggerConfig() *LoggerConfig {
+	return &LoggerConfig{"./logs", "json", LEVEL_INFO, map[string]Level{}, 100 * 1024 * 1024, 7 * 24 * time.Hour}
+}
+
 type GUILogger struct {
-	GeneralLog *os.File
+	config *LoggerConfig
+	file   *os.File
+	size   int64
+	lock   sync.Mutex
+}
+
+const currentFilename = "pandora.log"
+
+// Enabled returns if messages of the given level are written for a subsystem. A nil logger (wasm) enables info and above
+func (logger *GUILogger) Enabled(level Level, subsystem string) bool {
+	if logger == nil {
+		return level >= LEVEL_INFO
+	}
+	if subsystemLevel, ok := logger.config.Levels[subsystem]; ok {
+		return level >= subsystemLevel
+	}
+	return level >= logger.config.Level
+}
+
+// FormatFields converts key/value pairs into a map. A key without value gets nil
+func FormatFields(fields []any) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	out := make(map[string]any, (len(fields)+1)/2)
+	for i := 0; i < len(fields); i += 2 {
+
+		key, ok := fields[i].(string)
+		if !ok {
+			key = "field" + strconv.Itoa(i/2)
+		}
+
+		var value any
+		if i+1 < len(fields) {
+			value = fields[i+1]
+			if err, ok := value.(error); ok {
+				value = err.Error()
+			}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// FormatText formats the fields as key=value
+func FormatText(fields map[string]any) string {
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	s := make([]string, len(keys))
+	for i, key := range keys {
+		var value string
+		switch v := fields[key].(type) {
+		case string:
+			value = v
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				value = "error marshaling object"
+			} else {
+				value = string(data)
+			}
+		}
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		s[i] = key + "=" + value
+	}
+	return strings.Join(s, " ")
+}
+
+type logEntry struct {
+	Time      string         `json:"time"`
+	Level     string         `json:"level"`
+	Subsystem string         `json:"subsystem,omitempty"`
+	Message   string         `json:"msg"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+func (logger *GUILogger) format(t time.Time, level Level, subsystem, message string, fields map[string]any) []byte {
+
+	if logger.config.Format == "json" {
+		data, err := json.Marshal(&logEntry{t.UTC().Format(time.RFC3339Nano), level.String(), subsystem, message, fields})
+		if err == nil {
+			return append(data, '\n')
+		}
+		data, _ = json.Marshal(&logEntry{t.UTC().Format(time.RFC3339Nano), level.String(), subsystem, message, map[string]any{"error": err.Error()}})
+		return append(data, '\n')
+	}
+
+	s := t.Format("2006-01-02 15:04:05") + " " + strings.ToUpper(level.String())
+	if subsystem != "" {
+		s += " [" + subsystem + "]"
+	}
+	s += " " + message
+	if len(fields) > 0 {
+		s += " " + FormatText(fields)
+	}
+	return []byte(s + "\n")
+}
+
+// Write writes the message if the level is enabled for the subsystem
+func (logger *GUILogger) Write(level Level, subsystem, message string, fields map[string]any) {
+
+	if logger == nil || !logger.Enabled(level, subsystem) {
+		return
+	}
+
+	data := logger.format(time.Now(), level, subsystem, message, fields)
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.file == nil {
+		return
+	}
+
+	if logger.config.MaxSize > 0 && logger.size+int64(len(data)) > logger.config.MaxSize {
+		if err := logger.rotate(); err != nil {
+			return
+		}
+	}
+
+	n, _ := logger.file.Write(data)
+	logger.size += int64(n)
+}
+
+// rotate renames the current file and opens a new one. It is called locked
+func (logger *GUILogger) rotate() (err error) {
+
+	if err = logger.file.Close(); err != nil {
+		return
+	}
+	logger.file = nil
+
+	rotated := "log_" + time.Now().Format("2006_01_02_15_04_05.000") + ".log"
+	if err = os.Rename(filepath.Join(logger.config.Dir, currentFilename), filepath.Join(logger.config.Dir, rotated)); err != nil {
+		return
+	}
+
+	logger.removeOld()
+
+	return logger.open()
 }
 
-func CreateLogger() (*GUILogger, error) {
+// removeOld deletes the rotated files older than MaxAge
+func (logger *GUILogger) removeOld() {
 
-	logger := &GUILogger{}
-	var err error
+	if logger.config.MaxAge <= 0 {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(logger.config.Dir, "log_*.log"))
+	if err != nil {
+		return
+	}
 
-	if _, err = os.Stat("./logs"); os.IsNotExist(err) {
-		if err = os.Mkdir("./logs", 0755); err != nil {
-			return nil, err
+	expiration := time.Now().Add(-logger.config.MaxAge)
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().Before(expiration) {
+			os.Remove(file)
 		}
 	}
+}
+
+func (logger *GUILogger) open() (err error) {
+
+	if logger.file, err = os.OpenFile(filepath.Join(logger.config.Dir, currentFilename), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666); err != nil {
+		return
+	}
+
+	info, err := logger.file.Stat()
+	if err != nil {
+		return
+	}
+	logger.size = info.Size()
 
-	t := time.Now()
-	filename := "log_" + t.Format("2006_01_02") + ".log"
+	return
+}
+
+func (logger *GUILogger) Close() error {
+	if logger == nil {
+		return nil
+	}
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.file == nil {
+		return nil
+	}
+	err := logger.file.Close()
+	logger.file = nil
+	return err
+}
+
+func CreateLogger(config *LoggerConfig) (*GUILogger, error) {
+
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
 
-	if logger.GeneralLog, err = os.OpenFile("./logs/"+filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666); err != nil {
+	logger := &GUILogger{config: config}
+	if err := logger.open(); err != nil {
 		return nil, err
 	}
+	logger.removeOld()
 
 	return logger, nil
 }
@@ -0,0 +1,49 @@
+package gui_logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoggerLevelsAndRotation(t *testing.T) {
+
+	config := NewLoggerConfig()
+	config.Dir = t.TempDir()
+	config.MaxSize = 300
+	assert.Nil(t, config.ParseLevels("warn,mempool=debug"))
+
+	logger, err := CreateLogger(config)
+	assert.Nil(t, err)
+	defer logger.Close()
+
+	assert.True(t, logger.Enabled(LEVEL_DEBUG, "mempool"))
+	assert.False(t, logger.Enabled(LEVEL_INFO, "network"))
+
+	logger.Write(LEVEL_INFO, "network", "skipped", nil)
+	logger.Write(LEVEL_DEBUG, "mempool", "tx added", FormatFields([]any{"hash", "abc", "size", 10}))
+
+	file, err := os.Open(filepath.Join(config.Dir, currentFilename))
+	assert.Nil(t, err)
+	scanner := bufio.NewScanner(file)
+	assert.True(t, scanner.Scan())
+
+	entry := &logEntry{}
+	assert.Nil(t, json.Unmarshal(scanner.Bytes(), entry))
+	assert.Equal(t, "debug", entry.Level)
+	assert.Equal(t, "mempool", entry.Subsystem)
+	assert.Equal(t, "abc", entry.Fields["hash"])
+	assert.False(t, scanner.Scan())
+	file.Close()
+
+	for i := 0; i < 5; i++ {
+		logger.Write(LEVEL_ERROR, "mempool", "error", FormatFields([]any{"i", i}))
+	}
+
+	rotated, err := filepath.Glob(filepath.Join(config.Dir, "log_*.log"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, rotated)
+}
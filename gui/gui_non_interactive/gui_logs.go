@@ -3,34 +3,65 @@ package gui_non_interactive
 import (
 	"fmt"
 	"pandora-pay/gui/gui_interface"
+	"pandora-pay/gui/gui_logger"
 )
 
-func (g *GUINonInteractive) message(prefix string, color string, any ...interface{}) {
-	text := gui_interface.ProcessArgument(any...)
-	final := prefix + " " + color + " " + text
+func (g *GUINonInteractive) write(level gui_logger.Level, subsystem, prefix, color, text string, fields map[string]any) {
+
+	if !g.logger.Enabled(level, subsystem) {
+		return
+	}
+
+	g.logger.Write(level, subsystem, text, fields)
+
+	final := prefix + " " + color + " "
+	if subsystem != "" {
+		final += "[" + subsystem + "] "
+	}
+	final += text
+	if len(fields) > 0 {
+		final += " " + gui_logger.FormatText(fields)
+	}
 
 	g.writingMutex.Lock()
 	fmt.Println(final)
 	g.writingMutex.Unlock()
 }
 
+func (g *GUINonInteractive) message(level gui_logger.Level, prefix string, color string, any ...interface{}) {
+	g.write(level, "", prefix, color, gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUINonInteractive) LogFields(level gui_logger.Level, subsystem, message string, fields ...any) {
+	switch level {
+	case gui_logger.LEVEL_DEBUG:
+		g.write(level, subsystem, "DBG", g.colorLog, message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_INFO:
+		g.write(level, subsystem, "INF", g.colorInfo, message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_WARN:
+		g.write(level, subsystem, "WARN", g.colorWarning, message, gui_logger.FormatFields(fields))
+	default:
+		g.write(level, subsystem, "ERR", g.colorError, message, gui_logger.FormatFields(fields))
+	}
+}
+
 func (g *GUINonInteractive) Log(any ...any) {
-	g.message("LOG", g.colorLog, any...)
+	g.message(gui_logger.LEVEL_INFO, "LOG", g.colorLog, any...)
 }
 
 func (g *GUINonInteractive) Info(any ...any) {
-	g.message("INF", g.colorInfo, any...)
+	g.message(gui_logger.LEVEL_INFO, "INF", g.colorInfo, any...)
 }
 
 func (g *GUINonInteractive) Warning(any ...any) {
-	g.message("WARN", g.colorWarning, any...)
+	g.message(gui_logger.LEVEL_WARN, "WARN", g.colorWarning, any...)
 }
 
 func (g *GUINonInteractive) Fatal(any ...any) {
-	g.message("FATAL", g.colorFatal, any...)
+	g.message(gui_logger.LEVEL_FATAL, "FATAL", g.colorFatal, any...)
 	panic(any)
 }
 
 func (g *GUINonInteractive) Error(any ...any) {
-	g.message("ERR", g.colorError, any...)
+	g.message(gui_logger.LEVEL_ERROR, "ERR", g.colorError, any...)
 }
@@ -18,11 +18,13 @@ type GUINonInteractive struct {
 }
 
 func (g *GUINonInteractive) Close() {
+	g.logger.Close()
 }
 
-func CreateGUINonInteractive() (*GUINonInteractive, error) {
+// CreateGUINonInteractive prints the messages to stdout. The logger is optional (nil in wasm)
+func CreateGUINonInteractive(logger *gui_logger.GUILogger) (*GUINonInteractive, error) {
 
-	g := &GUINonInteractive{}
+	g := &GUINonInteractive{logger: logger}
 
 	switch runtime.GOARCH {
 	default:
@@ -7,20 +7,76 @@ import (
 	"errors"
 	"pandora-pay/config/arguments"
 	"pandora-pay/gui/gui_interactive"
+	"pandora-pay/gui/gui_logger"
 	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/gui/gui_script"
+	"strconv"
+	"time"
 )
 
+func create_logger() (*gui_logger.GUILogger, error) {
+
+	config := gui_logger.NewLoggerConfig()
+
+	if arguments.Arguments["--log-dir"] != nil {
+		config.Dir = arguments.Arguments["--log-dir"].(string)
+	}
+
+	if arguments.Arguments["--log-format"] != nil {
+		config.Format = arguments.Arguments["--log-format"].(string)
+		if config.Format != "json" && config.Format != "text" {
+			return nil, errors.New("invalid --log-format argument")
+		}
+	}
+
+	if arguments.Arguments["--log-level"] != nil {
+		if err := config.ParseLevels(arguments.Arguments["--log-level"].(string)); err != nil {
+			return nil, err
+		}
+	}
+
+	if arguments.Arguments["--log-max-size"] != nil {
+		size, err := strconv.ParseInt(arguments.Arguments["--log-max-size"].(string), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid --log-max-size argument")
+		}
+		config.MaxSize = size * 1024 * 1024
+	}
+
+	if arguments.Arguments["--log-max-age"] != nil {
+		days, err := strconv.ParseInt(arguments.Arguments["--log-max-age"].(string), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid --log-max-age argument")
+		}
+		config.MaxAge = time.Duration(days) * 24 * time.Hour
+	}
+
+	return gui_logger.CreateLogger(config)
+}
+
 func create_gui() (err error) {
 
-	if arguments.Arguments["--gui-type"] == "non-interactive" {
-		GUI, err = gui_non_interactive.CreateGUINonInteractive()
+	logger, err := create_logger()
+	if err != nil {
+		return
+	}
+
+	if arguments.Arguments["cmd"] == true {
+		argsJSON := ""
+		if arguments.Arguments["--args-json"] != nil {
+			argsJSON = arguments.Arguments["--args-json"].(string)
+		}
+		GUI, err = gui_script.CreateGUIScript(logger, argsJSON)
+	} else if arguments.Arguments["--gui-type"] == "non-interactive" {
+		GUI, err = gui_non_interactive.CreateGUINonInteractive(logger)
 	} else if arguments.Arguments["--gui-type"] == "interactive" {
-		GUI, err = gui_interactive.CreateGUIInteractive()
+		GUI, err = gui_interactive.CreateGUIInteractive(logger)
 	} else {
 		err = errors.New("invalid --gui-type argument")
 	}
 
 	if err != nil {
+		logger.Close()
 		return
 	}
 
@@ -0,0 +1,321 @@
+package gui_script
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"pandora-pay/gui/gui_interface"
+	"pandora-pay/gui/gui_logger"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type scriptCommand struct {
+	text     string
+	callback func(string, context.Context) error
+}
+
+// GUIScript runs a single command without a terminal. The prompts are answered from --args-json, the messages are printed to stderr and the output is collected
+type GUIScript struct {
+	logger       *gui_logger.GUILogger
+	answers      *scriptAnswers
+	commands     []*scriptCommand
+	commandsLock sync.Mutex
+	output       []string
+	outputLock   sync.Mutex
+	writingMutex sync.Mutex
+}
+
+type ScriptResult struct {
+	Command string   `json:"command"`
+	Output  []string `json:"output"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func (g *GUIScript) write(level gui_logger.Level, subsystem, prefix, text string, fields map[string]any) {
+
+	if !g.logger.Enabled(level, subsystem) {
+		return
+	}
+
+	g.logger.Write(level, subsystem, text, fields)
+
+	final := prefix + " "
+	if subsystem != "" {
+		final += "[" + subsystem + "] "
+	}
+	final += text
+	if len(fields) > 0 {
+		final += " " + gui_logger.FormatText(fields)
+	}
+
+	g.writingMutex.Lock()
+	fmt.Fprintln(os.Stderr, final)
+	g.writingMutex.Unlock()
+}
+
+func (g *GUIScript) LogFields(level gui_logger.Level, subsystem, message string, fields ...any) {
+	switch level {
+	case gui_logger.LEVEL_DEBUG:
+		g.write(level, subsystem, "DBG", message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_INFO:
+		g.write(level, subsystem, "INF", message, gui_logger.FormatFields(fields))
+	case gui_logger.LEVEL_WARN:
+		g.write(level, subsystem, "WARN", message, gui_logger.FormatFields(fields))
+	default:
+		g.write(level, subsystem, "ERR", message, gui_logger.FormatFields(fields))
+	}
+}
+
+func (g *GUIScript) Log(any ...any) {
+	g.write(gui_logger.LEVEL_INFO, "", "LOG", gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUIScript) Info(any ...any) {
+	g.write(gui_logger.LEVEL_INFO, "", "INF", gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUIScript) Warning(any ...any) {
+	g.write(gui_logger.LEVEL_WARN, "", "WARN", gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUIScript) Fatal(any ...any) {
+	g.write(gui_logger.LEVEL_FATAL, "", "FATAL", gui_interface.ProcessArgument(any...), nil)
+	panic(any)
+}
+
+func (g *GUIScript) Error(any ...any) {
+	g.write(gui_logger.LEVEL_ERROR, "", "ERR", gui_interface.ProcessArgument(any...), nil)
+}
+
+func (g *GUIScript) Close() {
+	g.logger.Close()
+}
+
+func (g *GUIScript) InfoUpdate(key string, text string) {
+}
+
+func (g *GUIScript) Info2Update(key string, text string) {
+}
+
+func (g *GUIScript) OutputWrite(any ...any) {
+	g.outputLock.Lock()
+	g.output = append(g.output, gui_interface.ProcessArgument(any...))
+	g.outputLock.Unlock()
+}
+
+func (g *GUIScript) CommandDefineCallback(Text string, callback func(string, context.Context) error, useIt bool) {
+
+	if !useIt {
+		callback = nil
+	}
+
+	g.commandsLock.Lock()
+	defer g.commandsLock.Unlock()
+
+	for _, command := range g.commands {
+		if command.text == Text {
+			command.callback = callback
+			return
+		}
+	}
+	g.commands = append(g.commands, &scriptCommand{Text, callback})
+}
+
+// OutputReadString panics with an error when no answer is found. The panic is recovered by Execute like the interactive GUI does with ErrorGUISuspended
+func (g *GUIScript) OutputReadString(text string) string {
+	out, err := g.answers.next(text)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func (g *GUIScript) OutputReadFilename(text, extension string, allowEmpty bool) string {
+	out := g.OutputReadString(text)
+	if len(out) == 0 {
+		if allowEmpty {
+			return ""
+		}
+		panic(errors.New("Invalid answer for: " + text + ". Filename is empty"))
+	}
+	if path.Ext(out) == "" {
+		out += "." + extension
+	}
+	return out
+}
+
+func (g *GUIScript) OutputReadInt(text string, allowEmpty bool, emptyValue int, validateCb func(int) bool) int {
+
+	str := g.OutputReadString(text)
+	if allowEmpty && str == "" {
+		return emptyValue
+	}
+
+	out, err := strconv.Atoi(str)
+	if err != nil {
+		panic(errors.New("Invalid answer for: " + text + ". Invalid Number"))
+	}
+	if validateCb != nil && !validateCb(out) {
+		panic(errors.New("Invalid answer for: " + text))
+	}
+	return out
+}
+
+func (g *GUIScript) OutputReadUint64(text string, allowEmpty bool, emptyValue uint64, validateCb func(uint64) bool) uint64 {
+
+	str := g.OutputReadString(text)
+	if allowEmpty && str == "" {
+		return emptyValue
+	}
+
+	out, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		panic(errors.New("Invalid answer for: " + text + ". Invalid Number"))
+	}
+	if validateCb != nil && !validateCb(out) {
+		panic(errors.New("Invalid answer for: " + text))
+	}
+	return out
+}
+
+func (g *GUIScript) OutputReadFloat64(text string, allowEmpty bool, emptyValue float64, validateCb func(float64) bool) float64 {
+
+	str := g.OutputReadString(text)
+	if allowEmpty && str == "" {
+		return emptyValue
+	}
+
+	out, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		panic(errors.New("Invalid answer for: " + text + ". Invalid Number"))
+	}
+	if validateCb != nil && !validateCb(out) {
+		panic(errors.New("Invalid answer for: " + text))
+	}
+	return out
+}
+
+func (g *GUIScript) OutputReadBool(text string, allowEmpty bool, emptyValue bool) bool {
+
+	str := g.OutputReadString(text)
+	if allowEmpty && str == "" {
+		return emptyValue
+	}
+
+	switch str {
+	case "y", "true":
+		return true
+	case "n", "false":
+		return false
+	}
+	panic(errors.New("Invalid answer for: " + text + ". Invalid boolean answer"))
+}
+
+func (g *GUIScript) OutputReadBytes(text string, validateCb func([]byte) bool) []byte {
+
+	input, err := base64.StdEncoding.DecodeString(g.OutputReadString(text))
+	if err != nil {
+		panic(errors.New("Invalid answer for: " + text + ". The input has to be a base64"))
+	}
+	if validateCb != nil && !validateCb(input) {
+		panic(errors.New("Invalid answer for: " + text))
+	}
+	return input
+}
+
+// normalizeCommandName allows "Export Balances JSON", "export-balances-json" and "export_balances_json"
+func normalizeCommandName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return -1
+	}, name)
+}
+
+func (g *GUIScript) findCommand(name string) *scriptCommand {
+
+	g.commandsLock.Lock()
+	defer g.commandsLock.Unlock()
+
+	normalized := normalizeCommandName(name)
+	for _, command := range g.commands {
+		if command.callback != nil && normalizeCommandName(command.text) == normalized {
+			return command
+		}
+	}
+	return nil
+}
+
+func (g *GUIScript) listCommands() (list []string) {
+
+	g.commandsLock.Lock()
+	defer g.commandsLock.Unlock()
+
+	for _, command := range g.commands {
+		if command.callback != nil {
+			list = append(list, command.text)
+		}
+	}
+	return
+}
+
+// Execute runs the command and returns the output written by it. The name "list" returns the available commands
+func (g *GUIScript) Execute(name string, ctx context.Context) (result *ScriptResult) {
+
+	result = &ScriptResult{Command: name, Output: []string{}}
+
+	if normalizeCommandName(name) == "list" {
+		result.Output = g.listCommands()
+		return
+	}
+
+	command := g.findCommand(name)
+	if command == nil {
+		result.Error = "Command " + name + " was not found"
+		return
+	}
+	result.Command = command.text
+
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok {
+				result.Error = err.Error()
+			} else {
+				result.Error = gui_interface.ProcessArgument(r)
+			}
+		}
+
+		g.outputLock.Lock()
+		result.Output = append(result.Output, g.output...)
+		g.output = nil
+		g.outputLock.Unlock()
+	}()
+
+	if err := command.callback(command.text, ctx); err != nil {
+		result.Error = err.Error()
+	}
+
+	return
+}
+
+func CreateGUIScript(logger *gui_logger.GUILogger, argsJSON string) (*GUIScript, error) {
+
+	answers, err := parseAnswers([]byte(argsJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GUIScript{
+		logger:  logger,
+		answers: answers,
+	}, nil
+}
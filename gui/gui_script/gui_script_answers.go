@@ -0,0 +1,113 @@
+package gui_script
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// scriptAnswers feeds the answers of the OutputRead* prompts.
+// A JSON array is consumed in order. A JSON object maps the prompt text (or a prefix of it) to an answer or to a list of answers used for repeated prompts.
+// Every prompt must be answered. An empty string or null keeps the default of an optional prompt
+type scriptAnswers struct {
+	list      []any
+	byPrompt  map[string][]any
+	positions map[string]int
+	lock      sync.Mutex
+}
+
+func (this *scriptAnswers) next(text string) (string, error) {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.byPrompt == nil {
+		if len(this.list) == 0 {
+			return "", errors.New("Missing answer for: " + text)
+		}
+		value := this.list[0]
+		this.list = this.list[1:]
+		return answerToString(value)
+	}
+
+	//the longest key which is a prefix of the prompt
+	key, found := "", false
+	for k := range this.byPrompt {
+		if strings.HasPrefix(text, k) && (!found || len(k) > len(key)) {
+			key, found = k, true
+		}
+	}
+	if !found {
+		return "", errors.New("Missing answer for: " + text)
+	}
+
+	values := this.byPrompt[key]
+	position := this.positions[key]
+	if position >= len(values) {
+		return "", errors.New("Missing answer for: " + text)
+	}
+	this.positions[key] = position + 1
+
+	return answerToString(values[position])
+}
+
+func answerToString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		if v {
+			return "y", nil
+		}
+		return "n", nil
+	default: //objects are passed as JSON, for instance "Asset as JSON"
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func parseAnswers(data []byte) (*scriptAnswers, error) {
+
+	answers := &scriptAnswers{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return answers, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var out any
+	if err := decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+
+	switch v := out.(type) {
+	case []any:
+		answers.list = v
+	case map[string]any:
+		answers.byPrompt = make(map[string][]any)
+		answers.positions = make(map[string]int)
+		for key, value := range v {
+			if list, ok := value.([]any); ok {
+				answers.byPrompt[key] = list
+			} else {
+				answers.byPrompt[key] = []any{value}
+			}
+		}
+	default:
+		return nil, errors.New("--args-json must be a JSON array or object")
+	}
+
+	return answers, nil
+}
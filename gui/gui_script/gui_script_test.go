@@ -0,0 +1,63 @@
+package gui_script
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestGUIScriptExecute(t *testing.T) {
+
+	define := func(g *GUIScript) {
+		g.CommandDefineCallback("Private Transfer", func(cmd string, ctx context.Context) error {
+			amount := g.OutputReadUint64("Amount", false, 0, nil)
+			propagate := g.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+			g.OutputWrite("Amount " + strconv.FormatUint(amount, 10) + " " + strconv.FormatBool(propagate))
+			return nil
+		}, true)
+	}
+
+	g, err := CreateGUIScript(nil, `[10, false]`)
+	assert.Nil(t, err)
+	define(g)
+
+	result := g.Execute("private-transfer", context.Background())
+	assert.Equal(t, "Private Transfer", result.Command)
+	assert.Equal(t, "", result.Error)
+	assert.Equal(t, []string{"Amount 10 false"}, result.Output)
+
+	g, err = CreateGUIScript(nil, `{"Amount": "12", "Propagate?": ""}`)
+	assert.Nil(t, err)
+	define(g)
+
+	result = g.Execute("Private Transfer", context.Background())
+	assert.Equal(t, []string{"Amount 12 true"}, result.Output)
+
+	//every prompt must be answered in both modes
+	g, err = CreateGUIScript(nil, `{"Amount": "12"}`)
+	assert.Nil(t, err)
+	define(g)
+
+	result = g.Execute("Private Transfer", context.Background())
+	assert.Equal(t, "Missing answer for: Propagate? y/n. Leave empty for yes", result.Error)
+
+	g, err = CreateGUIScript(nil, `[12]`)
+	assert.Nil(t, err)
+	define(g)
+
+	result = g.Execute("Private Transfer", context.Background())
+	assert.Equal(t, "Missing answer for: Propagate? y/n. Leave empty for yes", result.Error)
+
+	g, err = CreateGUIScript(nil, `["abc"]`)
+	assert.Nil(t, err)
+	define(g)
+
+	result = g.Execute("private_transfer", context.Background())
+	assert.Equal(t, "Invalid answer for: Amount. Invalid Number", result.Error)
+
+	result = g.Execute("unknown", context.Background())
+	assert.NotEqual(t, "", result.Error)
+
+	assert.Equal(t, []string{"Private Transfer"}, g.Execute("list", context.Background()).Output)
+}
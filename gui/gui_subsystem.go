@@ -0,0 +1,28 @@
+package gui
+
+import "pandora-pay/gui/gui_logger"
+
+// SubsystemLogger writes structured messages tagged with the subsystem. The level of every subsystem is configured via --log-level
+type SubsystemLogger struct {
+	name string
+}
+
+func (this *SubsystemLogger) Debug(message string, fields ...any) {
+	GUI.LogFields(gui_logger.LEVEL_DEBUG, this.name, message, fields...)
+}
+
+func (this *SubsystemLogger) Info(message string, fields ...any) {
+	GUI.LogFields(gui_logger.LEVEL_INFO, this.name, message, fields...)
+}
+
+func (this *SubsystemLogger) Warn(message string, fields ...any) {
+	GUI.LogFields(gui_logger.LEVEL_WARN, this.name, message, fields...)
+}
+
+func (this *SubsystemLogger) Error(message string, fields ...any) {
+	GUI.LogFields(gui_logger.LEVEL_ERROR, this.name, message, fields...)
+}
+
+func NewSubsystemLogger(name string) *SubsystemLogger {
+	return &SubsystemLogger{name}
+}
@@ -0,0 +1,236 @@
+package metrics
+
+import (
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// metric writes itself using the Prometheus text exposition format
+type metric interface {
+	write(w io.Writer, name string)
+}
+
+type registeredMetric struct {
+	name       string
+	help       string
+	metricType string
+	metric     metric
+}
+
+// DurationBuckets are the default buckets in seconds used for latencies
+var DurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var (
+	registry     = map[string]*registeredMetric{}
+	registryList []*registeredMetric
+	registryLock sync.RWMutex
+)
+
+// register replaces an existing metric with the same name
+func register(name, help, metricType string, m metric) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if found := registry[name]; found != nil {
+		found.help = help
+		found.metric = m
+		return
+	}
+
+	it := &registeredMetric{name, help, metricType, m}
+	registry[name] = it
+	registryList = append(registryList, it)
+}
+
+func escapeLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	s := make([]string, len(names))
+	for i := range names {
+		s[i] = names[i] + `="` + escapeLabel(values[i]) + `"`
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+type Counter struct {
+	value uint64
+}
+
+func (this *Counter) Add(delta uint64) {
+	atomic.AddUint64(&this.value, delta)
+}
+
+func (this *Counter) Inc() {
+	atomic.AddUint64(&this.value, 1)
+}
+
+func (this *Counter) write(w io.Writer, name string) {
+	io.WriteString(w, name+" "+strconv.FormatUint(atomic.LoadUint64(&this.value), 10)+"\n")
+}
+
+type Gauge struct {
+	bits uint64
+}
+
+func (this *Gauge) Set(value float64) {
+	atomic.StoreUint64(&this.bits, math.Float64bits(value))
+}
+
+func (this *Gauge) write(w io.Writer, name string) {
+	io.WriteString(w, name+" "+formatFloat(math.Float64frombits(atomic.LoadUint64(&this.bits)))+"\n")
+}
+
+type gaugeFunc struct {
+	callback func() float64
+}
+
+func (this *gaugeFunc) write(w io.Writer, name string) {
+	io.WriteString(w, name+" "+formatFloat(this.callback())+"\n")
+}
+
+type gaugeVecFunc struct {
+	label    string
+	callback func() map[string]float64
+}
+
+func (this *gaugeVecFunc) write(w io.Writer, name string) {
+
+	values := this.callback()
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		io.WriteString(w, name+formatLabels([]string{this.label}, []string{key})+" "+formatFloat(values[key])+"\n")
+	}
+}
+
+type histogram struct {
+	labels  []string
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+type HistogramVec struct {
+	labels  []string
+	buckets []float64
+	values  map[string]*histogram
+	lock    sync.Mutex
+}
+
+func (this *HistogramVec) Observe(value float64, labels ...string) {
+
+	key := strings.Join(labels, "\x00")
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	h := this.values[key]
+	if h == nil {
+		h = &histogram{labels, make([]uint64, len(this.buckets)), 0, 0}
+		this.values[key] = h
+	}
+
+	for i, bucket := range this.buckets {
+		if value <= bucket {
+			h.buckets[i] += 1
+		}
+	}
+	h.count += 1
+	h.sum += value
+}
+
+func (this *HistogramVec) write(w io.Writer, name string) {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	keys := make([]string, 0, len(this.values))
+	for key := range this.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bucketLabels := append(append([]string{}, this.labels...), "le")
+
+	for _, key := range keys {
+		h := this.values[key]
+		for i, bucket := range this.buckets {
+			io.WriteString(w, name+"_bucket"+formatLabels(bucketLabels, append(append([]string{}, h.labels...), formatFloat(bucket)))+" "+strconv.FormatUint(h.buckets[i], 10)+"\n")
+		}
+		io.WriteString(w, name+"_bucket"+formatLabels(bucketLabels, append(append([]string{}, h.labels...), "+Inf"))+" "+strconv.FormatUint(h.count, 10)+"\n")
+		io.WriteString(w, name+"_sum"+formatLabels(this.labels, h.labels)+" "+formatFloat(h.sum)+"\n")
+		io.WriteString(w, name+"_count"+formatLabels(this.labels, h.labels)+" "+strconv.FormatUint(h.count, 10)+"\n")
+	}
+}
+
+func NewCounter(name, help string) *Counter {
+	counter := &Counter{}
+	register(name, help, "counter", counter)
+	return counter
+}
+
+func NewGauge(name, help string) *Gauge {
+	gauge := &Gauge{}
+	register(name, help, "gauge", gauge)
+	return gauge
+}
+
+// NewGaugeFunc reads the value when the metrics are requested
+func NewGaugeFunc(name, help string, callback func() float64) {
+	register(name, help, "gauge", &gaugeFunc{callback})
+}
+
+// NewGaugeVecFunc reads the values, one per label value, when the metrics are requested
+func NewGaugeVecFunc(name, help, label string, callback func() map[string]float64) {
+	register(name, help, "gauge", &gaugeVecFunc{label, callback})
+}
+
+func NewHistogramVec(name, help string, labels []string, buckets []float64) *HistogramVec {
+	histogramVec := &HistogramVec{labels, buckets, make(map[string]*histogram), sync.Mutex{}}
+	register(name, help, "histogram", histogramVec)
+	return histogramVec
+}
+
+// Write writes all the registered metrics using the Prometheus text exposition format
+func Write(w io.Writer) {
+
+	registryLock.RLock()
+	list := make([]registeredMetric, len(registryList))
+	for i, it := range registryList {
+		list[i] = *it
+	}
+	registryLock.RUnlock()
+
+	for _, it := range list {
+		io.WriteString(w, "# HELP "+it.name+" "+it.help+"\n")
+		io.WriteString(w, "# TYPE "+it.name+" "+it.metricType+"\n")
+		it.metric.write(w, it.name)
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+
+	counter := NewCounter("test_counter_total", "Test counter")
+	counter.Add(3)
+	counter.Inc()
+
+	NewGaugeVecFunc("test_gauge", "Test gauge", "url", func() map[string]float64 {
+		return map[string]float64{"ws://b": 2, `ws://"a"`: 1.5}
+	})
+
+	histogram := NewHistogramVec("test_duration_seconds", "Test histogram", []string{"method"}, []float64{0.1, 1})
+	histogram.Observe(0.05, "info")
+	histogram.Observe(0.5, "info")
+
+	b := &bytes.Buffer{}
+	Write(b)
+	out := b.String()
+
+	assert.True(t, strings.Contains(out, "# TYPE test_counter_total counter\ntest_counter_total 4\n"))
+	assert.True(t, strings.Contains(out, "test_gauge{url=\"ws://\\\"a\\\"\"} 1.5\ntest_gauge{url=\"ws://b\"} 2\n"))
+	assert.True(t, strings.Contains(out, `test_duration_seconds_bucket{method="info",le="0.1"} 1`))
+	assert.True(t, strings.Contains(out, `test_duration_seconds_bucket{method="info",le="1"} 2`))
+	assert.True(t, strings.Contains(out, `test_duration_seconds_bucket{method="info",le="+Inf"} 2`))
+	assert.True(t, strings.Contains(out, `test_duration_seconds_count{method="info"} 2`))
+}
@@ -20,6 +20,8 @@ import (
 	"time"
 )
 
+var logger = gui.NewSubsystemLogger("mempool")
+
 type mempoolTx struct {
 	Tx          *transaction.Transaction `json:"tx" msgpack:"tx"`
 	Added       int64                    `json:"added" msgpack:"added"`
@@ -39,6 +41,8 @@ type mempool struct {
 	Txs                       *mempoolTxs
 	OnBroadcastNewTransaction func([]*transaction.Transaction, bool, bool, advanced_connection_types.UUID, context.Context) []error
 	workers                   []*mempoolWorker
+	storeLoaded               *atomic.Bool
+	closed                    *atomic.Bool
 }
 
 var Mempool *mempool
@@ -126,7 +130,7 @@ func (self *mempool) processTxsToMempool(txs []*transaction.Transaction, height
 		case transaction_type.TX_SIMPLE:
 			requiredFeePerByte = config_fees.FEE_PER_BYTE
 			txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
-			if txBase.TxScript == transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT {
+			if txBase.TxScript == transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT || txBase.TxScript == transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT {
 				checkFee = false
 			}
 		case transaction_type.TX_ZETHER:
@@ -239,6 +243,14 @@ func (self *mempool) ContinueWork() {
 
 func (self *mempool) Close() {
 
+	self.closed.Store(true)
+
+	if self.storeLoaded.Load() {
+		if err := self.saveTxs(); err != nil {
+			logger.Error("Error saving mempool", "err", err)
+		}
+	}
+
 	for _, worker := range self.workers {
 		worker.closed.Store(true)
 	}
@@ -248,7 +260,7 @@ func (self *mempool) Close() {
 
 func Initialize() (err error) {
 
-	gui.GUI.Log("Mempool init...")
+	logger.Info("Mempool init...")
 
 	Mempool = &mempool{
 		&generics.Value[*mempoolResult]{},
@@ -261,6 +273,8 @@ func Initialize() (err error) {
 		createMempoolTxs(),
 		nil,
 		[]*mempoolWorker{},
+		&atomic.Bool{},
+		&atomic.Bool{},
 	}
 
 	worker := &mempoolWorker{
@@ -274,6 +288,16 @@ func Initialize() (err error) {
 		worker.processing(Mempool.newWorkCn, Mempool.SuspendProcessingCn, Mempool.ContinueProcessingCn, Mempool.addTransactionCn, Mempool.insertTransactionsCn, Mempool.removeTransactionsCn, Mempool.Txs)
 	})
 
+	if runtime.GOARCH != "wasm" {
+		recovery.SafeGo(func() {
+			if err := Mempool.restoreTxs(); err != nil {
+				logger.Error("Error restoring mempool", "err", err)
+			}
+			Mempool.storeLoaded.Store(true)
+			Mempool.savingTxs()
+		})
+	}
+
 	Mempool.initCLI()
 
 	return nil
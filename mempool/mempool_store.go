@@ -0,0 +1,159 @@
+package mempool
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+type mempoolStoredTx struct {
+	Tx          []byte `json:"tx" msgpack:"tx"`
+	Added       int64  `json:"added" msgpack:"added"`
+	Mine        bool   `json:"mine" msgpack:"mine"`
+	ChainHeight uint64 `json:"chainHeight" msgpack:"chainHeight"`
+}
+
+// saveTxs writes a snapshot of the current mempool transactions in StoreMempool
+func (self *mempool) saveTxs() error {
+
+	list := self.Txs.GetTxsList()
+
+	return store.StoreMempool.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		oldCount, _ := strconv.Atoi(string(writer.Get("txs-count")))
+
+		var marshal []byte
+		for i, tx := range list {
+			if marshal, err = msgpack.Marshal(&mempoolStoredTx{tx.Tx.Bloom.Serialized, tx.Added, tx.Mine, tx.ChainHeight}); err != nil {
+				return
+			}
+			writer.Put("tx-"+strconv.Itoa(i), marshal)
+		}
+
+		for i := len(list); i < oldCount; i++ {
+			writer.Delete("tx-" + strconv.Itoa(i))
+		}
+
+		writer.Put("txs-count", []byte(strconv.Itoa(len(list))))
+		return
+	})
+}
+
+func (self *mempool) loadTxs() (out []*mempoolStoredTx, err error) {
+
+	err = store.StoreMempool.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("txs-count")
+		if data == nil {
+			return
+		}
+
+		var count int
+		if count, err = strconv.Atoi(string(data)); err != nil {
+			return
+		}
+
+		out = make([]*mempoolStoredTx, 0, count)
+		for i := 0; i < count; i++ {
+
+			if data = reader.Get("tx-" + strconv.Itoa(i)); data == nil {
+				return errors.New("Stored mempool transaction was not found")
+			}
+
+			storedTx := &mempoolStoredTx{}
+			if err = msgpack.Unmarshal(data, storedTx); err != nil {
+				return
+			}
+			out = append(out, storedTx)
+		}
+
+		return
+	})
+
+	return
+}
+
+// restoreTxs reloads the stored transactions and sends them to the worker to be revalidated against the current chain
+func (self *mempool) restoreTxs() (err error) {
+
+	storedTxs, err := self.loadTxs()
+	if err != nil || len(storedTxs) == 0 {
+		return
+	}
+
+	var chainHeight uint64
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		chainHeight, _ = binary.Uvarint(reader.Get("chainHeight"))
+		return
+	}); err != nil {
+		return
+	}
+
+	//each stored tx is processed on its own, so an invalid entry doesn't abort restoring the remaining ones
+	restored := 0
+	for _, storedTx := range storedTxs {
+
+		tx := &transaction.Transaction{}
+		if err := tx.Deserialize(advanced_buffers.NewBufferReader(storedTx.Tx)); err != nil {
+			logger.Warn("Error deserializing stored mempool tx", "err", err)
+			continue
+		}
+
+		finalTxs, errs := self.processTxsToMempool([]*transaction.Transaction{tx}, chainHeight, context.Background())
+		if finalTxs[0] == nil {
+			if errs[0] != nil {
+				logger.Warn("Stored mempool tx was dropped", "hash", tx.Bloom.Hash, "err", errs[0])
+			}
+			continue
+		}
+
+		finalTx := finalTxs[0]
+		finalTx.Added = storedTx.Added
+		finalTx.Mine = storedTx.Mine
+		finalTx.ChainHeight = storedTx.ChainHeight
+
+		answerCn := make(chan error)
+		self.addTransactionCn <- &mempoolWorkerAddTx{finalTx, answerCn}
+		if <-answerCn == nil {
+			restored += 1
+		}
+	}
+
+	logger.Info("Mempool restored", "restored", restored, "stored", len(storedTxs))
+
+	//storing again to remove the txs that became invalid
+	atomic.AddUint32(&self.Txs.changes, 1)
+
+	return
+}
+
+func (self *mempool) savingTxs() {
+
+	last := atomic.LoadUint32(&self.Txs.changes)
+
+	for {
+
+		time.Sleep(10 * time.Second)
+
+		if self.closed.Load() {
+			return
+		}
+
+		changes := atomic.LoadUint32(&self.Txs.changes)
+		if changes != last {
+			if err := self.saveTxs(); err != nil {
+				logger.Error("Error saving mempool", "err", err)
+				continue
+			}
+			last = changes
+		}
+	}
+}
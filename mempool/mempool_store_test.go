@@ -0,0 +1,153 @@
+package mempool
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/txs_validator"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var initTestValidator sync.Once
+
+func createTestMempool(t *testing.T) *mempool {
+
+	initTestValidator.Do(func() {
+		var err error
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(nil)
+		assert.NoError(t, err)
+		assert.NoError(t, txs_validator.NewTxsValidator())
+	})
+
+	return &mempool{
+		&generics.Value[*mempoolResult]{},
+		make(chan struct{}),
+		make(chan ContinueProcessingType),
+		make(chan *mempoolWork),
+		make(chan *mempoolWorkerAddTx, 1000),
+		make(chan *mempoolWorkerRemoveTxs),
+		make(chan *mempoolWorkerInsertTxs),
+		createMempoolTxs(),
+		nil,
+		[]*mempoolWorker{},
+		&atomic.Bool{},
+		&atomic.Bool{},
+	}
+}
+
+func createTestClaimTx(t *testing.T) *transaction.Transaction {
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaimConditionalPayment{nil, helpers.RandomBytes(cryptography.HashSize), 0, helpers.RandomBytes(32)},
+		&wizard.WizardTransactionData{},
+		&wizard.WizardTransactionFee{},
+		0,
+		nil,
+	}, true, func(string) {})
+	assert.NoError(t, err)
+	return tx
+}
+
+// createTestInvalidSignatureTx returns a tx that deserializes correctly but fails the validation
+func createTestInvalidSignatureTx(t *testing.T) *transaction.Transaction {
+
+	tx := createTestLiquidityTx(t, addresses.GenerateNewPrivateKey().Key, 0, config_fees.FEE_PER_BYTE)
+	tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple).Vin.Signature[0] ^= 1
+
+	tampered := &transaction.Transaction{}
+	assert.NoError(t, tampered.Deserialize(advanced_buffers.NewBufferReader(tx.SerializeManualToBytes())))
+	return tampered
+}
+
+func TestMempool_RestoreTxs(t *testing.T) {
+
+	for _, name := range []string{"mempool", "blockchain"} {
+		db, err := store_db_memory.CreateStoreDBMemory(name)
+		assert.NoError(t, err)
+		if name == "mempool" {
+			store.StoreMempool = &store.Store{name, true, db}
+		} else {
+			store.StoreBlockchain = &store.Store{name, true, db}
+		}
+	}
+
+	valid := []*transaction.Transaction{createTestClaimTx(t), createTestClaimTx(t)}
+	invalid := createTestInvalidSignatureTx(t)
+
+	before := createTestMempool(t)
+	for _, tx := range append([]*transaction.Transaction{invalid}, valid...) {
+		assert.True(t, before.Txs.insertTx(&mempoolTx{tx, time.Now().Unix(), true, 0, 10}))
+	}
+	assert.NoError(t, before.saveTxs())
+
+	storedTxs, err := before.loadTxs()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(storedTxs))
+
+	//the invalid entry is moved first and a corrupted entry is appended, none of them should stop the restoring
+	sort.SliceStable(storedTxs, func(i, j int) bool {
+		return bytes.Equal(storedTxs[i].Tx, invalid.Bloom.Serialized)
+	})
+	storedTxs = append(storedTxs, &mempoolStoredTx{[]byte{1, 2, 3}, 0, false, 0})
+
+	assert.NoError(t, store.StoreMempool.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		var marshal []byte
+		for i, storedTx := range storedTxs {
+			if marshal, err = msgpack.Marshal(storedTx); err != nil {
+				return
+			}
+			writer.Put("tx-"+strconv.Itoa(i), marshal)
+		}
+		writer.Put("txs-count", []byte(strconv.Itoa(len(storedTxs))))
+		return
+	}))
+
+	//restarting
+	after := createTestMempool(t)
+
+	restored := make(map[string]*mempoolTx)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case work := <-after.addTransactionCn:
+				restored[work.Tx.Tx.Bloom.HashStr] = work.Tx
+				work.Result <- nil
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	assert.NoError(t, after.restoreTxs())
+	close(done)
+
+	assert.Equal(t, len(valid), len(restored))
+	for _, tx := range valid {
+		found := restored[tx.Bloom.HashStr]
+		if assert.NotNil(t, found) {
+			assert.True(t, found.Mine)
+			assert.Equal(t, uint64(10), found.ChainHeight)
+			assert.Equal(t, tx.Bloom.Serialized, found.Tx.Bloom.Serialized)
+		}
+	}
+	assert.Nil(t, restored[invalid.Bloom.HashStr])
+}
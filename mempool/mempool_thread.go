@@ -1,9 +1,13 @@
 package mempool
 
 import (
+	"bytes"
 	"errors"
 	"golang.org/x/exp/slices"
 	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/config"
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
@@ -52,6 +56,7 @@ func (self *mempoolWorker) processing(
 
 	txsList := []*mempoolTx{}
 	txsMap := make(map[string]*mempoolTx)
+	txsSize := uint64(0)
 	listIndex := 0
 
 	includedTotalSize := uint64(0)
@@ -76,12 +81,116 @@ func (self *mempoolWorker) processing(
 		delete(txsMap, tx.Tx.Bloom.HashStr)
 
 		if txWasInserted {
+			txsSize -= tx.Tx.Bloom.Size
 			txs.deleteTx(tx.Tx.Bloom.HashStr)
-			txs.deleted(tx, txWasInserted, includedInBlockchainNotification)
+			txs.deleted(tx, txWasInserted, includedInBlockchainNotification, false, nil)
 
 		}
 	}
 
+	insertTxNow := func(tx *mempoolTx) {
+		txsMap[tx.Tx.Bloom.HashStr] = tx
+		txsSize += tx.Tx.Bloom.Size
+		txs.insertTx(tx)
+		txs.inserted(tx)
+	}
+
+	//the removed txs could have been already included in the work, so the entire list needs to be processed again
+	resetIncludedTxs := func() {
+		dataStorage = nil
+		listIndex = 0
+		includedTotalSize = uint64(0)
+		includedTxs = []*mempoolTx{}
+		atomic.StoreUint64(&work.result.totalSize, includedTotalSize)
+		work.result.txs.Store(includedTxs)
+	}
+
+	evictTxNow := func(tx *mempoolTx, replacedBy *transaction.Transaction) {
+		if index := slices.Index(txsList, tx); index != -1 {
+			txsList = slices.Delete(txsList, index, index+1)
+		}
+		delete(txsMap, tx.Tx.Bloom.HashStr)
+		txsSize -= tx.Tx.Bloom.Size
+		txs.deleteTx(tx.Tx.Bloom.HashStr)
+		txs.deleted(tx, true, false, replacedBy == nil, replacedBy)
+	}
+
+	//replace-by-fee for simple txs with the same vin and nonce and eviction of the lowest fee txs when the mempool is full
+	//it only finds the txs to be removed, nothing is removed until the tx was checked
+	makeRoomForTx := func(tx *mempoolTx) (replaced *mempoolTx, evicted []*mempoolTx, err error) {
+
+		if tx.Tx.Version == transaction_type.TX_SIMPLE {
+			base := tx.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+			if base.HasVin() {
+				for _, tx2 := range txsList {
+					if tx2.Tx.Version != transaction_type.TX_SIMPLE {
+						continue
+					}
+					base2 := tx2.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+					if base2.HasVin() && base2.Nonce == base.Nonce && bytes.Equal(base2.Vin.PublicKey, base.Vin.PublicKey) {
+						if tx.FeePerByte*100 < tx2.FeePerByte*(100+config.MEMPOOL_REPLACE_BY_FEE_INCREASE) {
+							return nil, nil, errors.New("A transaction with the same nonce already exists in mempool and the fee is not high enough to replace it")
+						}
+						replaced = tx2
+						break
+					}
+				}
+			}
+		}
+
+		count := len(txsList)
+		size := txsSize
+		if replaced != nil {
+			count -= 1
+			size -= replaced.Tx.Bloom.Size
+		}
+
+		if count+1 > config.MEMPOOL_MAX_TXS || size+tx.Tx.Bloom.Size > config.MEMPOOL_MAX_SIZE {
+
+			candidates := make([]*mempoolTx, 0, len(txsList))
+			for _, tx2 := range txsList {
+				if tx2 != replaced {
+					candidates = append(candidates, tx2)
+				}
+			}
+			sortTxs(candidates)
+
+			for _, tx2 := range candidates {
+				if count+1 <= config.MEMPOOL_MAX_TXS && size+tx.Tx.Bloom.Size <= config.MEMPOOL_MAX_SIZE {
+					break
+				}
+				if tx2.FeePerByte >= tx.FeePerByte {
+					break
+				}
+				evicted = append(evicted, tx2)
+				count -= 1
+				size -= tx2.Tx.Bloom.Size
+			}
+
+			if count+1 > config.MEMPOOL_MAX_TXS || size+tx.Tx.Bloom.Size > config.MEMPOOL_MAX_SIZE {
+				return nil, nil, errors.New("Mempool is full and the transaction fee is too low")
+			}
+		}
+
+		return
+	}
+
+	//the txs that are replaced could have been already included in the work, so the tx is checked only against the chain state
+	checkTxOnChain := func(tx *mempoolTx, dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		defer func() {
+			if errReturned := recover(); errReturned != nil {
+				err = errReturned.(error)
+			}
+		}()
+
+		if dbTx.Exists("txHash:" + tx.Tx.Bloom.HashStr) {
+			return errors.New("Tx is already included in blockchain")
+		}
+
+		return tx.Tx.IncludeTransaction(work.chainHeight, data_storage.NewDataStorage(dbTx))
+	}
+
 	removeTxs := func(data *mempoolWorkerRemoveTxs) {
 
 		removedTxsMap := make(map[string]bool)
@@ -121,13 +230,15 @@ func (self *mempoolWorker) processing(
 		data.Result <- len(removedTxsMap) > 0
 	}
 
+	//the txs of the removed blocks are not checked yet, so they never evict other txs and they are dropped once the mempool is full
 	insertTxs := func(data *mempoolWorkerInsertTxs) {
 		result := false
 		for _, tx := range data.Txs {
 			if tx != nil && txsMap[tx.Tx.Bloom.HashStr] == nil {
-				txsMap[tx.Tx.Bloom.HashStr] = tx
-				txs.insertTx(tx)
-				txs.inserted(tx)
+				if len(txsList)+1 > config.MEMPOOL_MAX_TXS || txsSize+tx.Tx.Bloom.Size > config.MEMPOOL_MAX_SIZE {
+					continue
+				}
+				insertTxNow(tx)
 				txsList = append(txsList, tx)
 				result = true
 			}
@@ -228,6 +339,34 @@ func (self *mempoolWorker) processing(
 					continue
 				}
 
+				if newAddTx != nil {
+					replaced, evicted, err := makeRoomForTx(tx)
+					if err == nil && (replaced != nil || len(evicted) > 0) {
+						err = checkTxOnChain(tx, dbTx)
+					}
+					if err != nil {
+						if newAddTx.Result != nil {
+							newAddTx.Result <- err
+						}
+						continue
+					}
+					if replaced != nil || len(evicted) > 0 {
+						if replaced != nil {
+							evictTxNow(replaced, tx.Tx)
+						}
+						for _, tx2 := range evicted {
+							evictTxNow(tx2, nil)
+						}
+						insertTxNow(tx)
+						txsList = append(txsList, tx)
+						resetIncludedTxs()
+						if newAddTx.Result != nil {
+							newAddTx.Result <- nil
+						}
+						continue
+					}
+				}
+
 				var finalErr error
 				var exists bool
 
@@ -269,9 +408,7 @@ func (self *mempoolWorker) processing(
 							if newAddTx != nil {
 								listIndex += 1
 								txsList = append(txsList, newAddTx.Tx)
-								txsMap[tx.Tx.Bloom.HashStr] = newAddTx.Tx
-								txs.insertTx(tx)
-								txs.inserted(tx)
+								insertTxNow(tx)
 							}
 
 						}
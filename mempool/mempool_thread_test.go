@@ -0,0 +1,122 @@
+package mempool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"sync/atomic"
+	"testing"
+)
+
+func createTestLiquidityTx(t *testing.T, key []byte, nonce, feePerByte uint64) *transaction.Transaction {
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, nil, false, nil},
+		&wizard.WizardTransactionData{},
+		&wizard.WizardTransactionFee{0, feePerByte, config_fees.FEE_PER_BYTE_EXTRA_SPACE, false},
+		nonce,
+		key,
+	}, true, func(string) {})
+	assert.NoError(t, err)
+	return tx
+}
+
+// startTestMempool creates plain accounts having enough unclaimed funds and starts the worker
+func startTestMempool(t *testing.T, accounts int) (*mempool, [][]byte) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+
+	keys := make([][]byte, accounts)
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		for i := range keys {
+			privateKey := addresses.GenerateNewPrivateKey()
+			keys[i] = privateKey.Key
+
+			plainAcc, err := dataStorage.CreatePlainAccount(privateKey.GeneratePublicKey(), false)
+			if err != nil {
+				return err
+			}
+			if err = plainAcc.AddUnclaimed(true, config_coins.ConvertToUnitsUint64Forced(1000)); err != nil {
+				return err
+			}
+			if err = dataStorage.PlainAccs.Update(string(plainAcc.Key), plainAcc); err != nil {
+				return err
+			}
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	mempool := createTestMempool(t)
+
+	worker := &mempoolWorker{nil, &atomic.Bool{}}
+	go worker.processing(mempool.newWorkCn, mempool.SuspendProcessingCn, mempool.ContinueProcessingCn, mempool.addTransactionCn, mempool.insertTransactionsCn, mempool.removeTransactionsCn, mempool.Txs)
+
+	mempool.UpdateWork(helpers.RandomBytes(cryptography.HashSize), 10)
+
+	return mempool, keys
+}
+
+func addTestTx(mempool *mempool, tx *transaction.Transaction) error {
+	return mempool.AddTxToMempool(tx, 10, false, true, false, advanced_connection_types.UUID_SKIP_ALL, context.Background())
+}
+
+func TestMempool_MakeRoomForTx(t *testing.T) {
+
+	oldMaxTxs := config.MEMPOOL_MAX_TXS
+	config.MEMPOOL_MAX_TXS = 2
+	defer func() {
+		config.MEMPOOL_MAX_TXS = oldMaxTxs
+	}()
+
+	mempool, keys := startTestMempool(t, 3)
+
+	txA := createTestLiquidityTx(t, keys[0], 0, 20)
+	txB := createTestLiquidityTx(t, keys[1], 0, 30)
+	assert.NoError(t, addTestTx(mempool, txA))
+	assert.NoError(t, addTestTx(mempool, txB))
+	assert.Equal(t, int32(2), mempool.Txs.GetCount())
+
+	//rejected, the fee is not higher than the lowest fee in the mempool
+	assert.EqualError(t, addTestTx(mempool, createTestLiquidityTx(t, keys[2], 0, 20)), "Mempool is full and the transaction fee is too low")
+
+	//rejected, a tx paying more but failing on chain must not evict anything
+	assert.Error(t, addTestTx(mempool, createTestLiquidityTx(t, addresses.GenerateNewPrivateKey().Key, 0, 100)))
+	assert.Error(t, addTestTx(mempool, createTestLiquidityTx(t, keys[2], 5, 100)))
+	assert.True(t, mempool.Txs.Exists(txA.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(txB.Bloom.HashStr))
+
+	//eviction of the lowest fee tx
+	txC := createTestLiquidityTx(t, keys[2], 0, 25)
+	assert.NoError(t, addTestTx(mempool, txC))
+	assert.False(t, mempool.Txs.Exists(txA.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(txC.Bloom.HashStr))
+	assert.Equal(t, int32(2), mempool.Txs.GetCount())
+
+	//replacement of a tx with the same nonce requires a higher fee
+	assert.Error(t, addTestTx(mempool, createTestLiquidityTx(t, keys[1], 0, 31)))
+	txB2 := createTestLiquidityTx(t, keys[1], 0, 40)
+	assert.NoError(t, addTestTx(mempool, txB2))
+	assert.False(t, mempool.Txs.Exists(txB.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(txB2.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(txC.Bloom.HashStr))
+	assert.Equal(t, int32(2), mempool.Txs.GetCount())
+
+	//the txs of removed blocks are dropped when the mempool is full
+	assert.False(t, mempool.InsertRemovedTxsFromBlockchain([]*transaction.Transaction{txA}, 10))
+	assert.False(t, mempool.Txs.Exists(txA.Bloom.HashStr))
+	assert.Equal(t, int32(2), mempool.Txs.GetCount())
+}
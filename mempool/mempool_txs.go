@@ -8,6 +8,7 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/metrics"
 	"pandora-pay/helpers/multicast"
 	"pandora-pay/helpers/recovery"
 	"strconv"
@@ -23,7 +24,9 @@ type mempoolAccountTxs struct {
 }
 
 type mempoolTxs struct {
+	size                      uint64 //total size in bytes, use atomic
 	count                     int32
+	changes                   uint32
 	txsMap                    *generics.Map[string, *mempoolTx]
 	accountsMapTxs            *generics.Map[string, *mempoolAccountTxs]
 	UpdateMempoolTransactions *multicast.MulticastChannel[*blockchain_types.MempoolTransactionUpdate]
@@ -32,7 +35,9 @@ type mempoolTxs struct {
 func (self *mempoolTxs) insertTx(tx *mempoolTx) bool {
 	_, loaded := self.txsMap.LoadOrStore(tx.Tx.Bloom.HashStr, tx)
 	if !loaded {
+		atomic.AddUint64(&self.size, tx.Tx.Bloom.Size)
 		atomic.AddInt32(&self.count, 1)
+		atomic.AddUint32(&self.changes, 1)
 	}
 	return !loaded
 }
@@ -65,20 +70,24 @@ func (self *mempoolTxs) inserted(tx *mempoolTx) {
 			tx.Tx,
 			false,
 			keys,
+			false,
+			nil,
 		})
 
 	}
 }
 
 func (self *mempoolTxs) deleteTx(hashStr string) bool {
-	_, deleted := self.txsMap.LoadAndDelete(hashStr)
+	tx, deleted := self.txsMap.LoadAndDelete(hashStr)
 	if deleted {
+		atomic.AddUint64(&self.size, ^(tx.Tx.Bloom.Size - 1))
 		atomic.AddInt32(&self.count, -1)
+		atomic.AddUint32(&self.changes, 1)
 	}
 	return deleted
 }
 
-func (self *mempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedInBlockchainNotification bool) {
+func (self *mempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedInBlockchainNotification, evicted bool, replacedBy *transaction.Transaction) {
 	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
 
 		keys := tx.Tx.GetAllKeys()
@@ -101,6 +110,8 @@ func (self *mempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedI
 				tx.Tx,
 				includedInBlockchainNotification,
 				keys,
+				evicted,
+				replacedBy,
 			})
 		}
 
@@ -142,6 +153,14 @@ func (self *mempoolTxs) GetTxsOnlyList() []*transaction.Transaction {
 	return out
 }
 
+func (self *mempoolTxs) GetCount() int32 {
+	return atomic.LoadInt32(&self.count)
+}
+
+func (self *mempoolTxs) GetSize() uint64 {
+	return atomic.LoadUint64(&self.size)
+}
+
 func (self *mempoolTxs) Exists(txId string) bool {
 	_, loaded := self.txsMap.Load(txId)
 	return loaded
@@ -182,12 +201,21 @@ func (self *mempoolTxs) GetAccountTxs(publicKey []byte) []*mempoolTx {
 func createMempoolTxs() (txs *mempoolTxs) {
 
 	txs = &mempoolTxs{
+		0,
+		0,
 		0,
 		&generics.Map[string, *mempoolTx]{},
 		&generics.Map[string, *mempoolAccountTxs]{},
 		multicast.NewMulticastChannel[*blockchain_types.MempoolTransactionUpdate](),
 	}
 
+	metrics.NewGaugeFunc("pandora_mempool_txs", "Number of transactions in the mempool", func() float64 {
+		return float64(txs.GetCount())
+	})
+	metrics.NewGaugeFunc("pandora_mempool_bytes", "Size in bytes of the transactions in the mempool", func() float64 {
+		return float64(txs.GetSize())
+	})
+
 	//printing from time to time the mempool
 	if config.DEBUG {
 		recovery.SafeGo(func() {
@@ -2,30 +2,22 @@ package api_code_types
 
 import (
 	"net/url"
+	"pandora-pay/network/api_keys"
 	"pandora-pay/network/network_config/network_config_auth"
 )
 
-func CheckAuthenticated(args url.Values) bool {
-
-	user := network_config_auth.CONFIG_AUTH_USERS_MAP[args.Get("user")]
-	if user == nil {
-		return false
-	}
-
-	return user.Password == args.Get("pass")
+// GetScopes returns the scopes granted by the "apiKey" or by the "user" and "pass" parameters
+func GetScopes(args url.Values) api_keys.Scope {
+	return network_config_auth.GetScopes(args.Get("user"), args.Get("pass"), args.Get("apiKey"))
 }
 
 type APIAuthenticated[T any] struct {
-	User string `json:"user" msgpack:"user"`
-	Pass string `json:"pass" msgpack:"pass"`
-	Data *T     `json:"req" msgpack:"req"`
+	User   string `json:"user" msgpack:"user"`
+	Pass   string `json:"pass" msgpack:"pass"`
+	ApiKey string `json:"apiKey" msgpack:"apiKey"`
+	Data   *T     `json:"req" msgpack:"req"`
 }
 
-func (authenticated *APIAuthenticated[T]) CheckAuthenticated() bool {
-	user := network_config_auth.CONFIG_AUTH_USERS_MAP[authenticated.User]
-	if user == nil {
-		return false
-	}
-
-	return user.Password == authenticated.Pass
+func (authenticated *APIAuthenticated[T]) GetScopes() api_keys.Scope {
+	return network_config_auth.GetScopes(authenticated.User, authenticated.Pass, authenticated.ApiKey)
 }
@@ -0,0 +1,8 @@
+package api_code_types
+
+import (
+	"pandora-pay/helpers/metrics"
+)
+
+// APIRequestsDurationMetric measures the latency of every API method. Transport is http, websocket or rpc
+var APIRequestsDurationMetric = metrics.NewHistogramVec("pandora_api_request_duration_seconds", "Latency of the API methods", []string{"method", "transport"}, metrics.DurationBuckets)
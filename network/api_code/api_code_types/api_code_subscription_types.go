@@ -13,6 +13,9 @@ const (
 	SUBSCRIPTION_ASSET
 	SUBSCRIPTION_REGISTRATION
 	SUBSCRIPTION_TRANSACTION
+	SUBSCRIPTION_BLOCKS              //new blocks and chain reorganizations, empty key
+	SUBSCRIPTION_MEMPOOL             //transactions inserted and removed from the mempool, empty key
+	SUBSCRIPTION_CONDITIONAL_PAYMENT //key is TxId followed by the PayloadIndex byte
 )
 
 type APISubscriptionNotification struct {
@@ -2,6 +2,7 @@ package api_code_websockets
 
 import (
 	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/api_keys"
 	"pandora-pay/network/network_config/network_config_auth"
 	"pandora-pay/network/websocks/connection"
 )
@@ -9,10 +10,12 @@ import (
 type APILogin struct {
 	Username string `json:"user" msgpack:"user"`
 	Password string `json:"pass" msgpack:"pass"`
+	ApiKey   string `json:"apiKey" msgpack:"apiKey"`
 }
 
 type APILoginReply struct {
-	Status bool `json:"status" msgpack:"status"`
+	Status bool     `json:"status" msgpack:"status"`
+	Scopes []string `json:"scopes" msgpack:"scopes"`
 }
 
 func Login(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
@@ -22,13 +25,18 @@ func Login(conn *connection.AdvancedConnection, values []byte) (interface{}, err
 	}
 	reply := &APILoginReply{}
 
-	user := network_config_auth.CONFIG_AUTH_USERS_MAP[args.Username]
-	if user == nil || user.Password != args.Password {
+	scopes := network_config_auth.GetScopes(args.Username, args.Password, args.ApiKey)
+	if scopes == 0 {
+		//a failed login drops the previous login, so the connection doesn't keep the old scopes
+		conn.AuthenticatedScopes.Store(0)
+		conn.ApiKeyId.Store("")
 		return reply, nil
 	}
 
-	conn.Authenticated.Set()
+	conn.AuthenticatedScopes.Store(uint32(scopes))
+	conn.ApiKeyId.Store(api_keys.ApiKeys.GetId(args.ApiKey))
 	reply.Status = true
+	reply.Scopes = scopes.Strings()
 
 	return reply, nil
 }
@@ -0,0 +1,31 @@
+package api_code_websockets
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/api_keys"
+	"pandora-pay/network/websocks/connection"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoginFailedClearsScopes(t *testing.T) {
+
+	conn := &connection.AdvancedConnection{
+		AuthenticatedScopes: &atomic.Uint32{},
+		ApiKeyId:            &generics.Value[string]{},
+	}
+	conn.AuthenticatedScopes.Store(uint32(api_keys.SCOPE_ADMIN))
+	conn.ApiKeyId.Store("0b1c2d3e4f5a6b7c")
+
+	values, err := msgpack.Marshal(&APILogin{"user", "wrong", ""})
+	assert.Nil(t, err)
+
+	out, err := Login(conn, values)
+	assert.Nil(t, err)
+	assert.False(t, out.(*APILoginReply).Status)
+
+	assert.Equal(t, uint32(0), conn.AuthenticatedScopes.Load())
+	assert.Equal(t, "", conn.ApiKeyId.Load())
+}
@@ -12,11 +12,12 @@ func Logout(conn *connection.AdvancedConnection, values []byte) (interface{}, er
 
 	reply := &APILogoutReply{}
 
-	if conn.Authenticated.IsNotSet() {
+	conn.ApiKeyId.Store("")
+
+	if conn.AuthenticatedScopes.Swap(0) == 0 {
 		return reply, nil
 	}
 
-	conn.Authenticated.UnSet()
 	reply.Status = true
 
 	return reply, nil
@@ -45,6 +45,7 @@ func (api *APICommon) readLocalBlockchain(newChainDataUpdate *blockchain.Blockch
 		newChainDataUpdate.Update.Target.String(),
 		newChainDataUpdate.Update.Supply,
 		newChainDataUpdate.Update.BigTotalDifficulty.String(),
+		base64.StdEncoding.EncodeToString(newChainDataUpdate.Update.StateRoot),
 	}
 	api.localChain.Store(newLocalChain)
 }
@@ -65,7 +66,9 @@ func NewAPICommon(apiStore *APIStore) (api *APICommon, err error) {
 
 	var delegatorNode *api_delegator_node.DelegatorNode
 	if config_nodes.DELEGATOR_ENABLED {
-		delegatorNode = api_delegator_node.NewDelegatorNode()
+		if delegatorNode, err = api_delegator_node.NewDelegatorNode(); err != nil {
+			return
+		}
 	}
 
 	api = &APICommon{
@@ -0,0 +1,31 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/helpers"
+)
+
+type ApiDelegatorNodeDelegatorRequest struct {
+	SharedStakedPublicKey helpers.Base64 `json:"sharedStakedPublicKey" msgpack:"sharedStakedPublicKey"`
+}
+
+type ApiDelegatorNodeDelegatorReply struct {
+	Delegator *DelegatorPoolEntry `json:"delegator" msgpack:"delegator"`
+	Fee       uint64              `json:"fee" msgpack:"fee"` //percentage
+}
+
+func (api *DelegatorNode) GetDelegator(r *http.Request, args *ApiDelegatorNodeDelegatorRequest, reply *ApiDelegatorNodeDelegatorReply, authenticated bool) error {
+
+	if config_nodes.DELEGATOR_REQUIRE_AUTH && !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if reply.Delegator = api.pool.getEntry(args.SharedStakedPublicKey); reply.Delegator == nil {
+		return errors.New("Delegator was not found")
+	}
+	reply.Fee = config_nodes.DELEGATOR_FEE
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"net/http"
+)
+
+type ApiDelegatorNodeDelegatorsReply struct {
+	Delegators   []*DelegatorPoolEntry `json:"delegators" msgpack:"delegators"`
+	TotalStake   uint64                `json:"totalStake" msgpack:"totalStake"`
+	Blocks       uint64                `json:"blocks" msgpack:"blocks"`
+	Rewards      uint64                `json:"rewards" msgpack:"rewards"`
+	OperatorFees uint64                `json:"operatorFees" msgpack:"operatorFees"`
+}
+
+func (api *DelegatorNode) GetDelegators(r *http.Request, args *struct{}, reply *ApiDelegatorNodeDelegatorsReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Delegators = api.pool.getEntries()
+	reply.TotalStake, reply.Blocks, reply.Rewards, reply.OperatorFees = api.pool.getTotals()
+
+	return nil
+}
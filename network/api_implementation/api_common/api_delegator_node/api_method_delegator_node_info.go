@@ -11,11 +11,17 @@ type ApiDelegatorNodeInfoReply struct {
 	MaximumAllowed int    `json:"maximumAllowed" msgpack:"maximumAllowed"`
 	DelegatesCount int    `json:"delegatesCount" msgpack:"delegatesCount"`
 	Blocks         uint64 `json:"blocks" msgpack:"blocks"`
+	Fee            uint64 `json:"fee" msgpack:"fee"` //percentage
+	TotalStake     uint64 `json:"totalStake" msgpack:"totalStake"`
+	ForgedBlocks   uint64 `json:"forgedBlocks" msgpack:"forgedBlocks"`
+	Rewards        uint64 `json:"rewards" msgpack:"rewards"`
 }
 
 func (api *DelegatorNode) GetDelegatorNodeInfo(r *http.Request, args *struct{}, reply *ApiDelegatorNodeInfoReply) error {
 	reply.MaximumAllowed = config_nodes.DELEGATES_MAXIMUM
 	reply.DelegatesCount = wallet.Wallet.GetDelegatesCount()
 	reply.Blocks = atomic.LoadUint64(&api.chainHeight)
+	reply.Fee = config_nodes.DELEGATOR_FEE
+	reply.TotalStake, reply.ForgedBlocks, reply.Rewards, _ = api.pool.getTotals()
 	return nil
 }
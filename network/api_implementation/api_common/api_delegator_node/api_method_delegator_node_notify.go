@@ -121,10 +121,15 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 		},
 		"",
 		"",
+		false,
 	}, true, true, acc, reg, chainHeight); err != nil {
 		return
 	}
 
+	if err = api.pool.setStake(sharedStakedPublicKey, args.SharedStakedBalance); err != nil {
+		return
+	}
+
 	reply.Result = true
 
 	return nil
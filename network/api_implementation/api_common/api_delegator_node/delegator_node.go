@@ -2,12 +2,19 @@ package api_delegator_node
 
 type DelegatorNode struct {
 	chainHeight uint64 //use atomic
+	pool        *delegatorPool
 }
 
-func NewDelegatorNode() (delegator *DelegatorNode) {
+func NewDelegatorNode() (delegator *DelegatorNode, err error) {
+
+	pool, err := loadDelegatorPool()
+	if err != nil {
+		return
+	}
 
 	delegator = &DelegatorNode{
 		0,
+		pool,
 	}
 
 	return
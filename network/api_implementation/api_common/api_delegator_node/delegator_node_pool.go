@@ -0,0 +1,238 @@
+package api_delegator_node
+
+import (
+	"bytes"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sync"
+	"time"
+)
+
+type DelegatorPoolEntry struct {
+	PublicKey       helpers.Base64 `json:"publicKey" msgpack:"publicKey"`
+	Stake           uint64         `json:"stake" msgpack:"stake"`
+	Blocks          uint64         `json:"blocks" msgpack:"blocks"`
+	Rewards         uint64         `json:"rewards" msgpack:"rewards"`           //rewards earned by the delegator (operator fee excluded)
+	OperatorFees    uint64         `json:"operatorFees" msgpack:"operatorFees"` //fees kept by the operator from the delegator's blocks
+	LastBlockHeight uint64         `json:"lastBlockHeight" msgpack:"lastBlockHeight"`
+	PendingBlocks   uint64         `json:"pendingBlocks" msgpack:"pendingBlocks"` //forged blocks waiting for confirmations
+	Joined          int64          `json:"joined" msgpack:"joined"`
+}
+
+type delegatorPoolPendingBlock struct {
+	PublicKey []byte `json:"publicKey" msgpack:"publicKey"`
+	Height    uint64 `json:"height" msgpack:"height"`
+	Hash      []byte `json:"hash" msgpack:"hash"`
+	Reward    uint64 `json:"reward" msgpack:"reward"`
+}
+
+type delegatorPool struct {
+	Entries      map[string]*DelegatorPoolEntry `json:"entries" msgpack:"entries"`
+	Pending      []*delegatorPoolPendingBlock   `json:"pending" msgpack:"pending"` //forged blocks which are not credited yet
+	Blocks       uint64                         `json:"blocks" msgpack:"blocks"`
+	Rewards      uint64                         `json:"rewards" msgpack:"rewards"`
+	OperatorFees uint64                         `json:"operatorFees" msgpack:"operatorFees"`
+	lock         sync.RWMutex
+}
+
+func (pool *delegatorPool) setStake(publicKey []byte, stake uint64) error {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	entry := pool.Entries[string(publicKey)]
+	if entry == nil {
+		entry = &DelegatorPoolEntry{
+			PublicKey: publicKey,
+			Joined:    time.Now().Unix(),
+		}
+		pool.Entries[string(publicKey)] = entry
+	}
+	entry.Stake = stake
+
+	return pool.save()
+}
+
+// addForgedBlock keeps the forged block as pending until it gets enough confirmations, as it can still be orphaned
+func (pool *delegatorPool) addForgedBlock(forgedBlock *forging.ForgedBlock) error {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	entry := pool.Entries[string(forgedBlock.PublicKey)]
+	if entry == nil {
+		return nil
+	}
+
+	entry.PendingBlocks += 1
+	pool.Pending = append(pool.Pending, &delegatorPoolPendingBlock{forgedBlock.PublicKey, forgedBlock.Height, forgedBlock.Hash, forgedBlock.Reward})
+
+	return pool.save()
+}
+
+// confirmForgedBlocks splits the reward of the confirmed pending blocks between the delegator and the operator.
+// The pending blocks which are no longer in the chain are dropped
+func (pool *delegatorPool) confirmForgedBlocks(chainHeight uint64, getBlockHash func(height uint64) ([]byte, error)) error {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	pending := make([]*delegatorPoolPendingBlock, 0, len(pool.Pending))
+	changed := false
+
+	for _, forgedBlock := range pool.Pending {
+
+		if forgedBlock.Height+config_nodes.DELEGATOR_REWARDS_CONFIRMATIONS > chainHeight {
+			pending = append(pending, forgedBlock)
+			continue
+		}
+
+		changed = true
+
+		entry := pool.Entries[string(forgedBlock.PublicKey)]
+		if entry == nil {
+			continue
+		}
+		entry.PendingBlocks -= 1
+
+		if hash, err := getBlockHash(forgedBlock.Height); err != nil || !bytes.Equal(hash, forgedBlock.Hash) {
+			continue
+		}
+
+		operatorFee := forgedBlock.Reward / 100 * config_nodes.DELEGATOR_FEE
+		operatorFee += forgedBlock.Reward % 100 * config_nodes.DELEGATOR_FEE / 100
+		reward := forgedBlock.Reward - operatorFee
+
+		entry.Blocks += 1
+		entry.Rewards += reward
+		entry.OperatorFees += operatorFee
+		entry.LastBlockHeight = forgedBlock.Height
+
+		pool.Blocks += 1
+		pool.Rewards += reward
+		pool.OperatorFees += operatorFee
+	}
+
+	if !changed {
+		return nil
+	}
+
+	pool.Pending = pending
+	return pool.save()
+}
+
+func (pool *delegatorPool) getEntry(publicKey []byte) *DelegatorPoolEntry {
+	pool.lock.RLock()
+	defer pool.lock.RUnlock()
+
+	if entry := pool.Entries[string(publicKey)]; entry != nil {
+		out := *entry
+		return &out
+	}
+	return nil
+}
+
+func (pool *delegatorPool) getEntries() []*DelegatorPoolEntry {
+	pool.lock.RLock()
+	defer pool.lock.RUnlock()
+
+	list := make([]*DelegatorPoolEntry, 0, len(pool.Entries))
+	for _, entry := range pool.Entries {
+		out := *entry
+		list = append(list, &out)
+	}
+	return list
+}
+
+func (pool *delegatorPool) getTotals() (stake, blocks, rewards, operatorFees uint64) {
+	pool.lock.RLock()
+	defer pool.lock.RUnlock()
+
+	for _, entry := range pool.Entries {
+		stake += entry.Stake
+	}
+	return stake, pool.Blocks, pool.Rewards, pool.OperatorFees
+}
+
+func (pool *delegatorPool) save() error {
+
+	marshal, err := msgpack.Marshal(pool)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("delegatorPool", marshal)
+		return nil
+	})
+}
+
+func (pool *delegatorPool) processForgedBlocks() {
+
+	forgedBlocksCn := forging.Forging.ForgedBlocks.AddListener()
+	defer forging.Forging.ForgedBlocks.RemoveChannel(forgedBlocksCn)
+
+	updateNewChainCn := blockchain.Blockchain.UpdateNewChain.AddListener()
+	defer blockchain.Blockchain.UpdateNewChain.RemoveChannel(updateNewChainCn)
+
+	for {
+		select {
+		case forgedBlock, ok := <-forgedBlocksCn:
+			if !ok {
+				return
+			}
+			if err := pool.addForgedBlock(forgedBlock); err != nil {
+				gui.GUI.Error("Error saving delegator pool", err)
+			}
+		case chainHeight, ok := <-updateNewChainCn:
+			if !ok {
+				return
+			}
+			if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+				return pool.confirmForgedBlocks(chainHeight, func(height uint64) ([]byte, error) {
+					return blockchain.Blockchain.LoadBlockHash(reader, height)
+				})
+			}); err != nil {
+				gui.GUI.Error("Error saving delegator pool", err)
+			}
+		}
+	}
+}
+
+func loadStoredDelegatorPool() (pool *delegatorPool, err error) {
+
+	pool = &delegatorPool{
+		Entries: make(map[string]*DelegatorPoolEntry),
+	}
+
+	if err = store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("delegatorPool")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, pool)
+	}); err != nil {
+		return
+	}
+
+	if pool.Entries == nil {
+		pool.Entries = make(map[string]*DelegatorPoolEntry)
+	}
+
+	return
+}
+
+func loadDelegatorPool() (pool *delegatorPool, err error) {
+
+	if pool, err = loadStoredDelegatorPool(); err != nil {
+		return
+	}
+
+	recovery.SafeGo(pool.processForgedBlocks)
+
+	return
+}
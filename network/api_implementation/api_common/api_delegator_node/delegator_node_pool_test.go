@@ -0,0 +1,92 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestDelegatorPool_ConfirmForgedBlocks(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.NoError(t, err)
+	store.StoreSettings = &store.Store{"settings", true, db}
+
+	oldFee := config_nodes.DELEGATOR_FEE
+	config_nodes.DELEGATOR_FEE = 10
+	defer func() {
+		config_nodes.DELEGATOR_FEE = oldFee
+	}()
+
+	pool := &delegatorPool{Entries: make(map[string]*DelegatorPoolEntry)}
+
+	publicKey := helpers.RandomBytes(cryptography.PublicKeySize)
+	assert.NoError(t, pool.setStake(publicKey, 1000))
+
+	chain := map[uint64][]byte{}
+	getBlockHash := func(height uint64) ([]byte, error) {
+		if hash := chain[height]; hash != nil {
+			return hash, nil
+		}
+		return nil, errors.New("Block Hash not found")
+	}
+
+	forge := func(height uint64) {
+		chain[height] = helpers.RandomBytes(cryptography.HashSize)
+		assert.NoError(t, pool.addForgedBlock(&forging.ForgedBlock{publicKey, height, chain[height], 1000}))
+	}
+
+	forge(100)
+	forge(101)
+	forge(102)
+
+	//a block forged by somebody else is ignored
+	assert.NoError(t, pool.addForgedBlock(&forging.ForgedBlock{helpers.RandomBytes(cryptography.PublicKeySize), 103, helpers.RandomBytes(cryptography.HashSize), 1000}))
+
+	assert.Equal(t, uint64(3), pool.getEntry(publicKey).PendingBlocks)
+
+	//nothing is credited before the confirmations
+	assert.NoError(t, pool.confirmForgedBlocks(100+config_nodes.DELEGATOR_REWARDS_CONFIRMATIONS-1, getBlockHash))
+	entry := pool.getEntry(publicKey)
+	assert.Equal(t, uint64(0), entry.Blocks)
+	assert.Equal(t, uint64(0), entry.Rewards)
+
+	//block 101 was orphaned by a reorg
+	chain[101] = helpers.RandomBytes(cryptography.HashSize)
+
+	assert.NoError(t, pool.confirmForgedBlocks(101+config_nodes.DELEGATOR_REWARDS_CONFIRMATIONS, getBlockHash))
+	entry = pool.getEntry(publicKey)
+	assert.Equal(t, uint64(1), entry.Blocks)
+	assert.Equal(t, uint64(900), entry.Rewards)
+	assert.Equal(t, uint64(100), entry.OperatorFees)
+	assert.Equal(t, uint64(100), entry.LastBlockHeight)
+	assert.Equal(t, uint64(1), entry.PendingBlocks)
+
+	//block 102 was removed by a reorg to a shorter chain
+	delete(chain, 102)
+
+	assert.NoError(t, pool.confirmForgedBlocks(200, getBlockHash))
+	entry = pool.getEntry(publicKey)
+	assert.Equal(t, uint64(1), entry.Blocks)
+	assert.Equal(t, uint64(0), entry.PendingBlocks)
+	assert.Equal(t, 0, len(pool.Pending))
+
+	_, blocks, rewards, operatorFees := pool.getTotals()
+	assert.Equal(t, uint64(1), blocks)
+	assert.Equal(t, uint64(900), rewards)
+	assert.Equal(t, uint64(100), operatorFees)
+
+	//the pending blocks are stored
+	forge(300)
+	loaded, err := loadStoredDelegatorPool()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(loaded.Pending))
+	assert.Equal(t, uint64(1), loaded.Entries[string(publicKey)].PendingBlocks)
+	assert.Equal(t, uint64(900), loaded.Entries[string(publicKey)].Rewards)
+}
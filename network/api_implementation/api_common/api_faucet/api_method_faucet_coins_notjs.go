@@ -45,7 +45,7 @@ func (api *Faucet) GetFaucetCoins(r *http.Request, args *APIFaucetCoinsRequest,
 			0,
 			&txs_builder.ZetherRingConfiguration{&txs_builder.ZetherSenderRingType{}, &txs_builder.ZetherRecipientRingType{}},
 			0,
-			&wizard.WizardTransactionData{[]byte("Testnet Faucet Tx"), true},
+			&wizard.WizardTransactionData{[]byte("Testnet Faucet Tx"), true, nil, nil},
 			&wizard.WizardZetherTransactionFee{&wizard.WizardTransactionFee{0, 0, 0, true}, false, 0, 0},
 			nil,
 		}},
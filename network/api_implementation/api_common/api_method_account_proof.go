@@ -0,0 +1,61 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_implementation/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIAccountProofRequest struct {
+	api_types.APIAccountBaseRequest
+	Asset helpers.Base64 `json:"asset,omitempty" msgpack:"asset,omitempty"`
+}
+
+type APIAccountProofReply struct {
+	Block        *api_types.APIStateProofBlock   `json:"block" msgpack:"block"`
+	Account      *api_types.APIStateElementProof `json:"account" msgpack:"account"`
+	PlainAccount *api_types.APIStateElementProof `json:"plainAccount" msgpack:"plainAccount"`
+	Registration *api_types.APIStateElementProof `json:"registration" msgpack:"registration"`
+}
+
+func getProofAsset(asset []byte) ([]byte, error) {
+	if len(asset) == 0 {
+		return config_coins.NATIVE_ASSET_FULL, nil
+	}
+	if len(asset) != config_coins.ASSET_LENGTH {
+		return nil, errors.New("Asset is invalid")
+	}
+	return asset, nil
+}
+
+func (api *APICommon) GetAccountProof(r *http.Request, args *APIAccountProofRequest, reply *APIAccountProofReply) (err error) {
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	asset, err := getProofAsset(args.Asset)
+	if err != nil {
+		return
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if reply.Block, err = api.loadStateProofBlock(reader); err != nil {
+			return
+		}
+		if reply.Account, err = api.getStateElementProof(reader, "accounts_"+string(asset), publicKey); err != nil {
+			return
+		}
+		if reply.PlainAccount, err = api.getStateElementProof(reader, "plainAccs", publicKey); err != nil {
+			return
+		}
+		reply.Registration, err = api.getStateElementProof(reader, "registrations", publicKey)
+		return
+	})
+}
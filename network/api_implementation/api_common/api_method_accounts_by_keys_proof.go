@@ -0,0 +1,61 @@
+package api_common
+
+import (
+	"fmt"
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_implementation/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIAccountsByKeysProofRequest struct {
+	Keys  []*api_types.APIAccountBaseRequest `json:"keys,omitempty" msgpack:"keys,omitempty"`
+	Asset helpers.Base64                     `json:"asset,omitempty" msgpack:"asset,omitempty"`
+}
+
+type APIAccountsByKeysProofReply struct {
+	Block         *api_types.APIStateProofBlock     `json:"block" msgpack:"block"`
+	Accounts      []*api_types.APIStateElementProof `json:"accounts" msgpack:"accounts"`
+	Registrations []*api_types.APIStateElementProof `json:"registrations" msgpack:"registrations"`
+}
+
+func (api *APICommon) GetAccountsByKeysProof(r *http.Request, args *APIAccountsByKeysProofRequest, reply *APIAccountsByKeysProofReply) (err error) {
+
+	if len(args.Keys) > 512*2 {
+		return fmt.Errorf("Too many indexes to process: limit %d, found %d", 512*2, len(args.Keys))
+	}
+
+	publicKeys := make([][]byte, len(args.Keys))
+	for i, key := range args.Keys {
+		if publicKeys[i], err = key.GetPublicKey(true); err != nil {
+			return
+		}
+	}
+
+	asset, err := getProofAsset(args.Asset)
+	if err != nil {
+		return
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if reply.Block, err = api.loadStateProofBlock(reader); err != nil {
+			return
+		}
+
+		reply.Accounts = make([]*api_types.APIStateElementProof, len(publicKeys))
+		reply.Registrations = make([]*api_types.APIStateElementProof, len(publicKeys))
+
+		for i, publicKey := range publicKeys {
+			if reply.Accounts[i], err = api.getStateElementProof(reader, "accounts_"+string(asset), publicKey); err != nil {
+				return
+			}
+			if reply.Registrations[i], err = api.getStateElementProof(reader, "registrations", publicKey); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+}
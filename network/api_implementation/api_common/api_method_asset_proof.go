@@ -0,0 +1,37 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_implementation/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIAssetProofRequest struct {
+	Height uint64         `json:"height,omitempty" msgpack:"height,omitempty"`
+	Hash   helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+}
+
+type APIAssetProofReply struct {
+	Block *api_types.APIStateProofBlock   `json:"block" msgpack:"block"`
+	Asset *api_types.APIStateElementProof `json:"asset" msgpack:"asset"`
+}
+
+func (api *APICommon) GetAssetProof(r *http.Request, args *APIAssetProofRequest, reply *APIAssetProofReply) error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if args.Hash == nil {
+			if args.Hash, err = api.ApiStore.loadAssetHash(reader, args.Height); err != nil {
+				return
+			}
+		}
+
+		if reply.Block, err = api.loadStateProofBlock(reader); err != nil {
+			return
+		}
+
+		reply.Asset, err = api.getStateElementProof(reader, "assets", args.Hash)
+		return
+	})
+}
@@ -1,6 +1,7 @@
 package api_common
 
 import (
+	"errors"
 	"net/http"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/blocks/block"
@@ -38,6 +39,10 @@ func (api *APICommon) GetBlock(r *http.Request, args *APIBlockRequest, reply *AP
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if blockchain.Blockchain.IsBlockPruned(reply.Block.Height) {
+			return errors.New("Block was pruned")
+		}
+
 		txHashes := [][]byte{}
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.Block.Height, 10))
 		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
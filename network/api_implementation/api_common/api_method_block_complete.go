@@ -39,6 +39,10 @@ func (api *APICommon) GetBlockComplete(r *http.Request, args *APIBlockCompleteRe
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if blockchain.Blockchain.IsBlockPruned(reply.BlockComplete.Block.Height) {
+			return errors.New("Block was pruned")
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.BlockComplete.Block.Height, 10))
 		if data == nil {
 			return errors.New("Strange. blockTxs was not found")
@@ -0,0 +1,53 @@
+package api_common
+
+import (
+	"fmt"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+const API_BLOCK_HEADERS_MAX_LENGTH = 500
+
+type APIBlockHeadersRequest struct {
+	Height uint64 `json:"height" msgpack:"height"`
+	Count  uint64 `json:"count" msgpack:"count"`
+}
+
+type APIBlockHeadersReply struct {
+	Headers [][]byte `json:"headers" msgpack:"headers"` //serialized blocks without the transactions
+}
+
+func (api *APICommon) GetBlockHeaders(r *http.Request, args *APIBlockHeadersRequest, reply *APIBlockHeadersReply) error {
+
+	if args.Count > API_BLOCK_HEADERS_MAX_LENGTH {
+		return fmt.Errorf("Too many headers to process: limit %d, found %d", API_BLOCK_HEADERS_MAX_LENGTH, args.Count)
+	}
+
+	chainHeight := blockchain.Blockchain.GetChainData().Height
+	if args.Height >= chainHeight {
+		return nil
+	}
+	if args.Count > chainHeight-args.Height {
+		args.Count = chainHeight - args.Height
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		reply.Headers = make([][]byte, args.Count)
+		for i := range reply.Headers {
+
+			hash, err := blockchain.Blockchain.LoadBlockHash(reader, args.Height+uint64(i))
+			if err != nil {
+				return err
+			}
+
+			if reply.Headers[i] = reader.Get("block_ByHash" + string(hash)); reply.Headers[i] == nil {
+				return fmt.Errorf("Block %d was not found", args.Height+uint64(i))
+			}
+		}
+
+		return nil
+	})
+}
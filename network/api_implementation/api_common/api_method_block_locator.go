@@ -0,0 +1,45 @@
+package api_common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+const API_BLOCK_LOCATOR_MAX_LENGTH = 32
+
+type APIBlockLocatorRequest struct {
+	Heights []uint64 `json:"heights" msgpack:"heights"` //descending
+	Hashes  [][]byte `json:"hashes" msgpack:"hashes"`
+}
+
+type APIBlockLocatorReply struct {
+	Found bool `json:"found" msgpack:"found"`
+	Index int  `json:"index" msgpack:"index"` //index of the highest block matching the chain
+}
+
+func (api *APICommon) GetBlockLocator(r *http.Request, args *APIBlockLocatorRequest, reply *APIBlockLocatorReply) error {
+
+	if len(args.Heights) > API_BLOCK_LOCATOR_MAX_LENGTH {
+		return fmt.Errorf("Too many heights to process: limit %d, found %d", API_BLOCK_LOCATOR_MAX_LENGTH, len(args.Heights))
+	}
+	if len(args.Heights) != len(args.Hashes) {
+		return errors.New("Heights and hashes length are not matching")
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		for i, height := range args.Heights {
+			hash, err := blockchain.Blockchain.LoadBlockHash(reader, height)
+			if err == nil && bytes.Equal(hash, args.Hashes[i]) {
+				reply.Found = true
+				reply.Index = i
+				return nil
+			}
+		}
+		return nil
+	})
+}
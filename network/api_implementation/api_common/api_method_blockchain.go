@@ -17,6 +17,7 @@ type APIBlockchain struct {
 	Target            string `json:"target" msgpack:"target"`
 	Supply            uint64 `json:"supply" msgpack:"supply"`
 	TotalDifficulty   string `json:"totalDifficulty" msgpack:"totalDifficulty"`
+	StateRoot         string `json:"stateRoot" msgpack:"stateRoot"`
 }
 
 func (api *APICommon) GetBlockchain(r *http.Request, args *struct{}, reply *APIBlockchain) error {
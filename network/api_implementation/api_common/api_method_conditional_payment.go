@@ -0,0 +1,116 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APIConditionalPaymentRequest struct {
+	TxId         helpers.Base64               `json:"txId" msgpack:"txId"`
+	PayloadIndex byte                         `json:"payloadIndex" msgpack:"payloadIndex"`
+	ReturnType   api_code_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIConditionalPaymentReply struct {
+	BlockHeight        uint64                                  `json:"blockHeight" msgpack:"blockHeight"` //expiry height
+	ConditionalPayment *conditional_payment.ConditionalPayment `json:"conditionalPayment,omitempty" msgpack:"conditionalPayment,omitempty"`
+	Serialized         helpers.Base64                          `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+}
+
+func (apiStore *APIStore) loadConditionalPayment(reader store_db_interface.StoreDBTransactionInterface, key string, returnType api_code_types.APIReturnType) (*APIConditionalPaymentReply, error) {
+
+	val := reader.Get("conditionalPayments:all:" + key)
+	if val == nil {
+		return nil, nil
+	}
+
+	blockHeight, err := strconv.ParseUint(string(val), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	condPayment, err := conditional_payments_list.NewConditionalPaymentsHashMap(reader, blockHeight).Get(key)
+	if err != nil || condPayment == nil {
+		return nil, err
+	}
+
+	reply := &APIConditionalPaymentReply{BlockHeight: blockHeight}
+	if returnType == api_code_types.RETURN_SERIALIZED {
+		reply.Serialized = helpers.SerializeToBytes(condPayment)
+	} else {
+		reply.ConditionalPayment = condPayment
+	}
+	return reply, nil
+}
+
+func (api *APICommon) GetConditionalPayment(r *http.Request, args *APIConditionalPaymentRequest, reply *APIConditionalPaymentReply) (err error) {
+
+	var out *APIConditionalPaymentReply
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		out, err = api.ApiStore.loadConditionalPayment(reader, conditional_payments_list.GetConditionalPaymentKey(args.TxId, args.PayloadIndex), args.ReturnType)
+		return
+	}); err != nil || out == nil {
+		return helpers.ReturnErrorIfNot(err, "Conditional Payment was not found")
+	}
+
+	*reply = *out
+	return
+}
+
+type APIConditionalPaymentsByMultisigKeyRequest struct {
+	PublicKey  helpers.Base64               `json:"publicKey" msgpack:"publicKey"`
+	Start      uint64                       `json:"start,omitempty" msgpack:"start,omitempty"`
+	Count      uint64                       `json:"count,omitempty" msgpack:"count,omitempty"`
+	ReturnType api_code_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIConditionalPaymentsByMultisigKeyReply struct {
+	Count   uint64                        `json:"count" msgpack:"count"` //total number of conditional payments of the key
+	Results []*APIConditionalPaymentReply `json:"results" msgpack:"results"`
+}
+
+func (api *APICommon) GetConditionalPaymentsByMultisigKey(r *http.Request, args *APIConditionalPaymentsByMultisigKeyRequest, reply *APIConditionalPaymentsByMultisigKeyReply) error {
+
+	if len(args.PublicKey) == 0 {
+		return errors.New("PublicKey is missing")
+	}
+
+	if args.Count == 0 || args.Count > config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS {
+		args.Count = config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		keys, err := conditional_payments_list.GetMultisigIndex(reader, args.PublicKey)
+		if err != nil {
+			return
+		}
+
+		reply.Count = uint64(len(keys))
+
+		s := generics.Min(args.Start, reply.Count)
+		n := generics.Min(s+args.Count, reply.Count)
+
+		reply.Results = make([]*APIConditionalPaymentReply, 0, n-s)
+		for _, key := range keys[s:n] {
+			var out *APIConditionalPaymentReply
+			if out, err = api.ApiStore.loadConditionalPayment(reader, string(key), args.ReturnType); err != nil {
+				return
+			}
+			if out != nil {
+				reply.Results = append(reply.Results, out)
+			}
+		}
+
+		return
+	})
+}
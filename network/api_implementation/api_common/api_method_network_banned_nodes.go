@@ -0,0 +1,77 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/known_nodes"
+	"time"
+)
+
+type APINetworkBannedNodesReply struct {
+	Nodes []*banned_nodes.BannedNode `json:"nodes" msgpack:"nodes"`
+}
+
+type APINetworkBanNodeRequest struct {
+	URL      string `json:"url" msgpack:"url"`
+	Message  string `json:"message" msgpack:"message"`
+	Duration uint64 `json:"duration" msgpack:"duration"` //seconds
+}
+
+type APINetworkBanNodeReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+type APINetworkUnbanNodeRequest struct {
+	URL string `json:"url" msgpack:"url"`
+}
+
+type APINetworkUnbanNodeReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+func (api *APICommon) GetNetworkBannedNodes(r *http.Request, args *struct{}, reply *APINetworkBannedNodesReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Nodes = banned_nodes.BannedNodes.GetList()
+	return nil
+}
+
+func (api *APICommon) NetworkBanNode(r *http.Request, args *APINetworkBanNodeRequest, reply *APINetworkBanNodeReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if args.URL == "" {
+		return errors.New("URL is empty")
+	}
+	if _, err := url.Parse(args.URL); err != nil {
+		return err
+	}
+	if args.Duration == 0 {
+		return errors.New("Duration is zero")
+	}
+
+	banned_nodes.BannedNodes.Ban(args.URL, args.Message, time.Duration(args.Duration)*time.Second)
+	if knownNode := known_nodes.KnownNodes.GetKnownNode(args.URL); knownNode != nil {
+		known_nodes.KnownNodes.RemoveKnownNode(knownNode)
+	}
+
+	reply.Result = true
+	return nil
+}
+
+func (api *APICommon) NetworkUnbanNode(r *http.Request, args *APINetworkUnbanNodeRequest, reply *APINetworkUnbanNodeReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Result = banned_nodes.BannedNodes.Unban(args.URL)
+	return nil
+}
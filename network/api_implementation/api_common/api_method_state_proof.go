@@ -0,0 +1,86 @@
+package api_common
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"pandora-pay/config/config_block"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_implementation/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APIStateProofRequest struct {
+	Map string         `json:"map" msgpack:"map"`
+	Key helpers.Base64 `json:"key" msgpack:"key"`
+}
+
+type APIStateProofReply struct {
+	Block   *api_types.APIStateProofBlock   `json:"block" msgpack:"block"`
+	Element *api_types.APIStateElementProof `json:"element" msgpack:"element"`
+}
+
+func (api *APICommon) loadStateProofBlock(reader store_db_interface.StoreDBTransactionInterface) (out *api_types.APIStateProofBlock, err error) {
+
+	if !reader.Exists("stateTreeInitialized") {
+		return nil, errors.New("State tree is not available")
+	}
+
+	chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+	if chainHeight == 0 {
+		return nil, errors.New("Chain is empty")
+	}
+
+	//the state tree is built by every full node, but the blocks commit to it only after the activation
+	if config_block.GetBlockVersion(chainHeight-1) < config_block.BLOCK_VERSION_STATE_ROOT {
+		return nil, errors.New("State proofs are not available yet. Blocks commit to the StateRoot only after STATE_ROOT_ACTIVATION_HEIGHT")
+	}
+
+	out = &api_types.APIStateProofBlock{
+		Height: chainHeight - 1,
+		Hash:   reader.Get("blockHash_ByHeight" + strconv.FormatUint(chainHeight-1, 10)),
+	}
+
+	if out.Block = reader.Get("block_ByHash" + string(out.Hash)); out.Block == nil {
+		return nil, errors.New("Block was not found")
+	}
+
+	if out.StateRoot, err = state_tree.GetRoot(reader); err != nil {
+		return
+	}
+
+	return
+}
+
+func (api *APICommon) getStateElementProof(reader store_db_interface.StoreDBTransactionInterface, mapName string, key []byte) (out *api_types.APIStateElementProof, err error) {
+
+	storeKey := mapName + ":map:" + string(key)
+
+	out = &api_types.APIStateElementProof{
+		Map:   mapName,
+		Key:   key,
+		Value: reader.Get(storeKey),
+	}
+
+	out.Proof, err = state_tree.GetProof(reader, storeKey)
+	return
+}
+
+// GetStateProof returns the serialized element of a state hash map and the proof tying it to the StateRoot of the last block
+func (api *APICommon) GetStateProof(r *http.Request, args *APIStateProofRequest, reply *APIStateProofReply) error {
+
+	if len(args.Map) <= 4 || len(args.Key) == 0 {
+		return errors.New("Invalid map or key")
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		if reply.Block, err = api.loadStateProofBlock(reader); err != nil {
+			return
+		}
+		reply.Element, err = api.getStateElementProof(reader, args.Map, args.Key)
+		return
+	})
+}
@@ -9,6 +9,7 @@ import (
 )
 
 type APIWalletCreateAddressRequest struct {
+	APIWalletRequest
 	Name          string `json:"name" msgpack:"name"`
 	Staked        bool   `json:"staked" msgpack:"staked"`
 	SpendRequired bool   `json:"spendRequired" msgpack:"spendRequired"`
@@ -23,7 +24,12 @@ func (api *APICommon) GetWalletCreateAddress(r *http.Request, args *APIWalletCre
 		return errors.New("Invalid User or Password")
 	}
 
-	addr, err := wallet.Wallet.AddNewAddress(true, args.Name, args.Staked, args.SpendRequired, true)
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	addr, err := w.AddNewAddress(true, args.Name, args.Staked, args.SpendRequired, true)
 	if err != nil {
 		return err
 	}
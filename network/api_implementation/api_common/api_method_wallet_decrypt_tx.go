@@ -14,6 +14,7 @@ import (
 )
 
 type APIWalletDecryptTxRequest struct {
+	APIWalletRequest
 	api_types.APIAccountBaseRequest
 	Hash helpers.Base64 `json:"hash" msgpack:"hash"`
 }
@@ -29,6 +30,11 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	publicKey, err := args.GetPublicKey(false)
 	if err != nil {
 		return
@@ -64,7 +70,7 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 		return
 	}
 
-	reply.Decrypted, err = wallet.Wallet.DecryptTx(tx, publicKey)
+	reply.Decrypted, err = w.DecryptTx(tx, publicKey)
 
 	return
 }
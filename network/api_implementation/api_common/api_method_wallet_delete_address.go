@@ -8,6 +8,7 @@ import (
 )
 
 type APIWalletDeleteAddressRequest struct {
+	APIWalletRequest
 	api_types.APIAccountBaseRequest
 }
 
@@ -20,11 +21,16 @@ func (api *APICommon) GetWalletDeleteAddress(r *http.Request, args *APIWalletDel
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	publicKey, err := args.GetPublicKey(true)
 	if err != nil {
 		return err
 	}
 
-	reply.Status, err = wallet.Wallet.RemoveAddressByPublicKey(publicKey, true)
+	reply.Status, err = w.RemoveAddressByPublicKey(publicKey, true)
 	return err
 }
@@ -7,6 +7,7 @@ import (
 )
 
 type APIWalletEncryptionDecryptRequest struct {
+	APIWalletRequest
 	Password string `json:"password" msgpack:"password"`
 }
 
@@ -20,7 +21,12 @@ func (api *APICommon) EncryptionWalletDecrypt(r *http.Request, args *APIWalletEn
 		return errors.New("Invalid User or Password")
 	}
 
-	if err = wallet.Wallet.Encryption.Decrypt(args.Password); err != nil {
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if err = w.Encryption.Decrypt(args.Password); err != nil {
 		return
 	}
 	reply.Result = true
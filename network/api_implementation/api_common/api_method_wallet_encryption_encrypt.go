@@ -7,6 +7,7 @@ import (
 )
 
 type APIWalletEncryptionEncryptRequest struct {
+	APIWalletRequest
 	Password   string `json:"password" msgpack:"password"`
 	Difficulty int    `json:"difficulty" msgpack:"difficulty"`
 }
@@ -21,7 +22,12 @@ func (api *APICommon) EncryptionWalletEncrypt(r *http.Request, args *APIWalletEn
 		return errors.New("Invalid User or Password")
 	}
 
-	if err = wallet.Wallet.Encryption.Encrypt(args.Password, args.Difficulty); err != nil {
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if err = w.Encryption.Encrypt(args.Password, args.Difficulty); err != nil {
 		return
 	}
 	reply.Result = true
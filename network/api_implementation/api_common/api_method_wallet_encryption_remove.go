@@ -10,13 +10,18 @@ type APIWalletEncryptionRemoveReply struct {
 	Result bool `json:"result" msgpack:"mnemonic"`
 }
 
-func (api *APICommon) EncryptionWalletRemove(r *http.Request, args *struct{}, reply *APIWalletEncryptionRemoveReply, authenticated bool) (err error) {
+func (api *APICommon) EncryptionWalletRemove(r *http.Request, args *APIWalletRequest, reply *APIWalletEncryptionRemoveReply, authenticated bool) (err error) {
 
 	if !authenticated {
 		return errors.New("Invalid User or Password")
 	}
 
-	if err = wallet.Wallet.Encryption.RemoveEncryption(); err != nil {
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if err = w.Encryption.RemoveEncryption(); err != nil {
 		return
 	}
 	reply.Result = true
@@ -13,6 +13,7 @@ import (
 )
 
 type APIWalletGenerateAddressRequest struct {
+	APIWalletRequest
 	api_types.APIAccountBaseRequest
 	PaymentID     helpers.Base64 `json:"paymentID" msgpack:"paymentID"`
 	PaymentAmount uint64         `json:"paymentAmount" msgpack:"paymentAmount"`
@@ -29,12 +30,17 @@ func (api *APICommon) GetWalletGenerateAddress(r *http.Request, args *APIWalletG
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	publicKey, err := args.GetPublicKey(true)
 	if err != nil {
 		return err
 	}
 
-	walletAddr := wallet.Wallet.GetWalletAddressByPublicKey(publicKey, true)
+	walletAddr := w.GetWalletAddressByPublicKey(publicKey, true)
 	if walletAddr == nil {
 		return errors.New("address doesn't exist in your waallet")
 	}
@@ -50,7 +56,13 @@ func (api *APICommon) GetWalletGenerateAddress(r *http.Request, args *APIWalletG
 
 		var addr *addresses.Address
 
-		if !isReg {
+		if walletAddr.WatchOnly { //watch-only address can only reuse the registration it was imported with
+			if !isReg {
+				addr, err = addresses.CreateAddr(publicKey, walletAddr.Staked, walletAddr.SpendPublicKey, walletAddr.Registration, args.PaymentID, args.PaymentAmount, args.PaymentAsset)
+			} else {
+				addr, err = addresses.CreateAddr(publicKey, false, nil, nil, args.PaymentID, args.PaymentAmount, args.PaymentAsset)
+			}
+		} else if !isReg {
 			addr, err = walletAddr.PrivateKey.GenerateAddress(walletAddr.Staked, walletAddr.SpendPublicKey, true, args.PaymentID, args.PaymentAmount, args.PaymentAsset)
 		} else {
 			addr, err = walletAddr.PrivateKey.GenerateAddress(false, nil, false, args.PaymentID, args.PaymentAmount, args.PaymentAsset)
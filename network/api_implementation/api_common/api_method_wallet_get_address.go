@@ -9,6 +9,7 @@ import (
 )
 
 type APIWalletGetAddressRequest struct {
+	APIWalletRequest
 	api_types.APIAccountBaseRequest
 	Index int `json:"index" msgpack:"index"`
 }
@@ -23,8 +24,13 @@ func (api *APICommon) GetWalletAddress(r *http.Request, args *APIWalletGetAddres
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	if args.Index >= 0 {
-		if reply.Address, err = wallet.Wallet.GetWalletAddress(args.Index, true); err != nil {
+		if reply.Address, err = w.GetWalletAddress(args.Index, true); err != nil {
 			return
 		}
 	} else {
@@ -32,7 +38,7 @@ func (api *APICommon) GetWalletAddress(r *http.Request, args *APIWalletGetAddres
 		if publicKey, err = args.GetPublicKey(true); err != nil {
 			return
 		}
-		reply.Address = wallet.Wallet.GetWalletAddressByPublicKey(publicKey, true)
+		reply.Address = w.GetWalletAddressByPublicKey(publicKey, true)
 	}
 
 	return
@@ -11,17 +11,22 @@ type APIWalletGetAddressesReply struct {
 	Addresses []*wallet_address.WalletAddress `json:"addresses" msgpack:"addresses"`
 }
 
-func (api *APICommon) GetWalletAddresses(r *http.Request, args *struct{}, reply *APIWalletGetAddressesReply, authenticated bool) (err error) {
+func (api *APICommon) GetWalletAddresses(r *http.Request, args *APIWalletRequest, reply *APIWalletGetAddressesReply, authenticated bool) (err error) {
 
 	if !authenticated {
 		return errors.New("Invalid User or Password")
 	}
 
-	wallet.Wallet.Lock.RLock()
-	defer wallet.Wallet.Lock.RUnlock()
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	w.Lock.RLock()
+	defer w.Lock.RUnlock()
 
-	reply.Addresses = make([]*wallet_address.WalletAddress, len(wallet.Wallet.Addresses))
-	for i, addr := range wallet.Wallet.Addresses {
+	reply.Addresses = make([]*wallet_address.WalletAddress, len(w.Addresses))
+	for i, addr := range w.Addresses {
 		reply.Addresses[i] = addr.Clone()
 	}
 
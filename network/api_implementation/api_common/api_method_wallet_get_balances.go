@@ -16,6 +16,7 @@ import (
 )
 
 type APIWalletGetBalanceRequest struct {
+	APIWalletRequest
 	List []*api_types.APIAccountBaseRequest `json:"list" msgpack:"list"`
 }
 
@@ -24,9 +25,11 @@ type APIWalletGetBalancesReply struct {
 }
 
 type APIWalletGetBalancesResultReply struct {
-	Address  string                          `json:"address" msgpack:"address"`
-	PlainAcc *plain_account.PlainAccount     `json:"plainAcc" msgpack:"plainAcc"`
-	Balances []*APIWalletGetBalanceDataReply `json:"balances" msgpack:"balances"`
+	Address   string                          `json:"address" msgpack:"address"`
+	PlainAcc  *plain_account.PlainAccount     `json:"plainAcc" msgpack:"plainAcc"`
+	Balances  []*APIWalletGetBalanceDataReply `json:"balances" msgpack:"balances"`
+	WatchOnly bool                            `json:"watchOnly,omitempty" msgpack:"watchOnly,omitempty"`
+	Decrypted bool                            `json:"decrypted" msgpack:"decrypted"` //false for watch-only addresses. Only the encrypted balances are returned
 }
 
 type APIWalletGetBalanceDataReply struct {
@@ -41,6 +44,11 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	publicKeys := make([][]byte, len(args.List))
 	for i, it := range args.List {
 		if publicKeys[i], err = it.GetPublicKey(true); err != nil {
@@ -50,7 +58,7 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 
 	walletAddresses := make([]*wallet_address.WalletAddress, len(publicKeys))
 	for i, publicKey := range publicKeys {
-		if walletAddresses[i] = wallet.Wallet.GetWalletAddressByPublicKey(publicKey, true); walletAddresses[i] == nil {
+		if walletAddresses[i] = w.GetWalletAddressByPublicKey(publicKey, true); walletAddresses[i] == nil {
 			return errors.New(fmt.Sprintf("input %d doesn't exist in your wallet", i))
 		}
 	}
@@ -71,6 +79,8 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 			reply.Results[i] = &APIWalletGetBalancesResultReply{}
 
 			reply.Results[i].Address = walletAddresses[i].GetAddress(isReg)
+			reply.Results[i].WatchOnly = walletAddresses[i].WatchOnly
+			reply.Results[i].Decrypted = walletAddresses[i].CanDecrypt()
 
 			var plainAcc *plain_account.PlainAccount
 			if plainAcc, err = dataStorage.PlainAccs.Get(string(publicKey)); err != nil {
@@ -112,9 +122,12 @@ func (api *APICommon) GetWalletBalances(r *http.Request, args *APIWalletGetBalan
 	}
 
 	for i, publicKey := range publicKeys {
+		if !reply.Results[i].Decrypted {
+			continue
+		}
 		for _, data := range reply.Results[i].Balances {
 
-			if data.Amount, err = wallet.Wallet.DecryptBalanceByPublicKey(publicKey, data.Balance, data.Asset, false, 0, true, true, nil, func(status string) {}); err != nil {
+			if data.Amount, err = w.DecryptBalanceByPublicKey(publicKey, data.Balance, data.Asset, false, 0, true, true, nil, func(status string) {}); err != nil {
 				return
 			}
 		}
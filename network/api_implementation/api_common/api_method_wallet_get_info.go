@@ -21,24 +21,29 @@ type APIWalletGetInfoReply struct {
 	DelegatesCount     int    `json:"delegatesCount" msgpack:"delegatesCount"`
 }
 
-func (api *APICommon) GetWalletInfo(r *http.Request, args *struct{}, reply *APIWalletGetInfoReply, authenticated bool) (err error) {
+func (api *APICommon) GetWalletInfo(r *http.Request, args *APIWalletRequest, reply *APIWalletGetInfoReply, authenticated bool) (err error) {
 
 	if !authenticated {
 		return errors.New("Invalid User or Password")
 	}
 
-	wallet.Wallet.Lock.RLock()
-	defer wallet.Wallet.Lock.RUnlock()
-
-	reply.Version = wallet.Wallet.Version
-	reply.Encryption.Encrypted = wallet.Wallet.Encryption.Encrypted
-	reply.Encryption.Salt = wallet.Wallet.Encryption.Salt
-	reply.Encryption.Difficulty = wallet.Wallet.Encryption.Difficulty
-	reply.SeedIndex = wallet.Wallet.SeedIndex
-	reply.Count = wallet.Wallet.Count
-	reply.CountImportedIndex = wallet.Wallet.CountImportedIndex
-	reply.Loaded = wallet.Wallet.Loaded
-	reply.DelegatesCount = wallet.Wallet.DelegatesCount
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	w.Lock.RLock()
+	defer w.Lock.RUnlock()
+
+	reply.Version = w.Version
+	reply.Encryption.Encrypted = w.Encryption.Encrypted
+	reply.Encryption.Salt = w.Encryption.Salt
+	reply.Encryption.Difficulty = w.Encryption.Difficulty
+	reply.SeedIndex = w.SeedIndex
+	reply.Count = w.Count
+	reply.CountImportedIndex = w.CountImportedIndex
+	reply.Loaded = w.Loaded
+	reply.DelegatesCount = w.DelegatesCount
 
 	return
 }
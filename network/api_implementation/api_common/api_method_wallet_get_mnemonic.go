@@ -10,16 +10,21 @@ type APIWalletGetMnemonicReply struct {
 	Mnemonic string `json:"mnemonic" msgpack:"mnemonic"`
 }
 
-func (api *APICommon) GetWalletMnemonic(r *http.Request, args *struct{}, reply *APIWalletGetMnemonicReply, authenticated bool) (err error) {
+func (api *APICommon) GetWalletMnemonic(r *http.Request, args *APIWalletRequest, reply *APIWalletGetMnemonicReply, authenticated bool) (err error) {
 
 	if !authenticated {
 		return errors.New("Invalid User or Password")
 	}
 
-	wallet.Wallet.Lock.RLock()
-	defer wallet.Wallet.Lock.RUnlock()
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	w.Lock.RLock()
+	defer w.Lock.RUnlock()
 
-	reply.Mnemonic = wallet.Wallet.Mnemonic
+	reply.Mnemonic = w.Mnemonic
 
 	return
 }
@@ -9,6 +9,7 @@ import (
 )
 
 type APIWalletImportAddressSecretKeyRequest struct {
+	APIWalletRequest
 	Name          string         `json:"name" msgpack:"name""`
 	SecretKey     helpers.Base64 `json:"mnemonic" msgpack:"mnemonic"`
 	Staked        bool           `json:"staked" msgpack:"staked"`
@@ -26,8 +27,13 @@ func (api *APICommon) ImportWalletAddressSecretKey(r *http.Request, args *APIWal
 		return errors.New("Invalid User or Password")
 	}
 
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
 	var addr *wallet_address.WalletAddress
-	if addr, err = wallet.Wallet.ImportSecretKey(args.Name, args.SecretKey, args.Staked, args.SpendRequired); err != nil {
+	if addr, err = w.ImportSecretKey(args.Name, args.SecretKey, args.Staked, args.SpendRequired); err != nil {
 		return err
 	}
 	reply.Address = addr
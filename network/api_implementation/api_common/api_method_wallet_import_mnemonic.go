@@ -7,6 +7,7 @@ import (
 )
 
 type APIWalletImportMnemonicRequest struct {
+	APIWalletRequest
 	Mnemonic string `json:"mnemonic" msgpack:"mnemonic"`
 }
 
@@ -20,7 +21,12 @@ func (api *APICommon) ImportWalletMnemonic(r *http.Request, args *APIWalletImpor
 		return errors.New("Invalid User or Password")
 	}
 
-	if err = wallet.Wallet.ImportMnemonic(args.Mnemonic); err != nil {
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if err = w.ImportMnemonic(args.Mnemonic); err != nil {
 		return
 	}
 	reply.Result = true
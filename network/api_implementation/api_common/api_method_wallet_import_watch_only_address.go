@@ -0,0 +1,38 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/wallet"
+	"pandora-pay/wallet/wallet_address"
+)
+
+type APIWalletImportWatchOnlyAddressRequest struct {
+	APIWalletRequest
+	Name    string `json:"name" msgpack:"name"`
+	Address string `json:"address" msgpack:"address"`
+}
+
+type APIWalletImportWatchOnlyAddressReply struct {
+	Address *wallet_address.WalletAddress `json:"address" msgpack:"address"`
+	Result  bool                          `json:"result" msgpack:"result"`
+}
+
+func (api *APICommon) ImportWalletWatchOnlyAddress(r *http.Request, args *APIWalletImportWatchOnlyAddressRequest, reply *APIWalletImportWatchOnlyAddressReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if reply.Address, err = w.ImportWatchOnlyAddress(args.Name, args.Address); err != nil {
+		return
+	}
+	reply.Result = true
+
+	return
+}
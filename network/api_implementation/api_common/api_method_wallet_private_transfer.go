@@ -9,6 +9,7 @@ import (
 )
 
 type APIWalletPrivateTransferRequest struct {
+	APIWalletRequest
 	Data      *txs_builder.TxBuilderCreateZetherTxData `json:"data" msgpack:"data"`
 	Propagate bool                                     `json:"propagate" msgpack:"propagate"`
 }
@@ -24,6 +25,13 @@ func (api *APICommon) WalletPrivateTransfer(r *http.Request, args *APIWalletPriv
 		return errors.New("Invalid User or Password")
 	}
 
+	if args.Data == nil {
+		return errors.New("Data is missing")
+	}
+
+	//the senders are searched only in the requested wallet, or in the default wallet
+	args.Data.Wallet = args.Wallet
+
 	if reply.Tx, err = txs_builder.TxsBuilder.CreateZetherTx(args.Data, nil, args.Propagate, true, true, false, context.Background(), func(string) {}); err != nil {
 		return
 	}
@@ -7,16 +7,22 @@ import (
 )
 
 type APIWalletScanAddressesReply struct {
-	Result bool `json:"result" msgpack:"result"`
+	Result    bool                             `json:"result" msgpack:"result"`
+	WatchOnly []*wallet.WatchOnlyAddressStatus `json:"watchOnly,omitempty" msgpack:"watchOnly,omitempty"`
 }
 
-func (api *APICommon) GetWalletScanAddresses(r *http.Request, args *struct{}, reply *APIWalletScanAddressesReply, authenticated bool) (err error) {
+func (api *APICommon) GetWalletScanAddresses(r *http.Request, args *APIWalletRequest, reply *APIWalletScanAddressesReply, authenticated bool) (err error) {
 
 	if !authenticated {
 		return errors.New("Invalid User or Password")
 	}
 
-	if err = wallet.Wallet.ScanAddresses(); err != nil {
+	w, err := wallet.Wallets.GetWallet(args.Wallet)
+	if err != nil {
+		return err
+	}
+
+	if reply.WatchOnly, err = w.ScanAddresses(); err != nil {
 		return
 	}
 	reply.Result = true
@@ -0,0 +1,90 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/wallet"
+)
+
+// APIWalletRequest selects the wallet used by the wallet/* methods. Empty uses the default wallet
+type APIWalletRequest struct {
+	Wallet string `json:"wallet" msgpack:"wallet"`
+}
+
+type APIWalletsInfo struct {
+	Name      string                  `json:"name" msgpack:"name"`
+	Count     int                     `json:"count" msgpack:"count"`
+	Encrypted wallet.EncryptedVersion `json:"encrypted" msgpack:"encrypted"`
+	Loaded    bool                    `json:"loaded" msgpack:"loaded"`
+}
+
+type APIWalletsReply struct {
+	Wallets []*APIWalletsInfo `json:"wallets" msgpack:"wallets"`
+}
+
+type APIWalletsCreateRequest struct {
+	Name       string `json:"name" msgpack:"name"`
+	Password   string `json:"password" msgpack:"password"`
+	Difficulty int    `json:"difficulty" msgpack:"difficulty"`
+}
+
+type APIWalletsCreateReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+type APIWalletsRemoveRequest struct {
+	Name string `json:"name" msgpack:"name"`
+}
+
+type APIWalletsRemoveReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+func (api *APICommon) GetWallets(r *http.Request, args *struct{}, reply *APIWalletsReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	for _, name := range wallet.Wallets.GetNames() {
+
+		w, err := wallet.Wallets.GetWallet(name)
+		if err != nil { //removed meanwhile
+			continue
+		}
+
+		w.Lock.RLock()
+		reply.Wallets = append(reply.Wallets, &APIWalletsInfo{name, w.Count, w.Encryption.Encrypted, w.Loaded})
+		w.Lock.RUnlock()
+	}
+
+	return nil
+}
+
+func (api *APICommon) WalletsCreate(r *http.Request, args *APIWalletsCreateRequest, reply *APIWalletsCreateReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if _, err = wallet.Wallets.Create(args.Name, args.Password, args.Difficulty); err != nil {
+		return
+	}
+
+	reply.Result = true
+	return
+}
+
+func (api *APICommon) WalletsRemove(r *http.Request, args *APIWalletsRemoveRequest, reply *APIWalletsRemoveReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if err = wallet.Wallets.Remove(args.Name); err != nil {
+		return
+	}
+
+	reply.Result = true
+	return
+}
@@ -0,0 +1,64 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/network/webhooks"
+)
+
+type APIWebhooksReply struct {
+	Webhooks          []*webhooks.Webhook `json:"webhooks" msgpack:"webhooks"`
+	PendingDeliveries int                 `json:"pendingDeliveries" msgpack:"pendingDeliveries"`
+}
+
+type APIWebhookAddRequest struct {
+	webhooks.WebhookConfig
+}
+
+type APIWebhookAddReply struct {
+	Webhook *webhooks.Webhook `json:"webhook" msgpack:"webhook"`
+}
+
+type APIWebhookRemoveRequest struct {
+	Id string `json:"id" msgpack:"id"`
+}
+
+type APIWebhookRemoveReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+func (api *APICommon) GetWebhooks(r *http.Request, args *struct{}, reply *APIWebhooksReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Webhooks = webhooks.Webhooks.GetList()
+	reply.PendingDeliveries = webhooks.Webhooks.GetPendingDeliveries()
+	return nil
+}
+
+func (api *APICommon) WebhookAdd(r *http.Request, args *APIWebhookAddRequest, reply *APIWebhookAddReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	var webhook *webhooks.Webhook
+	if webhook, err = webhooks.Webhooks.Add(&args.WebhookConfig); err != nil {
+		return
+	}
+
+	reply.Webhook = webhook.Public()
+	return
+}
+
+func (api *APICommon) WebhookRemove(r *http.Request, args *APIWebhookRemoveRequest, reply *APIWebhookRemoveReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Result, err = webhooks.Webhooks.Remove(args.Id)
+	return
+}
@@ -0,0 +1,64 @@
+package api_types
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store/state_tree"
+)
+
+// APIStateProofBlock is the last block of the chain whose header commits to the StateRoot
+type APIStateProofBlock struct {
+	Height    uint64         `json:"height" msgpack:"height"`
+	Hash      helpers.Base64 `json:"hash" msgpack:"hash"`
+	Block     helpers.Base64 `json:"block" msgpack:"block"` //serialized block header
+	StateRoot helpers.Base64 `json:"stateRoot" msgpack:"stateRoot"`
+}
+
+// APIStateElementProof is an element of a state hash map. A missing Value proves the element doesn't exist
+type APIStateElementProof struct {
+	Map   string                 `json:"map" msgpack:"map"`
+	Key   helpers.Base64         `json:"key" msgpack:"key"`
+	Value helpers.Base64         `json:"value,omitempty" msgpack:"value,omitempty"` //serialized
+	Proof *state_tree.StateProof `json:"proof" msgpack:"proof"`
+}
+
+// Verify checks that the serialized block has the given hash and commits to the StateRoot.
+// The blockHash must come from a trusted source, otherwise the proofs only prove what the node wants
+func (proofBlock *APIStateProofBlock) Verify(blockHash []byte) error {
+
+	if len(blockHash) != cryptography.HashSize {
+		return errors.New("Trusted block hash is missing")
+	}
+	if !bytes.Equal(blockHash, proofBlock.Hash) {
+		return errors.New("Block hash is not the expected one")
+	}
+
+	if !bytes.Equal(cryptography.SHA3(proofBlock.Block), proofBlock.Hash) {
+		return errors.New("Block doesn't match the hash")
+	}
+
+	blk := block.CreateEmptyBlock()
+	if err := blk.Deserialize(advanced_buffers.NewBufferReader(proofBlock.Block)); err != nil {
+		return err
+	}
+
+	if blk.Height != proofBlock.Height {
+		return errors.New("Block height doesn't match")
+	}
+	if blk.StateRoot == nil || !bytes.Equal(blk.StateRoot, proofBlock.StateRoot) {
+		return errors.New("Block doesn't commit to the StateRoot")
+	}
+
+	return nil
+}
+
+func (proof *APIStateElementProof) Verify(stateRoot []byte) error {
+	if !state_tree.VerifyProof(stateRoot, proof.Map+":map:"+string(proof.Key), proof.Value, proof.Proof) {
+		return errors.New("State proof is invalid for " + proof.Map)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package api_types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/config/config_block"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func createTestProofBlock(version uint64, stateRoot []byte) *APIStateProofBlock {
+
+	blk := &block.Block{
+		BlockHeader:    &block.BlockHeader{version, 10},
+		MerkleHash:     helpers.RandomBytes(cryptography.HashSize),
+		StateRoot:      stateRoot,
+		PrevHash:       helpers.RandomBytes(cryptography.HashSize),
+		PrevKernelHash: helpers.RandomBytes(cryptography.HashSize),
+		Timestamp:      1000,
+		StakingAmount:  5000,
+		StakingNonce:   helpers.RandomBytes(32),
+	}
+
+	serialized := blk.SerializeManualToBytes()
+	return &APIStateProofBlock{10, cryptography.SHA3(serialized), serialized, stateRoot}
+}
+
+func TestAPIStateProofBlock_Verify(t *testing.T) {
+
+	stateRoot := helpers.RandomBytes(cryptography.HashSize)
+	proofBlock := createTestProofBlock(config_block.BLOCK_VERSION_STATE_ROOT, stateRoot)
+
+	assert.NoError(t, proofBlock.Verify(proofBlock.Hash))
+
+	//the trusted hash is mandatory
+	assert.EqualError(t, proofBlock.Verify(nil), "Trusted block hash is missing")
+	assert.EqualError(t, proofBlock.Verify(helpers.RandomBytes(cryptography.HashSize)), "Block hash is not the expected one")
+
+	//the node returned a different StateRoot than the one committed in the block
+	tampered := *proofBlock
+	tampered.StateRoot = helpers.RandomBytes(cryptography.HashSize)
+	assert.EqualError(t, tampered.Verify(proofBlock.Hash), "Block doesn't commit to the StateRoot")
+
+	//the node changed the block but kept the trusted hash
+	tampered = *proofBlock
+	tampered.Block = helpers.CloneBytes(proofBlock.Block)
+	tampered.Block[len(tampered.Block)-1] ^= 1
+	assert.EqualError(t, tampered.Verify(proofBlock.Hash), "Block doesn't match the hash")
+
+	tampered = *proofBlock
+	tampered.Height = 11
+	assert.EqualError(t, tampered.Verify(proofBlock.Hash), "Block height doesn't match")
+
+	//blocks before the activation don't commit to any StateRoot
+	oldBlock := createTestProofBlock(config_block.BLOCK_VERSION_INITIAL, nil)
+	oldBlock.StateRoot = stateRoot
+	assert.EqualError(t, oldBlock.Verify(oldBlock.Hash), "Block doesn't commit to the StateRoot")
+}
+
+func TestAPIStateElementProof_Verify(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	key := helpers.RandomBytes(cryptography.PublicKeySize)
+	value := helpers.RandomBytes(50)
+	missingKey := helpers.RandomBytes(cryptography.PublicKeySize)
+
+	var stateRoot []byte
+	var proof, missingProof *APIStateElementProof
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		for i := 0; i < 20; i++ {
+			if err = state_tree.Update(writer, "plainAccs:map:"+string(helpers.RandomBytes(cryptography.PublicKeySize)), helpers.RandomBytes(20)); err != nil {
+				return
+			}
+		}
+		if err = state_tree.Update(writer, "plainAccs:map:"+string(key), value); err != nil {
+			return
+		}
+
+		if stateRoot, err = state_tree.GetRoot(writer); err != nil {
+			return
+		}
+
+		proof = &APIStateElementProof{"plainAccs", key, value, nil}
+		if proof.Proof, err = state_tree.GetProof(writer, "plainAccs:map:"+string(key)); err != nil {
+			return
+		}
+
+		missingProof = &APIStateElementProof{"plainAccs", missingKey, nil, nil}
+		missingProof.Proof, err = state_tree.GetProof(writer, "plainAccs:map:"+string(missingKey))
+		return
+	}))
+
+	assert.NoError(t, proof.Verify(stateRoot))
+	assert.NoError(t, missingProof.Verify(stateRoot))
+
+	assert.EqualError(t, proof.Verify(helpers.RandomBytes(cryptography.HashSize)), "State proof is invalid for plainAccs")
+
+	//tampered value
+	tampered := *proof
+	tampered.Value = helpers.CloneBytes(value)
+	tampered.Value[0] ^= 1
+	assert.Error(t, tampered.Verify(stateRoot))
+
+	//an existing element can't be proven missing
+	tampered = *proof
+	tampered.Value = nil
+	assert.Error(t, tampered.Verify(stateRoot))
+
+	//the proof is bound to the map and the key
+	tampered = *proof
+	tampered.Map = "accounts_" + string(helpers.RandomBytes(cryptography.HashSize))
+	assert.Error(t, tampered.Verify(stateRoot))
+
+	tampered = *missingProof
+	tampered.Key = key
+	assert.Error(t, tampered.Verify(stateRoot))
+
+	//tampered sibling
+	if assert.NotEmpty(t, proof.Proof.Siblings) {
+		siblings := make([][]byte, len(proof.Proof.Siblings))
+		for i := range siblings {
+			siblings[i] = helpers.CloneBytes(proof.Proof.Siblings[i])
+		}
+		siblings[len(siblings)-1][0] ^= 1
+		tampered = *proof
+		tampered.Proof = &state_tree.StateProof{siblings, nil, nil}
+		assert.Error(t, tampered.Verify(stateRoot))
+	}
+
+	//the proof of another key
+	tampered = *proof
+	tampered.Proof = missingProof.Proof
+	assert.Error(t, tampered.Verify(stateRoot))
+}
@@ -1,5 +1,7 @@
 package api_types
 
+import "pandora-pay/helpers"
+
 type APISubscriptionNotificationTxExtraBlockchain struct {
 	Inserted     bool   `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
 	BlkHeight    uint64 `json:"blkHeight" msgpack:"blkHeight"`
@@ -16,8 +18,10 @@ type APISubscriptionNotificationAccountTxExtraBlockchain struct {
 }
 
 type APISubscriptionNotificationAccountTxExtraMempool struct {
-	Inserted bool `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
-	Included bool `json:"included,omitempty" msgpack:"included,omitempty"`
+	Inserted   bool           `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	Included   bool           `json:"included,omitempty" msgpack:"included,omitempty"`
+	Evicted    bool           `json:"evicted,omitempty" msgpack:"evicted,omitempty"`
+	ReplacedBy helpers.Base64 `json:"replacedBy,omitempty" msgpack:"replacedBy,omitempty"`
 }
 
 type APISubscriptionNotificationAccountExtra struct {
@@ -43,8 +47,30 @@ type APISubscriptionNotificationAccountTxExtra struct {
 }
 
 type APISubscriptionNotificationTxExtraMempool struct {
-	Inserted bool `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
-	Included bool `json:"included,omitempty" msgpack:"included,omitempty"`
+	Inserted   bool           `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	Included   bool           `json:"included,omitempty" msgpack:"included,omitempty"`
+	Evicted    bool           `json:"evicted,omitempty" msgpack:"evicted,omitempty"`
+	ReplacedBy helpers.Base64 `json:"replacedBy,omitempty" msgpack:"replacedBy,omitempty"`
+}
+
+type APISubscriptionNotificationConditionalPaymentExtra struct {
+	BlockHeight uint64 `json:"blockHeight" msgpack:"blockHeight"` //expiry height
+	Index       uint64 `json:"index" msgpack:"index"`
+}
+
+type APISubscriptionNotificationBlock struct {
+	Height    uint64         `json:"height" msgpack:"height"`
+	Hash      helpers.Base64 `json:"hash" msgpack:"hash"`
+	Timestamp uint64         `json:"timestamp" msgpack:"timestamp"`
+	TxsCount  uint64         `json:"txsCount" msgpack:"txsCount"`
+}
+
+type APISubscriptionNotificationBlocksExtra struct {
+	Height        uint64                              `json:"height" msgpack:"height"`
+	Hash          helpers.Base64                      `json:"hash" msgpack:"hash"`
+	Inserted      []*APISubscriptionNotificationBlock `json:"inserted" msgpack:"inserted"`
+	ReorgDepth    uint64                              `json:"reorgDepth,omitempty" msgpack:"reorgDepth,omitempty"`
+	RemovedHashes []helpers.Base64                    `json:"removedHashes,omitempty" msgpack:"removedHashes,omitempty"` //ascending height
 }
 
 type APISubscriptionNotificationTxExtra struct {
@@ -10,6 +10,7 @@ import (
 	"pandora-pay/network/api_implementation/api_common"
 	"pandora-pay/network/api_implementation/api_common/api_delegator_node"
 	"pandora-pay/network/api_implementation/api_common/api_faucet"
+	"pandora-pay/network/api_keys"
 	"pandora-pay/network/network_config"
 )
 
@@ -43,6 +44,10 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon) *API
 		"block/exists":                     api_code_http.Handle[api_common.APIBlockExistsRequest, api_common.APIBlockExistsReply](api.apiCommon.GetBlockExists),
 		"block":                            api_code_http.Handle[api_common.APIBlockRequest, api_common.APIBlockReply](api.apiCommon.GetBlock),
 		"block-complete":                   api_code_http.Handle[api_common.APIBlockCompleteRequest, api_common.APIBlockCompleteReply](api.apiCommon.GetBlockComplete),
+		"state-proof":                      api_code_http.Handle[api_common.APIStateProofRequest, api_common.APIStateProofReply](api.apiCommon.GetStateProof),
+		"account/proof":                    api_code_http.Handle[api_common.APIAccountProofRequest, api_common.APIAccountProofReply](api.apiCommon.GetAccountProof),
+		"accounts/by-keys/proof":           api_code_http.Handle[api_common.APIAccountsByKeysProofRequest, api_common.APIAccountsByKeysProofReply](api.apiCommon.GetAccountsByKeysProof),
+		"asset/proof":                      api_code_http.Handle[api_common.APIAssetProofRequest, api_common.APIAssetProofReply](api.apiCommon.GetAssetProof),
 		"tx-hash":                          api_code_http.Handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
 		"tx":                               api_code_http.Handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
 		"tx/exists":                        api_code_http.Handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
@@ -58,25 +63,38 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon) *API
 		"mempool/tx-exists":                api_code_http.Handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
 		"mempool/new-tx":                   api_code_http.Handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
 		"network/nodes":                    api_code_http.Handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
-		"wallet/info":                      api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletGetInfoReply](api.apiCommon.GetWalletInfo),
-		"wallet/scan-addresses":            api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletScanAddressesReply](api.apiCommon.GetWalletScanAddresses),
-		"wallet/get-address":               api_code_http.HandleAuthenticated[api_common.APIWalletGetAddressRequest, api_common.APIWalletGetAddressReply](api.apiCommon.GetWalletAddress),
-		"wallet/get-addresses":             api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletGetAddressesReply](api.apiCommon.GetWalletAddresses),
-		"wallet/get-mnemonic":              api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletGetMnemonicReply](api.apiCommon.GetWalletMnemonic),
-		"wallet/generate-address":          api_code_http.HandleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
-		"wallet/create-address":            api_code_http.HandleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
-		"wallet/delete-address":            api_code_http.HandleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
-		"wallet/get-balances":              api_code_http.HandleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
-		"wallet/import-mnemonic":           api_code_http.HandleAuthenticated[api_common.APIWalletImportMnemonicRequest, api_common.APIWalletImportMnemonicReply](api.apiCommon.ImportWalletMnemonic),
-		"wallet/import-address-secret-key": api_code_http.HandleAuthenticated[api_common.APIWalletImportAddressSecretKeyRequest, api_common.APIWalletImportAddressSecretKeyReply](api.apiCommon.ImportWalletAddressSecretKey),
-		"wallet/encryption/encrypt":        api_code_http.HandleAuthenticated[api_common.APIWalletEncryptionEncryptRequest, api_common.APIWalletEncryptionEncryptReply](api.apiCommon.EncryptionWalletEncrypt),
-		"wallet/encryption/decrypt":        api_code_http.HandleAuthenticated[api_common.APIWalletEncryptionDecryptRequest, api_common.APIWalletEncryptionDecryptReply](api.apiCommon.EncryptionWalletDecrypt),
-		"wallet/encryption/remove":         api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletEncryptionRemoveReply](api.apiCommon.EncryptionWalletRemove),
-		"wallet/decrypt-tx":                api_code_http.HandleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"network/banned-nodes":             api_code_http.HandleAuthenticated[struct{}, api_common.APINetworkBannedNodesReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetNetworkBannedNodes),
+		"network/ban-node":                 api_code_http.HandleAuthenticated[api_common.APINetworkBanNodeRequest, api_common.APINetworkBanNodeReply](api_keys.SCOPE_ADMIN, api.apiCommon.NetworkBanNode),
+		"network/unban-node":               api_code_http.HandleAuthenticated[api_common.APINetworkUnbanNodeRequest, api_common.APINetworkUnbanNodeReply](api_keys.SCOPE_ADMIN, api.apiCommon.NetworkUnbanNode),
+		"webhooks":                         api_code_http.HandleAuthenticated[struct{}, api_common.APIWebhooksReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetWebhooks),
+		"webhooks/add":                     api_code_http.HandleAuthenticated[api_common.APIWebhookAddRequest, api_common.APIWebhookAddReply](api_keys.SCOPE_ADMIN, api.apiCommon.WebhookAdd),
+		"webhooks/remove":                  api_code_http.HandleAuthenticated[api_common.APIWebhookRemoveRequest, api_common.APIWebhookRemoveReply](api_keys.SCOPE_ADMIN, api.apiCommon.WebhookRemove),
+		"wallet/info":                      api_code_http.HandleAuthenticated[api_common.APIWalletRequest, api_common.APIWalletGetInfoReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletInfo),
+		"wallet/scan-addresses":            api_code_http.HandleAuthenticated[api_common.APIWalletRequest, api_common.APIWalletScanAddressesReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetWalletScanAddresses),
+		"wallet/get-address":               api_code_http.HandleAuthenticated[api_common.APIWalletGetAddressRequest, api_common.APIWalletGetAddressReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletAddress),
+		"wallet/get-addresses":             api_code_http.HandleAuthenticated[api_common.APIWalletRequest, api_common.APIWalletGetAddressesReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletAddresses),
+		"wallet/get-mnemonic":              api_code_http.HandleAuthenticated[api_common.APIWalletRequest, api_common.APIWalletGetMnemonicReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetWalletMnemonic),
+		"wallet/generate-address":          api_code_http.HandleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletGenerateAddress),
+		"wallet/create-address":            api_code_http.HandleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetWalletCreateAddress),
+		"wallet/delete-address":            api_code_http.HandleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api_keys.SCOPE_ADMIN, api.apiCommon.GetWalletDeleteAddress),
+		"wallet/get-balances":              api_code_http.HandleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletBalances),
+		"wallet/import-mnemonic":           api_code_http.HandleAuthenticated[api_common.APIWalletImportMnemonicRequest, api_common.APIWalletImportMnemonicReply](api_keys.SCOPE_ADMIN, api.apiCommon.ImportWalletMnemonic),
+		"wallet/import-address-secret-key": api_code_http.HandleAuthenticated[api_common.APIWalletImportAddressSecretKeyRequest, api_common.APIWalletImportAddressSecretKeyReply](api_keys.SCOPE_ADMIN, api.apiCommon.ImportWalletAddressSecretKey),
+		"wallet/import-watch-only-address": api_code_http.HandleAuthenticated[api_common.APIWalletImportWatchOnlyAddressRequest, api_common.APIWalletImportWatchOnlyAddressReply](api_keys.SCOPE_ADMIN, api.apiCommon.ImportWalletWatchOnlyAddress),
+		"wallet/encryption/encrypt":        api_code_http.HandleAuthenticated[api_common.APIWalletEncryptionEncryptRequest, api_common.APIWalletEncryptionEncryptReply](api_keys.SCOPE_ADMIN, api.apiCommon.EncryptionWalletEncrypt),
+		"wallet/encryption/decrypt":        api_code_http.HandleAuthenticated[api_common.APIWalletEncryptionDecryptRequest, api_common.APIWalletEncryptionDecryptReply](api_keys.SCOPE_ADMIN, api.apiCommon.EncryptionWalletDecrypt),
+		"wallet/encryption/remove":         api_code_http.HandleAuthenticated[api_common.APIWalletRequest, api_common.APIWalletEncryptionRemoveReply](api_keys.SCOPE_ADMIN, api.apiCommon.EncryptionWalletRemove),
+		"wallet/decrypt-tx":                api_code_http.HandleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWalletDecryptTx),
+		"wallets":                          api_code_http.HandleAuthenticated[struct{}, api_common.APIWalletsReply](api_keys.SCOPE_WALLET_READ, api.apiCommon.GetWallets),
+		"wallets/create":                   api_code_http.HandleAuthenticated[api_common.APIWalletsCreateRequest, api_common.APIWalletsCreateReply](api_keys.SCOPE_ADMIN, api.apiCommon.WalletsCreate),
+		"wallets/remove":                   api_code_http.HandleAuthenticated[api_common.APIWalletsRemoveRequest, api_common.APIWalletsRemoveReply](api_keys.SCOPE_ADMIN, api.apiCommon.WalletsRemove),
+
+		"conditional-payment":                  api_code_http.Handle[api_common.APIConditionalPaymentRequest, api_common.APIConditionalPaymentReply](api.apiCommon.GetConditionalPayment),
+		"conditional-payments/by-multisig-key": api_code_http.Handle[api_common.APIConditionalPaymentsByMultisigKeyRequest, api_common.APIConditionalPaymentsByMultisigKeyReply](api.apiCommon.GetConditionalPaymentsByMultisigKey),
 	}
 
 	api.PostMap = map[string]func(values io.ReadCloser) (interface{}, error){
-		"wallet/private-transfer": api_code_http.HandlePOSTAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api.apiCommon.WalletPrivateTransfer),
+		"wallet/private-transfer": api_code_http.HandlePOSTAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api_keys.SCOPE_WALLET_SPEND, api.apiCommon.WalletPrivateTransfer),
 	}
 
 	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
@@ -98,7 +116,9 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon) *API
 
 	if api.apiCommon.DelegatorNode != nil {
 		api.GetMap["delegator-node/info"] = api_code_http.Handle[struct{}, api_delegator_node.ApiDelegatorNodeInfoReply](api.apiCommon.DelegatorNode.GetDelegatorNodeInfo)
-		api.GetMap["delegator-node/notify"] = api_code_http.HandleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.GetMap["delegator-node/notify"] = api_code_http.HandleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api_keys.SCOPE_DELEGATOR, api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.GetMap["delegator-node/delegator"] = api_code_http.HandleAuthenticated[api_delegator_node.ApiDelegatorNodeDelegatorRequest, api_delegator_node.ApiDelegatorNodeDelegatorReply](api_keys.SCOPE_DELEGATOR, api.apiCommon.DelegatorNode.GetDelegator)
+		api.GetMap["delegator-node/delegators"] = api_code_http.HandleAuthenticated[struct{}, api_delegator_node.ApiDelegatorNodeDelegatorsReply](api_keys.SCOPE_DELEGATOR, api.apiCommon.DelegatorNode.GetDelegators)
 	}
 
 	if ConfigureAPIRoutes != nil {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers/linked_list"
 	"pandora-pay/helpers/msgpack"
@@ -36,6 +37,22 @@ func (consensus *Consensus) ChainUpdateProcess(conn *connection.AdvancedConnecti
 		return nil, nil
 	} else if compare < 0 {
 
+		//the node pruned the blocks we are missing
+		if chainUpdateNotification.Start > chainLastUpdate.Height {
+			return nil, nil
+		}
+
+		//the block is on top of our chain and it can be rebuilt from the mempool
+		if chainUpdateNotification.CompactBlock != nil && config.NODE_CONSENSUS == config.NODE_CONSENSUS_TYPE_FULL &&
+			chainUpdateNotification.End == chainLastUpdate.Height+1 && bytes.Equal(chainUpdateNotification.PrevHash, chainLastUpdate.Hash) {
+
+			err := consensus.processCompactBlock(conn, chainUpdateNotification)
+			if err == nil || bytes.Equal(blockchain.Blockchain.GetChainData().Hash, chainUpdateNotification.Hash) {
+				return nil, nil
+			}
+			logger.Debug("Compact block failed", "end", chainUpdateNotification.End, "err", err)
+		}
+
 		fork := &Fork{
 			End:                chainUpdateNotification.End,
 			Hash:               chainUpdateNotification.Hash,
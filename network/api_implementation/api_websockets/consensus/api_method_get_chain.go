@@ -12,6 +12,7 @@ func (consensus *Consensus) GetUpdateNotification(newChainData *blockchain.Block
 	}
 
 	return &ChainUpdateNotification{
+		Start:              blockchain.Blockchain.PrunedHeight.Load(),
 		End:                newChainData.Height,
 		Hash:               newChainData.Hash,
 		PrevHash:           newChainData.PrevHash,
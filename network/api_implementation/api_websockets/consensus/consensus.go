@@ -1,10 +1,15 @@
 package consensus
 
 import (
+	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/metrics"
 	"pandora-pay/helpers/recovery"
+	"sync/atomic"
 )
 
+var logger = gui.NewSubsystemLogger("consensus")
+
 type Consensus struct {
 	forks *Forks
 }
@@ -15,15 +20,28 @@ func (consensus *Consensus) execute() {
 	recovery.SafeGo(processForksThread.execute)
 }
 
+func (consensus *Consensus) initMetrics() {
+
+	metrics.NewGaugeFunc("pandora_consensus_forks", "Number of forks being downloaded", func() float64 {
+		return float64(consensus.forks.getCount())
+	})
+
+	metrics.NewGaugeFunc("pandora_consensus_best_fork_height", "Height of the best fork being downloaded", func() float64 {
+		return float64(consensus.forks.getBestForkEnd())
+	})
+}
+
 func NewConsensus() *Consensus {
 
 	consensus := &Consensus{
 		&Forks{
-			hashes: &generics.Map[string, *Fork]{},
+			hashes:      &generics.Map[string, *Fork]{},
+			bestForkEnd: &atomic.Uint64{},
 		},
 	}
 
 	consensus.execute()
+	consensus.initMetrics()
 
 	return consensus
 }
@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"errors"
+	"pandora-pay/blockchain"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+var errForkTooDeep = errors.New("Fork is too deep")
+
+// getLocatorHeights returns at most API_BLOCK_LOCATOR_MAX_LENGTH heights evenly spread between high and low (both included) in descending order
+func getLocatorHeights(low, high uint64) []uint64 {
+
+	if low == high {
+		return []uint64{high}
+	}
+
+	count := high - low + 1
+	if count > api_common.API_BLOCK_LOCATOR_MAX_LENGTH {
+		count = api_common.API_BLOCK_LOCATOR_MAX_LENGTH
+	}
+
+	heights := make([]uint64, count)
+	for i := range heights {
+		heights[i] = high - uint64(i)*(high-low)/(count-1)
+	}
+	return heights
+}
+
+// findForkStart returns the first height of the fork which is different from the chain. The fork start is between minStart and start.
+// Each request narrows the interval API_BLOCK_LOCATOR_MAX_LENGTH times
+func (thread *ConsensusProcessForksThread) findForkStart(conn *connection.AdvancedConnection, start, minStart uint64) (uint64, error) {
+
+	low, high := minStart, start
+	verified := low == 0 //block low-1 is the same in the chain and fork
+
+	for !verified || low < high {
+
+		from := low
+		if !verified {
+			from = low - 1
+		}
+
+		heights := getLocatorHeights(from, high-1)
+		hashes := make([][]byte, len(heights))
+
+		if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			for i, height := range heights {
+				if hashes[i], err = blockchain.Blockchain.LoadBlockHash(reader, height); err != nil {
+					return
+				}
+			}
+			return
+		}); err != nil {
+			return 0, err
+		}
+
+		answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockLocatorReply](conn, []byte("block-locator"), &api_common.APIBlockLocatorRequest{heights, hashes}, nil, 0)
+		if err != nil {
+			return 0, err
+		}
+
+		if !answer.Found {
+			if !verified {
+				return 0, errForkTooDeep
+			}
+			high = low
+			continue
+		}
+
+		if answer.Index < 0 || answer.Index >= len(heights) {
+			return 0, errors.New("Block locator index is invalid")
+		}
+
+		low = heights[answer.Index] + 1
+		verified = true
+		if answer.Index > 0 {
+			high = heights[answer.Index-1]
+		}
+	}
+
+	return low, nil
+}
@@ -0,0 +1,154 @@
+package consensus
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/linked_list"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+func TestGetLocatorHeights(t *testing.T) {
+
+	assert.Equal(t, []uint64{5}, getLocatorHeights(5, 5))
+	assert.Equal(t, []uint64{7, 6, 5}, getLocatorHeights(5, 7))
+
+	heights := getLocatorHeights(100, 1000)
+	assert.Equal(t, api_common.API_BLOCK_LOCATOR_MAX_LENGTH, len(heights))
+	assert.Equal(t, uint64(1000), heights[0])
+	assert.Equal(t, uint64(100), heights[len(heights)-1])
+	for i := 1; i < len(heights); i++ {
+		assert.Less(t, heights[i], heights[i-1])
+	}
+}
+
+func testChainHash(height uint64, forkHeight uint64) []byte {
+	if height >= forkHeight {
+		return cryptography.SHA3([]byte("fork" + strconv.FormatUint(height, 10)))
+	}
+	return cryptography.SHA3([]byte("chain" + strconv.FormatUint(height, 10)))
+}
+
+// createTestChain stores the hashes of the chain of the node
+func createTestChain(t *testing.T, height uint64) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for i := uint64(0); i <= height; i++ {
+			writer.Put("blockHash_ByHeight"+strconv.FormatUint(i, 10), testChainHash(i, math.MaxUint64))
+		}
+		return
+	}))
+}
+
+// testBlockLocatorRoute answers like a peer whose chain is different starting with forkHeight
+func testBlockLocatorRoute(forkHeight uint64, requests *int) func(conn *connection.AdvancedConnection, values []byte) (any, error) {
+	return testRoute(func(args *api_common.APIBlockLocatorRequest) (any, error) {
+		*requests += 1
+		for i, height := range args.Heights {
+			if bytes.Equal(testChainHash(height, forkHeight), args.Hashes[i]) {
+				return &api_common.APIBlockLocatorReply{true, i}, nil
+			}
+		}
+		return &api_common.APIBlockLocatorReply{false, 0}, nil
+	})
+}
+
+func TestFindForkStart(t *testing.T) {
+
+	const start, minStart = 1000, 100
+	createTestChain(t, start)
+
+	thread := &ConsensusProcessForksThread{}
+
+	for _, forkHeight := range []uint64{minStart, minStart + 1, 345, start - 1, start} {
+		requests := 0
+		conn := createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+			"block-locator": testBlockLocatorRoute(forkHeight, &requests),
+		})
+
+		forkStart, err := thread.findForkStart(conn, start, minStart)
+		assert.NoError(t, err)
+		assert.Equal(t, forkHeight, forkStart)
+		assert.LessOrEqual(t, requests, 3, "each request narrows the interval")
+	}
+
+	//the fork starts from genesis
+	requests := 0
+	conn := createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block-locator": testBlockLocatorRoute(0, &requests),
+	})
+	forkStart, err := thread.findForkStart(conn, start, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), forkStart)
+
+	//the fork is older than minStart
+	conn = createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block-locator": testBlockLocatorRoute(minStart-1, &requests),
+	})
+	_, err = thread.findForkStart(conn, start, minStart)
+	assert.Equal(t, errForkTooDeep, err)
+
+	//the peer replies an index outside the locator
+	for _, index := range []int{-1, api_common.API_BLOCK_LOCATOR_MAX_LENGTH} {
+		conn = createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+			"block-locator": testRoute(func(args *api_common.APIBlockLocatorRequest) (any, error) {
+				return &api_common.APIBlockLocatorReply{true, index}, nil
+			}),
+		})
+		_, err = thread.findForkStart(conn, start, minStart)
+		assert.EqualError(t, err, "Block locator index is invalid")
+	}
+
+	//the peer doesn't know block locators
+	conn = createTestConn(t, nil)
+	_, err = thread.findForkStart(conn, start, minStart)
+	assert.Equal(t, advanced_connection_types.ErrUnknownRequest, err)
+}
+
+func TestInitializeFork_UnknownRequest(t *testing.T) {
+
+	const start, minStart = 1000, 100
+	createTestChain(t, start)
+
+	thread := &ConsensusProcessForksThread{}
+
+	//the peer doesn't know block locators, so the blocks are asked backwards. Its fork starts right after the chain
+	blockHashRequests := 0
+	conn := createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block-hash": testRoute(func(args *api_common.APIBlockHashRequest) (any, error) {
+			blockHashRequests += 1
+			return &api_common.APIBlockHashReply{testChainHash(args.Height, start)}, nil
+		}),
+	})
+
+	fork := &Fork{End: start + 10, Blocks: linked_list.NewLinkedList[*block_complete.BlockComplete]()}
+	fork.AddConn(conn, false)
+
+	assert.True(t, thread.initializeFork(fork, start, minStart))
+	assert.True(t, fork.Initialized)
+	assert.Equal(t, uint64(start), fork.Current)
+	assert.Equal(t, 1, blockHashRequests)
+	assert.Equal(t, 0, fork.errors)
+
+	//a peer failing the fallback too is dropped after a few errors
+	conn = createTestConn(t, nil)
+	fork = &Fork{End: start + 10, Blocks: linked_list.NewLinkedList[*block_complete.BlockComplete]()}
+	fork.AddConn(conn, false)
+
+	assert.False(t, thread.initializeFork(fork, start, minStart))
+	assert.False(t, fork.Initialized)
+	assert.Equal(t, 3, fork.errors)
+}
@@ -0,0 +1,149 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/mempool"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/txs_validator"
+)
+
+const (
+	COMPACT_BLOCK_SALT_SIZE     = 8
+	COMPACT_BLOCK_SHORT_ID_SIZE = 6
+)
+
+// getShortId returns the first COMPACT_BLOCK_SHORT_ID_SIZE bytes of the salted tx hash. The salt makes the collisions different for every block
+func getShortId(salt, txHash []byte) uint64 {
+	hash := cryptography.SHA3(append(helpers.CloneBytes(salt), txHash...))
+	shortId := make([]byte, 8)
+	copy(shortId, hash[:COMPACT_BLOCK_SHORT_ID_SIZE])
+	return binary.LittleEndian.Uint64(shortId)
+}
+
+// NewCompactBlock creates the compact block. The staking reward tx is always the last one and it is never in the mempool, so it is prefilled
+func NewCompactBlock(blkComplete *block_complete.BlockComplete) *CompactBlock {
+
+	compactBlock := &CompactBlock{
+		Block:            blkComplete.Block.Bloom.Serialized,
+		Salt:             helpers.RandomBytes(COMPACT_BLOCK_SALT_SIZE),
+		ShortIds:         make([]uint64, len(blkComplete.Txs)),
+		PrefilledIndexes: []int{},
+		PrefilledTxs:     [][]byte{},
+	}
+
+	for i, tx := range blkComplete.Txs {
+		compactBlock.ShortIds[i] = getShortId(compactBlock.Salt, tx.Bloom.Hash)
+	}
+
+	if len(blkComplete.Txs) > 0 {
+		last := len(blkComplete.Txs) - 1
+		compactBlock.PrefilledIndexes = append(compactBlock.PrefilledIndexes, last)
+		compactBlock.PrefilledTxs = append(compactBlock.PrefilledTxs, blkComplete.Txs[last].Bloom.Serialized)
+	}
+
+	return compactBlock
+}
+
+// rebuildCompactBlock deserializes the block of the chain update and fills its transactions with the prefilled ones and the mempool txs.
+// The transactions whose short ids are not unique, in the block or in the mempool, are left nil and they will be downloaded
+func rebuildCompactBlock(chainUpdateNotification *ChainUpdateNotification, mempoolTxs []*transaction.Transaction) (*block.Block, []*transaction.Transaction, error) {
+
+	compactBlock := chainUpdateNotification.CompactBlock
+
+	if len(compactBlock.Salt) != COMPACT_BLOCK_SALT_SIZE {
+		return nil, nil, errors.New("Compact block salt is invalid")
+	}
+	if len(compactBlock.PrefilledIndexes) != len(compactBlock.PrefilledTxs) {
+		return nil, nil, errors.New("Compact block prefilled txs are not matching")
+	}
+
+	blk := block.CreateEmptyBlock()
+	if err := blk.Deserialize(advanced_buffers.NewBufferReader(compactBlock.Block)); err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(blk.Bloom.Hash, chainUpdateNotification.Hash) || blk.Height+1 != chainUpdateNotification.End {
+		return nil, nil, errors.New("Compact block is not matching the chain update")
+	}
+
+	txs := make([]*transaction.Transaction, len(compactBlock.ShortIds))
+
+	for i, index := range compactBlock.PrefilledIndexes {
+		if index < 0 || index >= len(txs) {
+			return nil, nil, errors.New("Compact block prefilled index is invalid")
+		}
+		tx := &transaction.Transaction{}
+		if err := tx.Deserialize(advanced_buffers.NewBufferReader(compactBlock.PrefilledTxs[i])); err != nil {
+			return nil, nil, err
+		}
+		txs[index] = tx
+	}
+
+	counts := make(map[uint64]int)
+	for i, shortId := range compactBlock.ShortIds {
+		if txs[i] == nil {
+			counts[shortId] += 1
+		}
+	}
+
+	indexes := make(map[uint64]int)
+	for i, shortId := range compactBlock.ShortIds {
+		if txs[i] == nil && counts[shortId] == 1 { //collision inside the block, the txs will be downloaded
+			indexes[shortId] = i
+		}
+	}
+
+	found := make(map[uint64]*transaction.Transaction)
+	for _, tx := range mempoolTxs {
+		shortId := getShortId(compactBlock.Salt, tx.Bloom.Hash)
+		if _, ok := indexes[shortId]; ok {
+			if found[shortId] != nil { //collision, the tx will be downloaded
+				delete(indexes, shortId)
+				continue
+			}
+			found[shortId] = tx
+		}
+	}
+
+	for shortId, index := range indexes {
+		txs[index] = found[shortId]
+	}
+
+	return blk, txs, nil
+}
+
+// processCompactBlock rebuilds the block using the mempool and downloads only the transactions which are missing
+func (consensus *Consensus) processCompactBlock(conn *connection.AdvancedConnection, chainUpdateNotification *ChainUpdateNotification) error {
+
+	blk, txs, err := rebuildCompactBlock(chainUpdateNotification, mempool.Mempool.Txs.GetTxsOnlyList())
+	if err != nil {
+		return err
+	}
+
+	if err = downloadMissingTxs(nil, conn, blk.Bloom.Hash, txs); err != nil {
+		return err
+	}
+
+	if err = txs_validator.TxsValidator.ValidateTxs(txs); err != nil {
+		return err
+	}
+
+	blkComplete := block_complete.CreateEmptyBlockComplete()
+	blkComplete.Block = blk
+	blkComplete.Txs = txs
+
+	if err = blkComplete.BloomAll(); err != nil {
+		return err
+	}
+
+	_, err = blockchain.Blockchain.AddBlocks([]*block_complete.BlockComplete{blkComplete}, false, conn.UUID)
+	return err
+}
@@ -0,0 +1,150 @@
+package consensus
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+)
+
+func TestGetShortId(t *testing.T) {
+
+	txHash := cryptography.SHA3([]byte("tx"))
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	shortId := getShortId(salt, txHash)
+	assert.Equal(t, shortId, getShortId(salt, txHash))
+	assert.Less(t, shortId, uint64(1)<<(8*COMPACT_BLOCK_SHORT_ID_SIZE))
+
+	assert.NotEqual(t, shortId, getShortId([]byte{8, 7, 6, 5, 4, 3, 2, 1}, txHash))
+	assert.NotEqual(t, shortId, getShortId(salt, cryptography.SHA3([]byte("tx2"))))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, salt)
+}
+
+func createTestTx(t *testing.T) *transaction.Transaction {
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaimConditionalPayment{nil, helpers.RandomBytes(cryptography.HashSize), 0, helpers.RandomBytes(32)},
+		&wizard.WizardTransactionData{},
+		&wizard.WizardTransactionFee{},
+		0,
+		nil,
+	}, true, func(string) {})
+	assert.NoError(t, err)
+	return tx
+}
+
+// createTestCompactBlock returns the chain update of a block with count txs. The last tx is prefilled
+func createTestCompactBlock(t *testing.T, count int) (*ChainUpdateNotification, []*transaction.Transaction) {
+
+	blk := createTestBlocks(t, 10, 1)[0]
+
+	txs := make([]*transaction.Transaction, count)
+	for i := range txs {
+		txs[i] = createTestTx(t)
+	}
+
+	compactBlock := NewCompactBlock(&block_complete.BlockComplete{Block: blk, Txs: txs})
+	return &ChainUpdateNotification{End: 11, Hash: blk.Bloom.Hash, CompactBlock: compactBlock}, txs
+}
+
+func TestRebuildCompactBlock(t *testing.T) {
+
+	chainUpdate, txs := createTestCompactBlock(t, 4)
+
+	//the block is rebuilt from the mempool and the prefilled staking reward tx
+	mempoolTxs := []*transaction.Transaction{createTestTx(t), txs[2], txs[0], txs[1]}
+	blk, rebuilt, err := rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.NoError(t, err)
+	assert.Equal(t, chainUpdate.Hash, blk.Bloom.Hash)
+	if assert.Equal(t, len(txs), len(rebuilt)) {
+		for i, tx := range txs {
+			assert.NotNil(t, rebuilt[i])
+			assert.Equal(t, tx.SerializeManualToBytes(), rebuilt[i].SerializeManualToBytes())
+		}
+	}
+
+	//the txs missing from the mempool are left to be downloaded
+	_, rebuilt, err = rebuildCompactBlock(chainUpdate, []*transaction.Transaction{txs[1]})
+	assert.NoError(t, err)
+	assert.Nil(t, rebuilt[0])
+	assert.Equal(t, txs[1], rebuilt[1])
+	assert.Nil(t, rebuilt[2])
+	assert.NotNil(t, rebuilt[3])
+
+	//two mempool txs having the short id of txs[0]
+	collision := &transaction.Transaction{Bloom: &transaction.TransactionBloom{Hash: txs[0].Bloom.Hash}}
+	_, rebuilt, err = rebuildCompactBlock(chainUpdate, append(mempoolTxs, collision))
+	assert.NoError(t, err)
+	assert.Nil(t, rebuilt[0])
+	assert.Equal(t, txs[1], rebuilt[1])
+	assert.Equal(t, txs[2], rebuilt[2])
+
+	//the block has the same short id twice, so it is unknown which slot the mempool tx belongs to
+	chainUpdate.CompactBlock.ShortIds[1] = chainUpdate.CompactBlock.ShortIds[0]
+	_, rebuilt, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.NoError(t, err)
+	assert.Nil(t, rebuilt[0])
+	assert.Nil(t, rebuilt[1])
+	assert.Equal(t, txs[2], rebuilt[2])
+
+	//the prefilled index is out of range
+	for _, index := range []int{-1, 4, 5} {
+		chainUpdate, _ = createTestCompactBlock(t, 4)
+		chainUpdate.CompactBlock.PrefilledIndexes[0] = index
+		_, _, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+		assert.EqualError(t, err, "Compact block prefilled index is invalid")
+	}
+
+	chainUpdate, _ = createTestCompactBlock(t, 4)
+	chainUpdate.CompactBlock.PrefilledTxs = nil
+	_, _, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.EqualError(t, err, "Compact block prefilled txs are not matching")
+
+	chainUpdate.CompactBlock.Salt = chainUpdate.CompactBlock.Salt[1:]
+	_, _, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.EqualError(t, err, "Compact block salt is invalid")
+
+	//the header is not the block of the chain update
+	chainUpdate, _ = createTestCompactBlock(t, 4)
+	chainUpdate.Hash = cryptography.SHA3([]byte("other block"))
+	_, _, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.EqualError(t, err, "Compact block is not matching the chain update")
+
+	chainUpdate, _ = createTestCompactBlock(t, 4)
+	chainUpdate.End = 12
+	_, _, err = rebuildCompactBlock(chainUpdate, mempoolTxs)
+	assert.EqualError(t, err, "Compact block is not matching the chain update")
+}
+
+func TestRebuildCompactBlock_DownloadCollisions(t *testing.T) {
+
+	chainUpdate, txs := createTestCompactBlock(t, 4)
+
+	collision := &transaction.Transaction{Bloom: &transaction.TransactionBloom{Hash: txs[1].Bloom.Hash}}
+	blk, rebuilt, err := rebuildCompactBlock(chainUpdate, []*transaction.Transaction{txs[0], txs[1], collision})
+	assert.NoError(t, err)
+
+	//only the txs which couldn't be rebuilt are downloaded
+	var requested []int
+	conn := createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block-miss-txs": testRoute(func(args *APIBlockCompleteMissingTxsRequest) (any, error) {
+			requested = args.MissingTxs
+			assert.Equal(t, blk.Bloom.Hash, []byte(args.Hash))
+			reply := &APIBlockCompleteMissingTxsReply{}
+			for _, index := range args.MissingTxs {
+				reply.Txs = append(reply.Txs, txs[index].SerializeManualToBytes())
+			}
+			return reply, nil
+		}),
+	})
+
+	assert.NoError(t, downloadMissingTxs(nil, conn, blk.Bloom.Hash, rebuilt))
+	assert.Equal(t, []int{1, 2}, requested)
+	for i, tx := range txs {
+		assert.Equal(t, tx.SerializeManualToBytes(), rebuilt[i].SerializeManualToBytes())
+	}
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/network/websocks/websock"
+	"strings"
+	"testing"
+)
+
+// createTestConn connects to a peer answering the routes of getMap. The returned connection is the one of the node
+func createTestConn(t *testing.T, getMap map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error)) *connection.AdvancedConnection {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := websock.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		peer, err := connection.NewAdvancedConnection(c, r.RemoteAddr, nil, getMap, true, nil, nil, func(*connection.AdvancedConnection) {}, nil)
+		if err != nil {
+			return
+		}
+		go peer.ReadPump()
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := websock.Dial("ws" + strings.TrimPrefix(server.URL, "http"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	conn, err := connection.NewAdvancedConnection(c, server.URL, nil, nil, false, nil, nil, func(*connection.AdvancedConnection) {}, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	go conn.ReadPump()
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn
+}
+
+// testRoute decodes the request and replies the answer of the callback
+func testRoute[T any](callback func(args *T) (any, error)) func(conn *connection.AdvancedConnection, values []byte) (any, error) {
+	return func(conn *connection.AdvancedConnection, values []byte) (any, error) {
+		args := new(T)
+		if err := msgpack.Unmarshal(values, args); err != nil {
+			return nil, err
+		}
+		return callback(args)
+	}
+}
@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"context"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config"
+	"pandora-pay/helpers/recovery"
+	"sync"
+	"sync/atomic"
+)
+
+const BLOCKS_SYNC_PEER_MAX_ERRORS = 2
+
+// downloadBlocksComplete downloads in parallel from all the connections of the fork the blocks of the header hashes, starting from the height start.
+// A block which failed or timed out is reassigned to another connection. A connection stops after BLOCKS_SYNC_PEER_MAX_ERRORS errors.
+// The blocks are returned in order until the first block which couldn't be downloaded. The fork is locked before
+func (thread *ConsensusProcessForksThread) downloadBlocksComplete(fork *Fork, start uint64, hashes [][]byte) []*block_complete.BlockComplete {
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	blocks := make([]*block_complete.BlockComplete, len(hashes))
+
+	jobs := make(chan int, len(hashes))
+	for i := range hashes {
+		jobs <- i
+	}
+
+	remaining := int32(len(hashes))
+	done := make(chan struct{})
+
+	wg := &sync.WaitGroup{}
+	for _, conn := range fork.getConns() {
+
+		conn := conn
+		wg.Add(1)
+		recovery.SafeGo(func() {
+			defer wg.Done()
+
+			failures := 0
+			for {
+				select {
+				case <-done:
+					return
+				case <-conn.Closed:
+					return
+				case i := <-jobs:
+
+					ctx, cancel := context.WithTimeout(context.Background(), config.BLOCKS_SYNC_PEER_TIMEOUT)
+					blkComplete, err := thread.downloadBlockComplete(ctx, conn, fork, start+uint64(i), hashes[i])
+					cancel()
+
+					if err != nil {
+						jobs <- i //reassign
+						if failures += 1; failures >= BLOCKS_SYNC_PEER_MAX_ERRORS {
+							return
+						}
+						continue
+					}
+
+					blocks[i] = blkComplete
+					if atomic.AddInt32(&remaining, -1) == 0 {
+						close(done)
+					}
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	for i, blkComplete := range blocks {
+		if blkComplete == nil {
+			return blocks[:i]
+		}
+	}
+	return blocks
+}
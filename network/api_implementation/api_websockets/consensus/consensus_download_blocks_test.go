@@ -0,0 +1,116 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/websocks/connection"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// createTestBlocks creates linked blocks without transactions
+func createTestBlocks(t *testing.T, start uint64, count int) []*block.Block {
+
+	blocks := make([]*block.Block, count)
+	for i := range blocks {
+		var prev *block.Block
+		if i > 0 {
+			prev = blocks[i-1]
+		}
+		blk := createTestHeader(t, start+uint64(i), prev)
+		blk.MerkleHash = cryptography.SHA3([]byte{})
+		blk.Bloom = nil
+		assert.Nil(t, blk.BloomNow())
+		blocks[i] = blk
+	}
+	return blocks
+}
+
+// createTestBlocksPeer serves the blocks. fail decides if the request of the block fails
+func createTestBlocksPeer(t *testing.T, blocks []*block.Block, delay time.Duration, requests *atomic.Int32, fail func(index int) bool) *connection.AdvancedConnection {
+	return createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block": testRoute(func(args *api_common.APIBlockRequest) (any, error) {
+			requests.Add(1)
+			time.Sleep(delay)
+			for i, blk := range blocks {
+				if bytes.Equal(blk.Bloom.Hash, args.Hash) {
+					if fail(i) {
+						return nil, errors.New("Block is not available")
+					}
+					return &api_common.APIBlockReply{nil, blk.SerializeManualToBytes(), nil}, nil
+				}
+			}
+			return nil, errors.New("Block was not found")
+		}),
+	})
+}
+
+func TestDownloadBlocksComplete(t *testing.T) {
+
+	const start = 10
+	blocks := createTestBlocks(t, start, 6)
+
+	hashes := make([][]byte, len(blocks))
+	for i, blk := range blocks {
+		hashes[i] = blk.Bloom.Hash
+	}
+
+	thread := &ConsensusProcessForksThread{}
+
+	checkBlocks := func(downloaded int, fork *Fork) {
+		result := thread.downloadBlocksComplete(fork, start, hashes)
+		if assert.Equal(t, downloaded, len(result)) {
+			for i := range result {
+				assert.Equal(t, blocks[i].Bloom.Hash, result[i].Block.Bloom.Hash)
+				assert.Equal(t, uint64(start+i), result[i].Block.Height)
+			}
+		}
+	}
+
+	//the blocks of a failing peer are reassigned to the other peer. The failing peer stops after BLOCKS_SYNC_PEER_MAX_ERRORS
+	goodRequests, badRequests := &atomic.Int32{}, &atomic.Int32{}
+	fork := &Fork{}
+	fork.AddConn(createTestBlocksPeer(t, blocks, 50*time.Millisecond, goodRequests, func(int) bool { return false }), false)
+	fork.AddConn(createTestBlocksPeer(t, blocks, 0, badRequests, func(int) bool { return true }), false)
+
+	checkBlocks(len(blocks), fork)
+	assert.Equal(t, int32(BLOCKS_SYNC_PEER_MAX_ERRORS), badRequests.Load())
+	assert.Equal(t, int32(len(blocks)), goodRequests.Load())
+
+	//a block which failed once is retried
+	requests, failed := &atomic.Int32{}, &atomic.Bool{}
+	fork = &Fork{}
+	fork.AddConn(createTestBlocksPeer(t, blocks, 0, requests, func(index int) bool {
+		return index == 2 && failed.CompareAndSwap(false, true)
+	}), false)
+
+	checkBlocks(len(blocks), fork)
+	assert.Equal(t, int32(len(blocks)+1), requests.Load())
+
+	//the blocks after the first missing block are not returned, even if they were downloaded
+	requests = &atomic.Int32{}
+	fork = &Fork{}
+	fork.AddConn(createTestBlocksPeer(t, blocks, 0, requests, func(index int) bool { return index == 3 }), false)
+
+	checkBlocks(3, fork)
+	assert.Equal(t, int32(len(blocks)+1), requests.Load())
+
+	//all the peers fail
+	requests2 := &atomic.Int32{}
+	requests = &atomic.Int32{}
+	fork = &Fork{}
+	fork.AddConn(createTestBlocksPeer(t, blocks, 0, requests, func(int) bool { return true }), false)
+	fork.AddConn(createTestBlocksPeer(t, blocks, 0, requests2, func(int) bool { return true }), false)
+
+	checkBlocks(0, fork)
+	assert.Equal(t, int32(BLOCKS_SYNC_PEER_MAX_ERRORS), requests.Load())
+	assert.Equal(t, int32(BLOCKS_SYNC_PEER_MAX_ERRORS), requests2.Load())
+
+	//no peers
+	checkBlocks(0, &Fork{})
+}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/blocks/block"
@@ -10,7 +11,6 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/config/globals"
 	"pandora-pay/cryptography"
-	"pandora-pay/gui"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/mempool"
 	"pandora-pay/network/api_code/api_code_types"
@@ -25,6 +25,46 @@ type ConsensusProcessForksThread struct {
 	forks *Forks
 }
 
+// downloadMissingTxs downloads the transactions which are nil
+func downloadMissingTxs(ctx context.Context, conn *connection.AdvancedConnection, blockHash []byte, txs []*transaction.Transaction) error {
+
+	missingTxs := make([]int, 0)
+	for i, tx := range txs {
+		if tx == nil {
+			missingTxs = append(missingTxs, i)
+		}
+	}
+
+	if len(missingTxs) == 0 {
+		return nil
+	}
+
+	blkCompleteMissingTxs, err := connection.SendJSONAwaitAnswer[APIBlockCompleteMissingTxsReply](conn, []byte("block-miss-txs"), &APIBlockCompleteMissingTxsRequest{blockHash, missingTxs}, ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(blkCompleteMissingTxs.Txs) != len(missingTxs) {
+		return errors.New("blkCompleteMissingTxs.Txs length is not matching")
+	}
+
+	for _, missingTx := range blkCompleteMissingTxs.Txs {
+		if missingTx == nil {
+			return errors.New("blkCompleteMissingTxs.Tx is null")
+		}
+	}
+
+	for i, missingTx := range missingTxs {
+		tx := &transaction.Transaction{}
+		if err = tx.Deserialize(advanced_buffers.NewBufferReader(blkCompleteMissingTxs.Txs[i])); err != nil {
+			return err
+		}
+		txs[missingTx] = tx
+	}
+
+	return nil
+}
+
 func (thread *ConsensusProcessForksThread) downloadBlockHash(conn *connection.AdvancedConnection, fork *Fork, height uint64) ([]byte, error) {
 	answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockHashReply](conn, []byte("block-hash"), &api_common.APIBlockHashRequest{height}, nil, 0)
 	if err != nil {
@@ -38,9 +78,10 @@ func (thread *ConsensusProcessForksThread) downloadBlockHash(conn *connection.Ad
 	return answer.Hash, nil
 }
 
-func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connection.AdvancedConnection, fork *Fork, height uint64) (*block_complete.BlockComplete, error) {
+// downloadBlockComplete downloads the block by height or, if the hash is known from the headers, by hash
+func (thread *ConsensusProcessForksThread) downloadBlockComplete(ctx context.Context, conn *connection.AdvancedConnection, fork *Fork, height uint64, hash []byte) (*block_complete.BlockComplete, error) {
 
-	blkWithTx, err := connection.SendJSONAwaitAnswer[api_common.APIBlockReply](conn, []byte("block"), &api_common.APIBlockRequest{height, nil, api_code_types.RETURN_SERIALIZED}, nil, 0)
+	blkWithTx, err := connection.SendJSONAwaitAnswer[api_common.APIBlockReply](conn, []byte("block"), &api_common.APIBlockRequest{height, hash, api_code_types.RETURN_SERIALIZED}, ctx, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -50,58 +91,22 @@ func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connectio
 		return nil, err
 	}
 
-	txsFound := 0
+	if hash != nil && !bytes.Equal(blkWithTx.Block.Bloom.Hash, hash) {
+		return nil, errors.New("Block is not matching the header")
+	}
+
 	txs := make([]*transaction.Transaction, len(blkWithTx.Txs))
 	for i := range txs {
 		if tx := mempool.Mempool.Txs.Get(string(blkWithTx.Txs[i])); tx != nil {
 			txs[i] = tx.Tx
-			txsFound++
-			continue
 		}
 	}
 
 	blkComplete := block_complete.CreateEmptyBlockComplete()
 	blkComplete.Block = blkWithTx.Block
 
-	missingTxsCount := 0
-	for _, tx := range txs {
-		if tx == nil {
-			missingTxsCount += 1
-		}
-	}
-
-	if missingTxsCount > 0 {
-		missingTxs := make([]int, missingTxsCount)
-		c := 0
-		for i, tx := range txs {
-			if tx == nil {
-				missingTxs[c] = i
-				c++
-			}
-		}
-
-		blkCompleteMissingTxs, err := connection.SendJSONAwaitAnswer[APIBlockCompleteMissingTxsReply](conn, []byte("block-miss-txs"), &APIBlockCompleteMissingTxsRequest{blkWithTx.Block.Bloom.Hash, missingTxs}, nil, 0)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(blkCompleteMissingTxs.Txs) != len(missingTxs) {
-			return nil, errors.New("blkCompleteMissingTxs.Txs length is not matching")
-		}
-
-		for _, missingTx := range blkCompleteMissingTxs.Txs {
-			if missingTx == nil {
-				return nil, errors.New("blkCompleteMissingTxs.Tx is null")
-			}
-		}
-
-		for i, missingTx := range missingTxs {
-			tx := &transaction.Transaction{}
-			if err = tx.Deserialize(advanced_buffers.NewBufferReader(blkCompleteMissingTxs.Txs[i])); err != nil {
-				return nil, err
-			}
-			txs[missingTx] = tx
-		}
+	if err = downloadMissingTxs(ctx, conn, blkWithTx.Block.Bloom.Hash, txs); err != nil {
+		return nil, err
 	}
 
 	blkComplete.Txs = txs
@@ -136,12 +141,23 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 		start = chainData.Height
 	}
 
+	minStart := uint64(0)
+	if chainData.Height > config.FORK_MAX_UNCLE_ALLOWED+chainData.ConsecutiveSelfForged {
+		minStart = chainData.Height - config.FORK_MAX_UNCLE_ALLOWED - chainData.ConsecutiveSelfForged
+	}
+
+	return thread.initializeFork(fork, start, minStart)
+}
+
+// initializeFork finds the start of the fork using block locators. Peers which don't know block locators are asked backwards block by block. The fork is locked before
+func (thread *ConsensusProcessForksThread) initializeFork(fork *Fork, start, minStart uint64) bool {
+
 	for {
 
 		if start == 0 { //let's exit
 			break
 		}
-		if (chainData.Height-start > config.FORK_MAX_UNCLE_ALLOWED+chainData.ConsecutiveSelfForged) && (chainData.Height-start > chainData.ConsecutiveSelfForged) {
+		if start < minStart {
 			return false
 		}
 
@@ -158,6 +174,21 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 			return false
 		}
 
+		forkStart, err := thread.findForkStart(conn, start, minStart)
+		if err == nil {
+			fork.Blocks.Empty()
+			start = forkStart
+			break
+		}
+		if err == errForkTooDeep {
+			return false
+		}
+		if err != advanced_connection_types.ErrUnknownRequest {
+			fork.errors += 1
+			continue
+		}
+
+		//the peer doesn't know block locators. The blocks are downloaded backwards until the chain is found
 		hash, err := thread.downloadBlockHash(conn, fork, start-1)
 		if err != nil {
 			fork.errors += 1
@@ -169,7 +200,7 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 			break
 		}
 
-		blkComplete, err := thread.downloadBlockComplete(conn, fork, start-1)
+		blkComplete, err := thread.downloadBlockComplete(nil, conn, fork, start-1, nil)
 		if err != nil {
 			fork.errors += 1
 			continue
@@ -193,11 +224,61 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 	return true
 }
 
+// downloadRemainingBlocks downloads the headers first and then the transactions of the next blocks in parallel.
+// The blocks are downloaded only after all the headers up to End are anchored to the fork hash
 func (thread *ConsensusProcessForksThread) downloadRemainingBlocks(fork *Fork) bool {
 
 	fork.Lock()
 	defer fork.Unlock()
 
+	for fork.Current+uint64(len(fork.headers)) < fork.End {
+
+		if fork.errors > 2 {
+			return false
+		}
+		if fork.errors < -10 {
+			fork.errors = -10
+		}
+
+		conn := fork.getRandomConn()
+		if conn == nil {
+			return false
+		}
+
+		if err := thread.downloadHeaders(conn, fork); err != nil {
+			if err == advanced_connection_types.ErrUnknownRequest { //the peer doesn't know headers
+				return thread.downloadRemainingBlocksSequentially(fork)
+			}
+			fork.errors += 1
+			continue
+		}
+	}
+
+	count := uint64(len(fork.headers))
+	if count > config.BLOCKS_SYNC_MAX_DOWNLOAD {
+		count = config.BLOCKS_SYNC_MAX_DOWNLOAD
+	}
+
+	blocks := thread.downloadBlocksComplete(fork, fork.Current, fork.headers[:count])
+	if uint64(len(blocks)) < count {
+		fork.errors += 1
+	}
+
+	for _, blkComplete := range blocks {
+		fork.Blocks.Push(blkComplete)
+	}
+	fork.headers = fork.headers[len(blocks):]
+	fork.Current += uint64(len(blocks))
+
+	return fork.Blocks.Length > 0
+}
+
+// downloadRemainingBlocksSequentially downloads the blocks one by one. Used for the peers which don't know headers. The fork is locked before
+func (thread *ConsensusProcessForksThread) downloadRemainingBlocksSequentially(fork *Fork) bool {
+
+	fork.headers = nil
+	fork.headersPrev = nil
+
 	for i := uint64(0); i < config.BLOCKS_SYNC_MAX_DOWNLOAD; i++ {
 
 		if fork.Current == fork.End {
@@ -216,7 +297,7 @@ func (thread *ConsensusProcessForksThread) downloadRemainingBlocks(fork *Fork) b
 			return false
 		}
 
-		blkComplete, err := thread.downloadBlockComplete(conn, fork, fork.Current)
+		blkComplete, err := thread.downloadBlockComplete(nil, conn, fork, fork.Current, nil)
 		if err != nil {
 			fork.errors += 1
 			continue
@@ -236,6 +317,8 @@ func (thread *ConsensusProcessForksThread) execute() {
 	for {
 
 		fork := thread.forks.getBestFork()
+		thread.forks.setBestForkEnd(fork)
+
 		if fork != nil {
 
 			willRemove := true
@@ -258,9 +341,7 @@ func (thread *ConsensusProcessForksThread) execute() {
 						}
 
 						if _, err := blockchain.Blockchain.AddBlocks(blocks, false, advanced_connection_types.UUID_ALL); err != nil {
-							if config.DEBUG {
-								gui.GUI.Error("Invalid Fork", err)
-							}
+							logger.Debug("Invalid fork", "end", fork.End, "err", err)
 						} else {
 							fork.Lock()
 							if fork.Current < fork.End {
@@ -276,7 +357,7 @@ func (thread *ConsensusProcessForksThread) execute() {
 
 			} else {
 				globals.MainEvents.BroadcastEvent("consensus/update", fork)
-				gui.GUI.Log("Status. AddBlocks fork - Simulating block")
+				logger.Debug("AddBlocks fork - Simulating block", "end", fork.End)
 
 				newChainData := &blockchain.BlockchainData{
 					Height:             fork.End,
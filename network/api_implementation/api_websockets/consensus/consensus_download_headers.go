@@ -0,0 +1,142 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/config"
+	"pandora-pay/config/config_block"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/websocks/connection"
+	"time"
+)
+
+// headersPrev is the block before the headers which are validated
+type headersPrev struct {
+	hash       []byte //nil when it is unknown
+	kernelHash []byte //nil when it is unknown
+	timestamp  uint64
+}
+
+// validateHeader checks everything that doesn't require the state. The kernel hash can't be checked against the target and the staking can't be verified, as they depend on the state.
+// Only AddBlocks verifies them, so the headers are trusted only after they are anchored to the fork hash
+func validateHeader(blk *block.Block, height uint64, prev *headersPrev) error {
+
+	if blk.Height != height {
+		return fmt.Errorf("Header height %d is not matching %d", blk.Height, height)
+	}
+	if blk.Version != config_block.GetBlockVersion(height) {
+		return errors.New("Header version is invalid")
+	}
+	if prev.hash != nil && !bytes.Equal(blk.PrevHash, prev.hash) {
+		return errors.New("Header PrevHash is not matching")
+	}
+	if prev.kernelHash != nil && !bytes.Equal(blk.PrevKernelHash, prev.kernelHash) {
+		return errors.New("Header PrevKernelHash is not matching")
+	}
+	if blk.Timestamp < prev.timestamp {
+		return errors.New("Header timestamp has to be greater than the last timestamp")
+	}
+	if blk.Timestamp > uint64(time.Now().UTC().Unix())+config.NETWORK_TIMESTAMP_DRIFT_MAX {
+		return errors.New("Header timestamp is too much into the future")
+	}
+	if blk.StakingAmount < config_stake.GetRequiredStake(height) {
+		return errors.New("Header staked amount is not enough")
+	}
+
+	prev.hash = blk.Bloom.Hash
+	prev.kernelHash = blk.Bloom.KernelHash
+	prev.timestamp = blk.Timestamp
+
+	return nil
+}
+
+// getHeadersPrev returns the block before the next headers. The fork is locked before
+func (thread *ConsensusProcessForksThread) getHeadersPrev(fork *Fork) (*headersPrev, error) {
+
+	if len(fork.headers) > 0 {
+		prev := *fork.headersPrev
+		return &prev, nil
+	}
+
+	if last, ok := fork.Blocks.GetTail(); ok {
+		return &headersPrev{last.Block.Bloom.Hash, last.Block.Bloom.KernelHash, last.Block.Timestamp}, nil
+	}
+
+	if fork.Current == 0 {
+		return &headersPrev{}, nil
+	}
+
+	chainData := blockchain.Blockchain.GetChainData()
+	if chainData.Height == fork.Current {
+		return &headersPrev{chainData.Hash, chainData.KernelHash, chainData.Timestamp}, nil
+	}
+
+	hash, err := blockchain.Blockchain.OpenLoadBlockHash(fork.Current - 1)
+	if err != nil {
+		return nil, err
+	}
+	return &headersPrev{hash, nil, 0}, nil
+}
+
+// downloadHeaders downloads the next headers of the fork. The headers have to be linked to the previous ones and the last header of the fork has to be the fork hash.
+// When the last header is not the fork hash, all the headers are dropped as it is unknown which of them are invalid. The fork is locked before
+func (thread *ConsensusProcessForksThread) downloadHeaders(conn *connection.AdvancedConnection, fork *Fork) error {
+
+	height := fork.Current + uint64(len(fork.headers))
+	if height >= fork.End {
+		return nil
+	}
+
+	count := fork.End - height
+	if count > api_common.API_BLOCK_HEADERS_MAX_LENGTH {
+		count = api_common.API_BLOCK_HEADERS_MAX_LENGTH
+	}
+
+	answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockHeadersReply](conn, []byte("block-headers"), &api_common.APIBlockHeadersRequest{height, count}, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(answer.Headers) == 0 || uint64(len(answer.Headers)) > count {
+		return errors.New("Headers length is invalid")
+	}
+
+	prev, err := thread.getHeadersPrev(fork)
+	if err != nil {
+		return err
+	}
+
+	headers := make([][]byte, len(answer.Headers))
+	for i, data := range answer.Headers {
+
+		blk := block.CreateEmptyBlock()
+		if err = blk.Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+			return err
+		}
+		if err = blk.BloomNow(); err != nil {
+			return err
+		}
+
+		if err = validateHeader(blk, height+uint64(i), prev); err != nil {
+			return err
+		}
+
+		headers[i] = blk.Bloom.Hash
+	}
+
+	if height+uint64(len(headers)) == fork.End && !bytes.Equal(prev.hash, fork.Hash) {
+		fork.headers = nil
+		fork.headersPrev = nil
+		return errors.New("Headers are not matching the fork hash")
+	}
+
+	fork.headers = append(fork.headers, headers...)
+	fork.headersPrev = prev
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package consensus
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config/config_block"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/linked_list"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/websocks/connection"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func createTestHeader(t *testing.T, height uint64, prev *block.Block) *block.Block {
+
+	blk := &block.Block{
+		BlockHeader:    &block.BlockHeader{Version: config_block.GetBlockVersion(height), Height: height},
+		MerkleHash:     cryptography.SHA3([]byte("MerkleHash")),
+		PrevHash:       cryptography.SHA3([]byte("PrevHash")),
+		PrevKernelHash: cryptography.SHA3([]byte("PrevKernelHash")),
+		Timestamp:      uint64(time.Now().Unix()),
+		StakingAmount:  config_stake.GetRequiredStake(height),
+		StakingNonce:   cryptography.SHA3([]byte("StakingNonce")),
+	}
+	if blk.Version >= config_block.BLOCK_VERSION_STATE_ROOT {
+		blk.StateRoot = cryptography.SHA3([]byte("StateRoot"))
+	}
+	if prev != nil {
+		blk.PrevHash = prev.Bloom.Hash
+		blk.PrevKernelHash = prev.Bloom.KernelHash
+	}
+
+	assert.Nil(t, blk.BloomNow())
+	return blk
+}
+
+func TestValidateHeader(t *testing.T) {
+
+	first := createTestHeader(t, 10, nil)
+	second := createTestHeader(t, 11, first)
+
+	prev := &headersPrev{}
+	assert.Nil(t, validateHeader(first, 10, prev))
+	assert.Equal(t, first.Bloom.Hash, prev.hash)
+	assert.Equal(t, first.Bloom.KernelHash, prev.kernelHash)
+	assert.Nil(t, validateHeader(second, 11, prev))
+
+	assert.NotNil(t, validateHeader(second, 12, &headersPrev{}))
+	assert.NotNil(t, validateHeader(second, 11, &headersPrev{hash: second.Bloom.Hash}))
+	assert.NotNil(t, validateHeader(second, 11, &headersPrev{kernelHash: second.Bloom.KernelHash}))
+	assert.NotNil(t, validateHeader(second, 11, &headersPrev{timestamp: second.Timestamp + 1}))
+
+	future := createTestHeader(t, 12, second)
+	future.Timestamp += 3600
+	assert.NotNil(t, validateHeader(future, 12, &headersPrev{}))
+}
+
+// createTestHeadersPeer serves at most two headers per request and counts the requested blocks
+func createTestHeadersPeer(t *testing.T, blocks []*block.Block, blockRequests *atomic.Int32) *connection.AdvancedConnection {
+	return createTestConn(t, map[string]func(conn *connection.AdvancedConnection, values []byte) (any, error){
+		"block-headers": testRoute(func(args *api_common.APIBlockHeadersRequest) (any, error) {
+			headers := [][]byte{}
+			for _, blk := range blocks {
+				if blk.Height >= args.Height && blk.Height < args.Height+args.Count && len(headers) < 2 {
+					headers = append(headers, blk.SerializeManualToBytes())
+				}
+			}
+			return &api_common.APIBlockHeadersReply{headers}, nil
+		}),
+		"block": testRoute(func(args *api_common.APIBlockRequest) (any, error) {
+			blockRequests.Add(1)
+			for _, blk := range blocks {
+				if blk.Height == args.Height {
+					return &api_common.APIBlockReply{nil, blk.SerializeManualToBytes(), nil}, nil
+				}
+			}
+			return nil, errors.New("Block was not found")
+		}),
+	})
+}
+
+func TestDownloadHeaders(t *testing.T) {
+
+	blocks := createTestBlocks(t, 10, 6)
+
+	thread := &ConsensusProcessForksThread{}
+
+	createFork := func(hash []byte) *Fork {
+		fork := &Fork{Current: 11, End: 16, Hash: hash, Blocks: linked_list.NewLinkedList[*block_complete.BlockComplete]()}
+		fork.Blocks.Push(&block_complete.BlockComplete{Block: blocks[0]})
+		return fork
+	}
+
+	blockRequests := &atomic.Int32{}
+	conn := createTestHeadersPeer(t, blocks, blockRequests)
+
+	//the headers are linked in batches until the fork hash
+	fork := createFork(blocks[5].Bloom.Hash)
+	for i, count := range []int{2, 4, 5} {
+		assert.NoError(t, thread.downloadHeaders(conn, fork), i)
+		assert.Equal(t, count, len(fork.headers))
+		assert.Equal(t, blocks[count].Bloom.Hash, fork.headersPrev.hash)
+	}
+	for i, hash := range fork.headers {
+		assert.Equal(t, blocks[i+1].Bloom.Hash, hash)
+	}
+
+	//the headers are not anchored to the fork hash, so all of them are dropped
+	fork = createFork(cryptography.SHA3([]byte("other fork")))
+	assert.NoError(t, thread.downloadHeaders(conn, fork))
+	assert.NoError(t, thread.downloadHeaders(conn, fork))
+	assert.Equal(t, 4, len(fork.headers))
+	assert.EqualError(t, thread.downloadHeaders(conn, fork), "Headers are not matching the fork hash")
+	assert.Nil(t, fork.headers)
+	assert.Nil(t, fork.headersPrev)
+
+	//no block is downloaded before the headers are anchored
+	fork = createFork(cryptography.SHA3([]byte("other fork")))
+	fork.AddConn(conn, false)
+	assert.False(t, thread.downloadRemainingBlocks(fork))
+	assert.Equal(t, int32(0), blockRequests.Load())
+	assert.Equal(t, uint64(11), fork.Current)
+
+	fork = createFork(blocks[5].Bloom.Hash)
+	fork.AddConn(conn, false)
+	assert.True(t, thread.downloadRemainingBlocks(fork))
+	assert.Equal(t, int32(5), blockRequests.Load())
+	assert.Equal(t, uint64(16), fork.Current)
+	assert.Equal(t, 6, fork.Blocks.Length)
+	assert.Empty(t, fork.headers)
+}
@@ -5,10 +5,20 @@ import (
 )
 
 type ChainUpdateNotification struct {
-	End                uint64   `json:"end" msgpack:"end"`
-	Hash               []byte   `json:"hash" msgpack:"hash"`
-	PrevHash           []byte   `json:"prevHash" msgpack:"prevHash"`
-	BigTotalDifficulty *big.Int `json:"bigTotalDifficulty" msgpack:"bigTotalDifficulty"`
+	Start              uint64        `json:"start" msgpack:"start"` //first block whose transactions can be served
+	End                uint64        `json:"end" msgpack:"end"`
+	Hash               []byte        `json:"hash" msgpack:"hash"`
+	PrevHash           []byte        `json:"prevHash" msgpack:"prevHash"`
+	BigTotalDifficulty *big.Int      `json:"bigTotalDifficulty" msgpack:"bigTotalDifficulty"`
+	CompactBlock       *CompactBlock `json:"compactBlock,omitempty" msgpack:"compactBlock,omitempty"` //only when the last block is relayed to full nodes
+}
+
+type CompactBlock struct {
+	Block            []byte   `json:"block" msgpack:"block"` //serialized block without the transactions
+	Salt             []byte   `json:"salt" msgpack:"salt"`
+	ShortIds         []uint64 `json:"shortIds" msgpack:"shortIds"`                 //salted short ids of all the transactions
+	PrefilledIndexes []int    `json:"prefilledIndexes" msgpack:"prefilledIndexes"` //transactions which are not in the mempool of the other nodes
+	PrefilledTxs     [][]byte `json:"prefilledTxs" msgpack:"prefilledTxs"`
 }
 
 type ChainLastUpdate struct {
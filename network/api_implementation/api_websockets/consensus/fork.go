@@ -18,6 +18,8 @@ type Fork struct {
 	Hash               []byte                                                 `json:"hash" msgpack:"hash"`
 	HashStr            string                                                 `json:"hashStr" msgpack:"hashStr"`
 	PrevHash           []byte                                                 `json:"prevHash" msgpack:"prevHash"`
+	headers            [][]byte                                               //hashes of the validated headers starting from Current
+	headersPrev        *headersPrev                                           //last validated header
 	conns              []*connection.AdvancedConnection
 	errors             int
 	sync.RWMutex       `json:"-" msgpack:"-"`
@@ -39,6 +41,20 @@ func (fork *Fork) getRandomConn() (conn *connection.AdvancedConnection) {
 	return nil
 }
 
+//is locked before
+func (fork *Fork) getConns() []*connection.AdvancedConnection {
+
+	conns := make([]*connection.AdvancedConnection, 0, len(fork.conns))
+	for _, conn := range fork.conns {
+		if !conn.IsClosed.IsSet() {
+			conns = append(conns, conn)
+		}
+	}
+	fork.conns = conns
+
+	return conns
+}
+
 func (fork *Fork) AddConn(conn *connection.AdvancedConnection, lock bool) {
 
 	if lock {
@@ -3,10 +3,12 @@ package consensus
 import (
 	"pandora-pay/config"
 	"pandora-pay/helpers/generics"
+	"sync/atomic"
 )
 
 type Forks struct {
-	hashes *generics.Map[string, *Fork]
+	hashes      *generics.Map[string, *Fork]
+	bestForkEnd *atomic.Uint64 //cached by the download thread, so reading it never waits for the fork lock
 }
 
 func (forks *Forks) getBestFork() (selectedFork *Fork) {
@@ -35,3 +37,25 @@ func (forks *Forks) addFork(fork *Fork) {
 func (forks *Forks) removeFork(fork *Fork) {
 	forks.hashes.Delete(fork.HashStr)
 }
+
+func (forks *Forks) getCount() (count int) {
+	forks.hashes.Range(func(key string, fork *Fork) bool {
+		count += 1
+		return true
+	})
+	return
+}
+
+// setBestForkEnd caches the height of the best fork. End never changes after the fork is created
+func (forks *Forks) setBestForkEnd(fork *Fork) {
+	if fork != nil {
+		forks.bestForkEnd.Store(fork.End)
+	} else {
+		forks.bestForkEnd.Store(0)
+	}
+}
+
+// getBestForkEnd returns the height of the best fork which is downloaded
+func (forks *Forks) getBestForkEnd() uint64 {
+	return forks.bestForkEnd.Load()
+}
@@ -0,0 +1,82 @@
+package api_keys
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Scope uint32
+
+const (
+	SCOPE_WALLET_READ  Scope = 1 << iota //read only wallet methods
+	SCOPE_WALLET_SPEND                   //creating transactions
+	SCOPE_DELEGATOR                      //delegator node methods
+	SCOPE_ADMIN                          //every method, including mnemonics, encryption and node management
+	SCOPE_METRICS                        //prometheus metrics
+)
+
+var scopesNames = []struct {
+	scope Scope
+	name  string
+}{
+	{SCOPE_WALLET_READ, "read"},
+	{SCOPE_WALLET_SPEND, "spend"},
+	{SCOPE_DELEGATOR, "delegator"},
+	{SCOPE_ADMIN, "admin"},
+	{SCOPE_METRICS, "metrics"},
+}
+
+// Has returns if the scope is granted. Admin grants every scope
+func (scopes Scope) Has(scope Scope) bool {
+	return scopes&SCOPE_ADMIN != 0 || scopes&scope == scope
+}
+
+func (scopes Scope) Strings() []string {
+	list := make([]string, 0)
+	for _, it := range scopesNames {
+		if scopes&it.scope != 0 {
+			list = append(list, it.name)
+		}
+	}
+	return list
+}
+
+func (scopes Scope) String() string {
+	return strings.Join(scopes.Strings(), ",")
+}
+
+// ParseScopes parses names like "read", "spend", "delegator", "admin" and "metrics"
+func ParseScopes(names []string) (scopes Scope, err error) {
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, it := range scopesNames {
+			if it.name == name {
+				scopes |= it.scope
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, errors.New("Invalid scope " + name)
+		}
+	}
+	if scopes == 0 {
+		return 0, errors.New("At least one scope is required")
+	}
+	return
+}
+
+type ApiKey struct {
+	Id        string    `json:"id" msgpack:"id"`
+	Name      string    `json:"name" msgpack:"name"`
+	Hash      []byte    `json:"hash,omitempty" msgpack:"hash"` //SHA3 of the secret. The secret itself is never stored
+	Scopes    Scope     `json:"scopes" msgpack:"scopes"`
+	Timestamp time.Time `json:"timestamp" msgpack:"timestamp"`
+}
+
+// Public returns a copy without the hash
+func (apiKey *ApiKey) Public() *ApiKey {
+	return &ApiKey{apiKey.Id, apiKey.Name, nil, apiKey.Scopes, apiKey.Timestamp}
+}
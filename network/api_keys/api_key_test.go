@@ -0,0 +1,36 @@
+package api_keys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseScopes(t *testing.T) {
+
+	scopes, err := ParseScopes([]string{"read", " Spend"})
+	assert.Nil(t, err)
+	assert.Equal(t, SCOPE_WALLET_READ|SCOPE_WALLET_SPEND, scopes)
+	assert.Equal(t, "read,spend", scopes.String())
+
+	scopes, err = ParseScopes([]string{"metrics"})
+	assert.Nil(t, err)
+	assert.Equal(t, SCOPE_METRICS, scopes)
+	assert.Equal(t, "metrics", scopes.String())
+
+	_, err = ParseScopes([]string{"read", "root"})
+	assert.NotNil(t, err)
+
+	_, err = ParseScopes(nil)
+	assert.NotNil(t, err)
+}
+
+func TestScopeHas(t *testing.T) {
+
+	assert.True(t, SCOPE_WALLET_READ.Has(SCOPE_WALLET_READ))
+	assert.False(t, SCOPE_WALLET_READ.Has(SCOPE_WALLET_SPEND))
+	assert.False(t, SCOPE_WALLET_READ.Has(SCOPE_ADMIN))
+	assert.False(t, Scope(0).Has(SCOPE_WALLET_READ))
+
+	assert.True(t, SCOPE_ADMIN.Has(SCOPE_WALLET_SPEND))
+	assert.True(t, SCOPE_ADMIN.Has(SCOPE_DELEGATOR))
+}
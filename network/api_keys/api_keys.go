@@ -0,0 +1,115 @@
+package api_keys
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ApiKeysType struct {
+	keysMap *generics.Map[string, *ApiKey]
+}
+
+// Create generates a new key. The returned key "<id>.<secret>" is shown only once as only its hash is stored
+func (this *ApiKeysType) Create(name string, scopes Scope) (string, *ApiKey, error) {
+
+	if scopes == 0 {
+		return "", nil, errors.New("At least one scope is required")
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(helpers.RandomBytes(32))
+
+	apiKey := &ApiKey{
+		Id:        hex.EncodeToString(helpers.RandomBytes(8)),
+		Name:      name,
+		Hash:      cryptography.SHA3([]byte(secret)),
+		Scopes:    scopes,
+		Timestamp: time.Now(),
+	}
+
+	this.keysMap.Store(apiKey.Id, apiKey)
+
+	if err := this.save(); err != nil {
+		gui.GUI.Error("Error saving api keys", err)
+	}
+
+	return apiKey.Id + "." + secret, apiKey.Public(), nil
+}
+
+func (this *ApiKeysType) Remove(id string) bool {
+
+	if _, deleted := this.keysMap.LoadAndDelete(id); !deleted {
+		return false
+	}
+
+	if err := this.save(); err != nil {
+		gui.GUI.Error("Error saving api keys", err)
+	}
+	return true
+}
+
+func (this *ApiKeysType) lookup(key string) *ApiKey {
+
+	id, secret, found := strings.Cut(key, ".")
+	if !found {
+		return nil
+	}
+
+	apiKey, found := this.keysMap.Load(id)
+	if !found {
+		return nil
+	}
+
+	if subtle.ConstantTimeCompare(cryptography.SHA3([]byte(secret)), apiKey.Hash) != 1 {
+		return nil
+	}
+	return apiKey
+}
+
+// GetScopes returns the scopes of the key. An invalid key has no scope
+func (this *ApiKeysType) GetScopes(key string) Scope {
+	if apiKey := this.lookup(key); apiKey != nil {
+		return apiKey.Scopes
+	}
+	return 0
+}
+
+// GetId returns the id of the key. An invalid key has an empty id
+func (this *ApiKeysType) GetId(key string) string {
+	if apiKey := this.lookup(key); apiKey != nil {
+		return apiKey.Id
+	}
+	return ""
+}
+
+// GetList returns the keys without their hashes
+func (this *ApiKeysType) GetList() []*ApiKey {
+
+	list := make([]*ApiKey, 0)
+	this.keysMap.Range(func(id string, apiKey *ApiKey) bool {
+		list = append(list, apiKey.Public())
+		return true
+	})
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
+	})
+
+	return list
+}
+
+var ApiKeys *ApiKeysType
+
+func init() {
+	ApiKeys = &ApiKeysType{
+		keysMap: &generics.Map[string, *ApiKey]{},
+	}
+}
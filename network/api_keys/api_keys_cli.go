@@ -0,0 +1,58 @@
+package api_keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pandora-pay/gui"
+	"strings"
+	"time"
+)
+
+func (this *ApiKeysType) InitCLI() {
+
+	cliListApiKeys := func(cmd string, ctx context.Context) (err error) {
+
+		list := this.GetList()
+
+		gui.GUI.OutputWrite(fmt.Sprintf("API Keys: %d", len(list)))
+		for _, apiKey := range list {
+			gui.GUI.OutputWrite(fmt.Sprintf("%s %s %s %s", apiKey.Id, apiKey.Timestamp.UTC().Format(time.RFC822), apiKey.Scopes, apiKey.Name))
+		}
+
+		return
+	}
+
+	cliCreateApiKey := func(cmd string, ctx context.Context) (err error) {
+
+		name := gui.GUI.OutputReadString("Name of the API Key")
+		scopes, err := ParseScopes(strings.Split(gui.GUI.OutputReadString("Scopes separated by comma: read, spend, delegator, admin, metrics"), ","))
+		if err != nil {
+			return
+		}
+
+		key, apiKey, err := this.Create(name, scopes)
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("API Key created", apiKey.Id, apiKey.Scopes)
+		gui.GUI.OutputWrite("Key: " + key)
+		gui.GUI.OutputWrite("Save the key now. It can't be shown again")
+		return
+	}
+
+	cliRemoveApiKey := func(cmd string, ctx context.Context) (err error) {
+
+		if !this.Remove(gui.GUI.OutputReadString("API Key Id")) {
+			return errors.New("API Key was not found")
+		}
+
+		gui.GUI.OutputWrite("API Key removed")
+		return
+	}
+
+	gui.GUI.CommandDefineCallback("List API Keys", cliListApiKeys, true)
+	gui.GUI.CommandDefineCallback("Create API Key", cliCreateApiKey, true)
+	gui.GUI.CommandDefineCallback("Remove API Key", cliRemoveApiKey, true)
+}
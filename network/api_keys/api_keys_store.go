@@ -0,0 +1,48 @@
+package api_keys
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+func (this *ApiKeysType) save() error {
+
+	list := make([]*ApiKey, 0)
+	this.keysMap.Range(func(id string, apiKey *ApiKey) bool {
+		list = append(list, apiKey)
+		return true
+	})
+
+	marshal, err := msgpack.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("apiKeys", marshal)
+		return nil
+	})
+}
+
+// Load restores the keys stored on the disk
+func (this *ApiKeysType) Load() error {
+
+	var list []*ApiKey
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("apiKeys")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &list)
+	}); err != nil {
+		return err
+	}
+
+	for _, apiKey := range list {
+		this.keysMap.Store(apiKey.Id, apiKey)
+	}
+
+	return nil
+}
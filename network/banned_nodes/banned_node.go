@@ -5,8 +5,8 @@ import (
 )
 
 type BannedNode struct {
-	URL        string
-	Timestamp  time.Time
-	Expiration time.Time
-	Message    string
+	URL        string    `json:"url" msgpack:"url"`
+	Timestamp  time.Time `json:"timestamp" msgpack:"timestamp"`
+	Expiration time.Time `json:"expiration" msgpack:"expiration"`
+	Message    string    `json:"message" msgpack:"message"`
 }
@@ -2,6 +2,7 @@ package banned_nodes
 
 import (
 	"net/url"
+	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
 	"time"
 )
@@ -10,9 +11,10 @@ type BannedNodesType struct {
 	bannedMap *generics.Map[string, *BannedNode]
 }
 
+// IsBanned only reads the map. The expired bans are removed by RemoveExpired
 func (this *BannedNodesType) IsBanned(urlStr string) bool {
-	if _, found := this.bannedMap.Load(urlStr); found {
-		return true
+	if bannedNode, found := this.bannedMap.Load(urlStr); found {
+		return time.Now().Before(bannedNode.Expiration)
 	}
 	return false
 }
@@ -30,6 +32,58 @@ func (this *BannedNodesType) Ban(urlStr, message string, duration time.Duration)
 		Timestamp:  time,
 		Expiration: time.Add(duration),
 	})
+
+	if err := this.save(); err != nil {
+		gui.GUI.Error("Error saving banned nodes", err)
+	}
+}
+
+func (this *BannedNodesType) Unban(urlStr string) bool {
+
+	if _, deleted := this.bannedMap.LoadAndDelete(urlStr); !deleted {
+		return false
+	}
+
+	if err := this.save(); err != nil {
+		gui.GUI.Error("Error saving banned nodes", err)
+	}
+	return true
+}
+
+// RemoveExpired deletes the expired bans and stores the remaining ones
+func (this *BannedNodesType) RemoveExpired() error {
+
+	now := time.Now()
+
+	removed := false
+	this.bannedMap.Range(func(key string, bannedNode *BannedNode) bool {
+		if !now.Before(bannedNode.Expiration) {
+			this.bannedMap.Delete(key)
+			removed = true
+		}
+		return true
+	})
+
+	if !removed {
+		return nil
+	}
+	return this.save()
+}
+
+// GetList returns the bans that didn't expire
+func (this *BannedNodesType) GetList() []*BannedNode {
+
+	now := time.Now()
+
+	list := make([]*BannedNode, 0)
+	this.bannedMap.Range(func(key string, bannedNode *BannedNode) bool {
+		if now.Before(bannedNode.Expiration) {
+			list = append(list, bannedNode)
+		}
+		return true
+	})
+
+	return list
 }
 
 var BannedNodes *BannedNodesType
@@ -0,0 +1,51 @@
+package banned_nodes
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"time"
+)
+
+func (this *BannedNodesType) save() error {
+
+	//the store is not opened yet
+	if store.StoreSettings == nil {
+		return nil
+	}
+
+	marshal, err := msgpack.Marshal(this.GetList())
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("bannedNodes", marshal)
+		return nil
+	})
+}
+
+// Load restores the bans stored on the disk. The expired ones are skipped
+func (this *BannedNodesType) Load() error {
+
+	var list []*BannedNode
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("bannedNodes")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &list)
+	}); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, bannedNode := range list {
+		if now.Before(bannedNode.Expiration) {
+			this.bannedMap.LoadOrStore(bannedNode.URL, bannedNode)
+		}
+	}
+
+	return this.save()
+}
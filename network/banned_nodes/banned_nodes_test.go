@@ -0,0 +1,72 @@
+package banned_nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+	"time"
+)
+
+func getStoredBans(t *testing.T) (list []*BannedNode) {
+	assert.NoError(t, store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return msgpack.Unmarshal(reader.Get("bannedNodes"), &list)
+	}))
+	return
+}
+
+func TestBannedNodes_Persistence(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.NoError(t, err)
+	store.StoreSettings = &store.Store{"settings", true, db}
+
+	bannedNodes := &BannedNodesType{&generics.Map[string, *BannedNode]{}}
+	bannedNodes.Ban("ws://node1:5230/ws", "spam", time.Hour)
+	bannedNodes.Ban("ws://node2:5230/ws", "invalid blocks", 50*time.Millisecond)
+	bannedNodes.Ban("ws://node3:5230/ws", "unbanned", time.Hour)
+	assert.True(t, bannedNodes.Unban("ws://node3:5230/ws"))
+	assert.False(t, bannedNodes.Unban("ws://node3:5230/ws"))
+
+	assert.Equal(t, 2, len(getStoredBans(t)))
+
+	//restarting
+	restored := &BannedNodesType{&generics.Map[string, *BannedNode]{}}
+	assert.NoError(t, restored.Load())
+	assert.True(t, restored.IsBanned("ws://node1:5230/ws"))
+	assert.True(t, restored.IsBanned("ws://node2:5230/ws"))
+	assert.False(t, restored.IsBanned("ws://node3:5230/ws"))
+
+	banned, _ := restored.bannedMap.Load("ws://node1:5230/ws")
+	assert.Equal(t, "spam", banned.Message)
+
+	time.Sleep(100 * time.Millisecond)
+
+	//an expired ban is neither removed nor stored by IsBanned
+	assert.False(t, restored.IsBanned("ws://node2:5230/ws"))
+	_, found := restored.bannedMap.Load("ws://node2:5230/ws")
+	assert.True(t, found)
+	assert.Equal(t, 2, len(getStoredBans(t)))
+
+	assert.NoError(t, restored.RemoveExpired())
+	_, found = restored.bannedMap.Load("ws://node2:5230/ws")
+	assert.False(t, found)
+
+	list := getStoredBans(t)
+	if assert.Equal(t, 1, len(list)) {
+		assert.Equal(t, "ws://node1:5230/ws", list[0].URL)
+	}
+
+	//the expired bans are not restored
+	bannedNodes.Ban("ws://node4:5230/ws", "expired", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	restored = &BannedNodesType{&generics.Map[string, *BannedNode]{}}
+	assert.NoError(t, restored.Load())
+	assert.Equal(t, 1, len(restored.GetList()))
+	_, found = restored.bannedMap.Load("ws://node4:5230/ws")
+	assert.False(t, found)
+}
@@ -38,6 +38,11 @@ func (this *KnownNodesType) GetList() []*known_node.KnownNodeScored {
 	return knownList
 }
 
+func (this *KnownNodesType) GetKnownNode(url string) *known_node.KnownNodeScored {
+	knownNode, _ := this.knownMap.Load(url)
+	return knownNode
+}
+
 func (this *KnownNodesType) GetRandomKnownNode() *known_node.KnownNodeScored {
 	this.knownListMutex.RLock()
 	defer this.knownListMutex.RUnlock()
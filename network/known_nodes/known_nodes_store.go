@@ -0,0 +1,67 @@
+package known_nodes
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type knownNodeStored struct {
+	URL   string `json:"url" msgpack:"url"`
+	Score int32  `json:"score" msgpack:"score"`
+}
+
+// Save stores the known nodes which are not seeds together with their scores
+func (this *KnownNodesType) Save() error {
+
+	list := this.GetList()
+
+	stored := make([]*knownNodeStored, 0, len(list))
+	for _, knownNode := range list {
+		if !knownNode.IsSeed {
+			stored = append(stored, &knownNodeStored{knownNode.URL, knownNode.GetScore()})
+		}
+	}
+
+	marshal, err := msgpack.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("knownNodes", marshal)
+		return nil
+	})
+}
+
+// Load adds the known nodes stored on the disk
+func (this *KnownNodesType) Load() error {
+
+	var stored []*knownNodeStored
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("knownNodes")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &stored)
+	}); err != nil {
+		return err
+	}
+
+	for _, it := range stored {
+
+		knownNode, err := this.AddKnownNode(it.URL, false)
+		if err != nil {
+			continue
+		}
+
+		if it.Score > 0 {
+			this.IncreaseKnownNodeScore(knownNode, it.Score, true)
+		} else if it.Score < 0 {
+			this.DecreaseKnownNodeScore(knownNode, it.Score, true)
+		}
+	}
+
+	return nil
+}
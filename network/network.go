@@ -3,16 +3,23 @@ package network
 import (
 	"context"
 	"pandora-pay/config"
+	"pandora-pay/gui"
 	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/api_keys"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/connected_nodes"
 	"pandora-pay/network/known_nodes"
 	"pandora-pay/network/server/node_tcp"
+	"pandora-pay/network/webhooks"
 	"pandora-pay/network/websocks"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"time"
 )
 
+var logger = gui.NewSubsystemLogger("network")
+
 type networkType struct {
+	closedCn chan struct{}
 }
 
 var Network *networkType
@@ -88,8 +95,21 @@ func SendJSONAwaitAnswer[T any](name []byte, data any, ctxParent context.Context
 	}
 }
 
+// Close stops saving the network nodes periodically and saves them one last time
+func (this *networkType) Close() {
+	close(this.closedCn)
+	this.saveNetworkNodes()
+}
+
 func NewNetwork() error {
 
+	if err := banned_nodes.BannedNodes.Load(); err != nil {
+		return err
+	}
+	if err := api_keys.ApiKeys.Load(); err != nil {
+		return err
+	}
+
 	list := make([]string, len(config.NETWORK_SELECTED_SEEDS))
 	for i, seed := range config.NETWORK_SELECTED_SEEDS {
 		list[i] = seed.Url
@@ -97,15 +117,29 @@ func NewNetwork() error {
 	if err := known_nodes.KnownNodes.Reset(list, true); err != nil {
 		return err
 	}
+	if err := known_nodes.KnownNodes.Load(); err != nil {
+		return err
+	}
 
 	if err := node_tcp.NewTcpServer(); err != nil {
 		return err
 	}
 
-	Network = &networkType{}
+	if err := webhooks.Webhooks.Load(); err != nil {
+		return err
+	}
+	webhooks.Webhooks.Start()
+
+	Network = &networkType{
+		make(chan struct{}),
+	}
 
 	Network.continuouslyConnectingNewPeers()
 	Network.continuouslyDownloadNetworkNodes()
+	Network.continuouslySavingNetworkNodes()
+	Network.initCLI()
+	api_keys.ApiKeys.InitCLI()
+	Network.initMetrics()
 
 	return nil
 }
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"pandora-pay/gui"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/known_nodes"
+	"time"
+)
+
+func (this *networkType) initCLI() {
+
+	cliListKnownNodes := func(cmd string, ctx context.Context) (err error) {
+
+		list := known_nodes.KnownNodes.GetList()
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Known Nodes: %d", len(list)))
+		for _, knownNode := range list {
+			gui.GUI.OutputWrite(fmt.Sprintf("%6d %5t %s", knownNode.GetScore(), knownNode.IsSeed, knownNode.URL))
+		}
+
+		return
+	}
+
+	cliListBannedNodes := func(cmd string, ctx context.Context) (err error) {
+
+		list := banned_nodes.BannedNodes.GetList()
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Banned Nodes: %d", len(list)))
+		for _, bannedNode := range list {
+			gui.GUI.OutputWrite(fmt.Sprintf("%s %s %s", bannedNode.Expiration.UTC().Format(time.RFC822), bannedNode.URL, bannedNode.Message))
+		}
+
+		return
+	}
+
+	cliBanNode := func(cmd string, ctx context.Context) (err error) {
+
+		urlStr := gui.GUI.OutputReadString("Node URL")
+		if _, err = url.Parse(urlStr); err != nil {
+			return
+		}
+		if urlStr == "" {
+			return errors.New("URL is empty")
+		}
+
+		message := gui.GUI.OutputReadString("Message. Leave empty for none")
+		duration := gui.GUI.OutputReadUint64("Duration in seconds", false, 0, func(value uint64) bool {
+			return value > 0
+		})
+
+		banned_nodes.BannedNodes.Ban(urlStr, message, time.Duration(duration)*time.Second)
+		if knownNode := known_nodes.KnownNodes.GetKnownNode(urlStr); knownNode != nil {
+			known_nodes.KnownNodes.RemoveKnownNode(knownNode)
+		}
+
+		gui.GUI.OutputWrite("Node banned")
+		return
+	}
+
+	cliUnbanNode := func(cmd string, ctx context.Context) (err error) {
+
+		urlStr := gui.GUI.OutputReadString("Node URL")
+		if !banned_nodes.BannedNodes.Unban(urlStr) {
+			return errors.New("Node was not banned")
+		}
+
+		gui.GUI.OutputWrite("Node unbanned")
+		return
+	}
+
+	gui.GUI.CommandDefineCallback("List Known Nodes", cliListKnownNodes, true)
+	gui.GUI.CommandDefineCallback("List Banned Nodes", cliListBannedNodes, true)
+	gui.GUI.CommandDefineCallback("Ban Node", cliBanNode, true)
+	gui.GUI.CommandDefineCallback("Unban Node", cliUnbanNode, true)
+}
@@ -4,6 +4,7 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/config/arguments"
 	"pandora-pay/network/network_config/network_config_auth"
+	"pandora-pay/network/network_config/network_config_rate_limit"
 	"strconv"
 	"time"
 )
@@ -17,6 +18,7 @@ var (
 	NETWORK_KNOWN_NODES_LIST_RETURN            = 100
 	NETWORK_ENABLE_SUBSCRIPTIONS               = false
 	NETWORK_CONNECTIONS_READY_THRESHOLD        = int64(1)
+	SSE_MAX_STREAMS                            = int64(100)
 	STATIC_FILES                               = map[string]string{}
 )
 
@@ -29,6 +31,14 @@ const (
 	WEBSOCKETS_INCREASE_KNOWN_NODE_SCORE_INTERVAL = 1 * time.Minute
 	WEBSOCKETS_CONCURRENT_NEW_CONENCTIONS         = 5
 	WEBSOCKETS_TIMEOUT                            = 15 * time.Second //seconds
+	NETWORK_KNOWN_NODES_SAVE_INTERVAL             = 1 * time.Minute
+	WEBHOOKS_QUEUE_MAX                            = 100000
+	WEBHOOKS_MAX_ATTEMPTS                         = 12
+	WEBHOOKS_RETRY_INITIAL                        = 5 * time.Second
+	WEBHOOKS_RETRY_MAX                            = 1 * time.Hour
+	WEBHOOKS_DELIVERY_TIMEOUT                     = 10 * time.Second
+	WEBHOOKS_CONCURRENT_DELIVERIES                = 5
+	SSE_STREAM_BUFFER                             = 100
 )
 
 func InitConfig() (err error) {
@@ -45,6 +55,12 @@ func InitConfig() (err error) {
 		}
 	}
 
+	if arguments.Arguments["--sse-max-streams"] != nil {
+		if SSE_MAX_STREAMS, err = strconv.ParseInt(arguments.Arguments["--sse-max-streams"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
 	if arguments.Arguments["--tcp-connections-ready=threshold"] != nil {
 		if NETWORK_CONNECTIONS_READY_THRESHOLD, err = strconv.ParseInt(arguments.Arguments["--tcp-connections-ready"].(string), 10, 64); err != nil {
 			return
@@ -71,6 +87,10 @@ func InitConfig() (err error) {
 		return
 	}
 
+	if err = network_config_rate_limit.InitConfig(); err != nil {
+		return
+	}
+
 	NETWORK_ENABLE_SUBSCRIPTIONS = config.NODE_PROVIDE_EXTENDED_INFO_APP
 
 	return
@@ -3,11 +3,14 @@ package network_config_auth
 import (
 	"encoding/json"
 	"pandora-pay/config/arguments"
+	"pandora-pay/network/api_keys"
 )
 
 type ConfigAuth struct {
-	Username string `json:"user" msgpack:"user"`
-	Password string `json:"pass"  msgpack:"pass"`
+	Username   string         `json:"user" msgpack:"user"`
+	Password   string         `json:"pass"  msgpack:"pass"`
+	ScopesList []string       `json:"scopes" msgpack:"scopes"` //when missing the user is an admin
+	Scopes     api_keys.Scope `json:"-" msgpack:"-"`
 }
 
 var (
@@ -15,6 +18,20 @@ var (
 	CONFIG_AUTH_USERS_MAP  map[string]*ConfigAuth
 )
 
+// GetScopes returns the scopes granted by an API key or by the user and password of --auth-users
+func GetScopes(username, password, apiKey string) api_keys.Scope {
+
+	if apiKey != "" {
+		return api_keys.ApiKeys.GetScopes(apiKey)
+	}
+
+	user := CONFIG_AUTH_USERS_MAP[username]
+	if user == nil || user.Password != password {
+		return 0
+	}
+	return user.Scopes
+}
+
 func InitConfig() (err error) {
 
 	if str := arguments.Arguments["--auth-users"]; str != nil {
@@ -25,6 +42,12 @@ func InitConfig() (err error) {
 
 	CONFIG_AUTH_USERS_MAP = map[string]*ConfigAuth{}
 	for _, auth := range CONFIG_AUTH_USERS_LIST {
+		auth.Scopes = api_keys.SCOPE_ADMIN
+		if len(auth.ScopesList) > 0 {
+			if auth.Scopes, err = api_keys.ParseScopes(auth.ScopesList); err != nil {
+				return
+			}
+		}
 		CONFIG_AUTH_USERS_MAP[auth.Username] = auth
 	}
 
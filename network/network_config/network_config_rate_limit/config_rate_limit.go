@@ -0,0 +1,119 @@
+package network_config_rate_limit
+
+import (
+	"encoding/json"
+	"errors"
+	"pandora-pay/config/arguments"
+	"strconv"
+	"strings"
+)
+
+var (
+	RATE_LIMIT_IP            = float64(0) //tokens per second for each IP. 0 disables it
+	RATE_LIMIT_API_KEY       = float64(0) //tokens per second for each API key. 0 disables it
+	RATE_LIMIT_BURST         = float64(50)
+	RATE_LIMIT_SYNC          = float64(0) //tokens per second for each IP on the sync routes
+	RATE_LIMIT_SYNC_BURST    = float64(0)
+	RATE_LIMIT_SYNC_FACTOR   = float64(10)       //the sync routes allow by default 10 times more tokens than the other methods
+	RATE_LIMIT_ALLOWLIST     = map[string]bool{} //IPs which are never limited
+	RATE_LIMIT_DEFAULT_COST  = float64(1)
+	RATE_LIMIT_COSTS         map[string]float64
+	RATE_LIMIT_DEFAULT_COSTS = map[string]float64{
+		"ping":                   0,
+		"handshake":              0,
+		"login":                  0,
+		"logout":                 0,
+		"block":                  2,
+		"block-complete":         5,
+		"block-headers":          5,
+		"block-info":             2,
+		"accounts/keys-by-index": 10,
+		"accounts/by-keys":       5,
+		"account/txs":            3,
+		"mempool":                5,
+		"mempool/new-tx":         5,
+		"tx-preview":             2,
+		"network/nodes":          2,
+	}
+	//routes used by the nodes to sync. They use a separate bucket so a node syncing doesn't consume the tokens of the other methods
+	RATE_LIMIT_SYNC_ROUTES = map[string]bool{
+		"block":             true,
+		"block-complete":    true,
+		"block-hash":        true,
+		"block-headers":     true,
+		"block-locator":     true,
+		"block-miss-txs":    true,
+		"get-chain":         true,
+		"chain-update":      true,
+		"mempool":           true,
+		"mempool/new-tx-id": true,
+		"tx-raw":            true,
+	}
+)
+
+func parseRate(name string) (float64, error) {
+	value, err := strconv.ParseFloat(arguments.Arguments[name].(string), 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, errors.New(name + " can not be negative")
+	}
+	return value, nil
+}
+
+func InitConfig() (err error) {
+
+	if arguments.Arguments["--rate-limit"] != nil {
+		if RATE_LIMIT_IP, err = parseRate("--rate-limit"); err != nil {
+			return
+		}
+	}
+	if arguments.Arguments["--rate-limit-api-key"] != nil {
+		if RATE_LIMIT_API_KEY, err = parseRate("--rate-limit-api-key"); err != nil {
+			return
+		}
+	}
+	if arguments.Arguments["--rate-limit-burst"] != nil {
+		if RATE_LIMIT_BURST, err = parseRate("--rate-limit-burst"); err != nil {
+			return
+		}
+	}
+
+	RATE_LIMIT_SYNC = RATE_LIMIT_IP * RATE_LIMIT_SYNC_FACTOR
+	if arguments.Arguments["--rate-limit-sync"] != nil {
+		if RATE_LIMIT_SYNC, err = parseRate("--rate-limit-sync"); err != nil {
+			return
+		}
+	}
+	RATE_LIMIT_SYNC_BURST = RATE_LIMIT_BURST * RATE_LIMIT_SYNC_FACTOR
+
+	RATE_LIMIT_ALLOWLIST = make(map[string]bool)
+	if str := arguments.Arguments["--rate-limit-allowlist"]; str != nil {
+		for _, ip := range strings.Split(str.(string), ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				RATE_LIMIT_ALLOWLIST[ip] = true
+			}
+		}
+	}
+
+	RATE_LIMIT_COSTS = make(map[string]float64)
+	for method, cost := range RATE_LIMIT_DEFAULT_COSTS {
+		RATE_LIMIT_COSTS[method] = cost
+	}
+
+	if str := arguments.Arguments["--rate-limit-costs"]; str != nil {
+		costs := make(map[string]float64)
+		if err = json.Unmarshal([]byte(str.(string)), &costs); err != nil {
+			return
+		}
+		for method, cost := range costs {
+			if cost < 0 {
+				return errors.New("Cost of " + method + " can not be negative")
+			}
+			RATE_LIMIT_COSTS[method] = cost
+		}
+	}
+
+	return
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"pandora-pay/helpers/metrics"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/known_nodes"
+	"pandora-pay/network/webhooks"
+	"pandora-pay/network/websocks"
+)
+
+func (this *networkType) initMetrics() {
+
+	metrics.NewGaugeFunc("pandora_peers_connected", "Number of connected peers", func() float64 {
+		return float64(len(websocks.Websockets.GetAllSockets()))
+	})
+
+	metrics.NewGaugeFunc("pandora_peers_clients", "Number of peers this node connected to", func() float64 {
+		return float64(websocks.Websockets.GetClients())
+	})
+
+	metrics.NewGaugeFunc("pandora_peers_servers", "Number of peers connected to this node", func() float64 {
+		return float64(websocks.Websockets.GetServerSockets())
+	})
+
+	metrics.NewGaugeFunc("pandora_peers_known", "Number of known peers", func() float64 {
+		return float64(len(known_nodes.KnownNodes.GetList()))
+	})
+
+	metrics.NewGaugeFunc("pandora_peers_banned", "Number of banned peers", func() float64 {
+		return float64(len(banned_nodes.BannedNodes.GetList()))
+	})
+
+	metrics.NewGaugeVecFunc("pandora_peer_score", "Score of the known peers", "url", func() map[string]float64 {
+		list := known_nodes.KnownNodes.GetList()
+		out := make(map[string]float64, len(list))
+		for _, knownNode := range list {
+			out[knownNode.URL] = float64(knownNode.GetScore())
+		}
+		return out
+	})
+
+	metrics.NewGaugeFunc("pandora_webhooks_pending_deliveries", "Number of webhooks events waiting to be delivered", func() float64 {
+		return float64(webhooks.Webhooks.GetPendingDeliveries())
+	})
+}
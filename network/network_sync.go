@@ -3,7 +3,6 @@ package network
 import (
 	"math/rand"
 	"pandora-pay/config"
-	"pandora-pay/gui"
 	"pandora-pay/helpers/recovery"
 	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/connected_nodes"
@@ -36,6 +35,30 @@ func (this *networkType) continuouslyDownloadNetworkNodes() {
 
 }
 
+func (this *networkType) saveNetworkNodes() {
+	if err := known_nodes.KnownNodes.Save(); err != nil {
+		logger.Error("Error saving known nodes", "err", err)
+	}
+	if err := banned_nodes.BannedNodes.RemoveExpired(); err != nil {
+		logger.Error("Error saving banned nodes", "err", err)
+	}
+}
+
+func (this *networkType) continuouslySavingNetworkNodes() {
+
+	recovery.SafeGo(func() {
+		for {
+			select {
+			case <-this.closedCn:
+				return
+			case <-time.After(network_config.NETWORK_KNOWN_NODES_SAVE_INTERVAL):
+			}
+			this.saveNetworkNodes()
+		}
+	})
+
+}
+
 func (this *networkType) continuouslyConnectingNewPeers() {
 
 	for i := 0; i < network_config.WEBSOCKETS_CONCURRENT_NEW_CONENCTIONS; i++ {
@@ -81,7 +104,7 @@ func (this *networkType) continuouslyConnectingNewPeers() {
 							}
 
 						} else {
-							gui.GUI.Log("connected to: " + knownNode.URL)
+							logger.Info("Connected", "url", knownNode.URL)
 						}
 					}
 				}
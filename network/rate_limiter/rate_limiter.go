@@ -0,0 +1,142 @@
+package rate_limiter
+
+import (
+	"math"
+	"net"
+	"pandora-pay/network/network_config/network_config_rate_limit"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// limiter is a token bucket for each client. The bucket is refilled with rate tokens per second up to burst
+type limiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+}
+
+// take returns how long the client has to wait when there are not enough tokens
+func (this *limiter) take(client string, cost float64, now time.Time) time.Duration {
+
+	b := this.buckets[client]
+	if b == nil {
+		b = &bucket{this.burst, now}
+		this.buckets[client] = b
+	} else {
+		b.tokens = math.Min(this.burst, b.tokens+now.Sub(b.updated).Seconds()*this.rate)
+		b.updated = now
+	}
+
+	if b.tokens >= cost {
+		b.tokens -= cost
+		return 0
+	}
+
+	return time.Duration((cost - b.tokens) / this.rate * float64(time.Second))
+}
+
+// removeFull deletes the buckets which are refilled as they are the same as new ones
+func (this *limiter) removeFull(now time.Time) {
+	for client, b := range this.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*this.rate >= this.burst {
+			delete(this.buckets, client)
+		}
+	}
+}
+
+type TooManyRequestsError struct {
+	RetryAfter time.Duration
+}
+
+func (err *TooManyRequestsError) Error() string {
+	return "Too many requests. Retry after " + strconv.FormatInt(int64(math.Ceil(err.RetryAfter.Seconds())), 10) + " seconds"
+}
+
+type RateLimiterType struct {
+	ip          *limiter
+	sync        *limiter //used by the IPs on the sync routes
+	apiKey      *limiter
+	lastCleanup time.Time
+	lock        sync.Mutex
+}
+
+func (this *RateLimiterType) getCost(method string) float64 {
+	if cost, ok := network_config_rate_limit.RATE_LIMIT_COSTS[method]; ok {
+		return cost
+	}
+	return network_config_rate_limit.RATE_LIMIT_DEFAULT_COST
+}
+
+func (this *RateLimiterType) allow(ip, apiKeyId, method string, now time.Time) error {
+
+	cost := this.getCost(method)
+	if cost == 0 || network_config_rate_limit.RATE_LIMIT_ALLOWLIST[ip] {
+		return nil
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if now.Sub(this.lastCleanup) > time.Minute {
+		this.ip.removeFull(now)
+		this.sync.removeFull(now)
+		this.apiKey.removeFull(now)
+		this.lastCleanup = now
+	}
+
+	var wait time.Duration
+	if apiKeyId != "" {
+		if this.apiKey.rate == 0 {
+			return nil
+		}
+		wait = this.apiKey.take(apiKeyId, cost, now)
+	} else if network_config_rate_limit.RATE_LIMIT_SYNC_ROUTES[method] {
+		if this.sync.rate == 0 {
+			return nil
+		}
+		wait = this.sync.take(ip, cost, now)
+	} else {
+		if this.ip.rate == 0 {
+			return nil
+		}
+		wait = this.ip.take(ip, cost, now)
+	}
+
+	if wait > 0 {
+		return &TooManyRequestsError{wait}
+	}
+	return nil
+}
+
+// Allow consumes the cost of the method from the bucket of the API key or, when there is no API key, from the bucket of the IP. The allowlisted IPs are never limited
+func (this *RateLimiterType) Allow(remoteAddr, apiKeyId, method string) error {
+	return this.allow(GetIP(remoteAddr), apiKeyId, method, time.Now())
+}
+
+// GetIP removes the port from the remote address
+func GetIP(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+var RateLimiter *RateLimiterType
+
+func NewRateLimiter() *RateLimiterType {
+	return &RateLimiterType{
+		ip:     &limiter{network_config_rate_limit.RATE_LIMIT_IP, network_config_rate_limit.RATE_LIMIT_BURST, make(map[string]*bucket)},
+		sync:   &limiter{network_config_rate_limit.RATE_LIMIT_SYNC, network_config_rate_limit.RATE_LIMIT_SYNC_BURST, make(map[string]*bucket)},
+		apiKey: &limiter{network_config_rate_limit.RATE_LIMIT_API_KEY, network_config_rate_limit.RATE_LIMIT_BURST, make(map[string]*bucket)},
+	}
+}
+
+func InitializeRateLimiter() {
+	RateLimiter = NewRateLimiter()
+}
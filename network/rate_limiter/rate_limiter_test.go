@@ -0,0 +1,80 @@
+package rate_limiter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/network/network_config/network_config_rate_limit"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+
+	rateLimiter := &RateLimiterType{
+		ip:     &limiter{2, 3, make(map[string]*bucket)},
+		sync:   &limiter{20, 30, make(map[string]*bucket)},
+		apiKey: &limiter{0, 3, make(map[string]*bucket)},
+	}
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, rateLimiter.allow("1.1.1.1", "", "account", now))
+	}
+
+	err := rateLimiter.allow("1.1.1.1", "", "account", now)
+	assert.NotNil(t, err)
+	assert.Equal(t, 500*time.Millisecond, err.(*TooManyRequestsError).RetryAfter)
+
+	//other clients have their own buckets
+	assert.Nil(t, rateLimiter.allow("2.2.2.2", "", "account", now))
+	//api keys are unlimited
+	assert.Nil(t, rateLimiter.allow("1.1.1.1", "key", "account", now))
+
+	assert.Nil(t, rateLimiter.allow("1.1.1.1", "", "account", now.Add(500*time.Millisecond)))
+	assert.NotNil(t, rateLimiter.allow("1.1.1.1", "", "account", now.Add(500*time.Millisecond)))
+
+	rateLimiter.ip.removeFull(now.Add(time.Hour))
+	assert.Empty(t, rateLimiter.ip.buckets)
+}
+
+func TestRateLimiter_Sync(t *testing.T) {
+
+	rateLimiter := &RateLimiterType{
+		ip:     &limiter{2, 3, make(map[string]*bucket)},
+		sync:   &limiter{20, 30, make(map[string]*bucket)},
+		apiKey: &limiter{0, 3, make(map[string]*bucket)},
+	}
+
+	oldAllowlist := network_config_rate_limit.RATE_LIMIT_ALLOWLIST
+	network_config_rate_limit.RATE_LIMIT_ALLOWLIST = map[string]bool{"3.3.3.3": true}
+	defer func() {
+		network_config_rate_limit.RATE_LIMIT_ALLOWLIST = oldAllowlist
+	}()
+
+	now := time.Now()
+
+	//a full node syncing uses only the sync bucket
+	for i := 0; i < 30; i++ {
+		assert.Nil(t, rateLimiter.allow("1.1.1.1", "", "block-headers", now))
+	}
+	err := rateLimiter.allow("1.1.1.1", "", "block-headers", now)
+	assert.NotNil(t, err)
+	assert.Equal(t, 50*time.Millisecond, err.(*TooManyRequestsError).RetryAfter)
+
+	//the other methods still have their tokens, but they are limited too
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, rateLimiter.allow("1.1.1.1", "", "account", now))
+	}
+	assert.NotNil(t, rateLimiter.allow("1.1.1.1", "", "account", now))
+
+	//the allowlisted IPs are never limited
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, rateLimiter.allow("3.3.3.3", "", "block-headers", now))
+		assert.Nil(t, rateLimiter.allow("3.3.3.3", "", "account", now))
+	}
+}
+
+func TestGetIP(t *testing.T) {
+	assert.Equal(t, "127.0.0.1", GetIP("127.0.0.1:5230"))
+	assert.Equal(t, "::1", GetIP("[::1]:5230"))
+	assert.Equal(t, "127.0.0.1", GetIP("127.0.0.1"))
+}
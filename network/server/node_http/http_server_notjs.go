@@ -8,14 +8,23 @@ import (
 	"errors"
 	"github.com/rs/cors"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"pandora-pay/helpers/metrics"
+	"pandora-pay/network/api_code/api_code_types"
 	"pandora-pay/network/api_implementation/api_common"
 	"pandora-pay/network/api_implementation/api_http"
 	"pandora-pay/network/api_implementation/api_websockets"
+	"pandora-pay/network/api_keys"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/rate_limiter"
 	"pandora-pay/network/server/node_http_rpc"
 	"pandora-pay/network/websocks"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type httpServerType struct {
@@ -24,10 +33,27 @@ type httpServerType struct {
 	ApiStore      *api_common.APIStore
 	GetMap        map[string]func(values url.Values) (any, error)
 	PostMap       map[string]func(values io.ReadCloser) (any, error)
+	sseStreams    *atomic.Int64
 }
 
 var HttpServer *httpServerType
 
+// limit replies 429 when the client used all its tokens
+func (this *httpServerType) limit(w http.ResponseWriter, req *http.Request) bool {
+
+	apiKeyId := ""
+	if apiKey := req.URL.Query().Get("apiKey"); apiKey != "" {
+		apiKeyId = api_keys.ApiKeys.GetId(apiKey)
+	}
+
+	if err := rate_limiter.RateLimiter.Allow(req.RemoteAddr, apiKeyId, strings.TrimPrefix(req.URL.Path, "/")); err != nil {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(err.(*rate_limiter.TooManyRequestsError).RetryAfter.Seconds())), 10))
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
 func (this *httpServerType) get(w http.ResponseWriter, req *http.Request) {
 
 	defer func() {
@@ -42,12 +68,18 @@ func (this *httpServerType) get(w http.ResponseWriter, req *http.Request) {
 	callback := this.GetMap[req.URL.Path]
 	if callback != nil {
 
+		if !this.limit(w, req) {
+			return
+		}
+
 		var args url.Values
 		if args, err = url.ParseQuery(req.URL.RawQuery); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		start := time.Now()
 		output, err = callback(args)
+		api_code_types.APIRequestsDurationMetric.Observe(time.Since(start).Seconds(), req.URL.Path, "http")
 	} else {
 		err = errors.New("Unknown request")
 	}
@@ -87,7 +119,13 @@ func (this *httpServerType) post(w http.ResponseWriter, req *http.Request) {
 
 	callback := this.PostMap[req.URL.Path]
 	if callback != nil {
+
+		if !this.limit(w, req) {
+			return
+		}
+		start := time.Now()
 		output, err = callback(req.Body)
+		api_code_types.APIRequestsDurationMetric.Observe(time.Since(start).Seconds(), req.URL.Path, "http")
 	} else {
 		err = errors.New("Unknown request")
 	}
@@ -114,11 +152,29 @@ func (this *httpServerType) post(w http.ResponseWriter, req *http.Request) {
 	w.Write(final)
 }
 
+// metrics exposes the node internals using the Prometheus text format. It requires the metrics scope
+func (this *httpServerType) metrics(w http.ResponseWriter, req *http.Request) {
+
+	if !this.limit(w, req) {
+		return
+	}
+
+	if !api_code_types.GetScopes(req.URL.Query()).Has(api_keys.SCOPE_METRICS) {
+		http.Error(w, "Invalid User or Password", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	metrics.Write(w)
+}
+
 func (this *httpServerType) GetHttpHandler() *http.Handler {
 
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/ws", websocks.Websockets.HandleUpgradeConnection)
+	mux.HandleFunc("/sse", this.sse)
+	mux.HandleFunc("/metrics", this.metrics)
 
 	for key, filepath := range network_config.STATIC_FILES {
 		fs := http.FileServer(http.Dir(filepath))
@@ -152,12 +208,15 @@ func NewHttpServer() error {
 
 	websocks.NewWebsockets(apiWebsockets.GetMap)
 
+	rate_limiter.InitializeRateLimiter()
+
 	HttpServer = &httpServerType{
 		api,
 		apiWebsockets,
 		apiStore,
 		make(map[string]func(values url.Values) (any, error)),
 		make(map[string]func(values io.ReadCloser) (any, error)),
+		&atomic.Int64{},
 	}
 
 	if err = node_http_rpc.InitializeRPC(apiCommon); err != nil {
@@ -0,0 +1,221 @@
+//go:build !wasm
+// +build !wasm
+
+package node_http
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/websocks"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// sseNotification is the JSON sent in the data field of every SSE event
+type sseNotification struct {
+	Type  api_code_types.SubscriptionType `json:"type"`
+	Key   helpers.Base64                  `json:"key"`
+	Data  any                             `json:"data,omitempty"`
+	Extra any                             `json:"extra,omitempty"`
+}
+
+type sseSubscriber struct {
+	uuid       advanced_connection_types.UUID
+	returnType api_code_types.APIReturnType
+	messages   chan []byte
+	lastId     *atomic.Uint64 //every notification gets an id, so the client can detect the gaps
+	dropped    *atomic.Uint64 //notifications dropped since the last "dropped" event
+	droppedCn  chan struct{}
+}
+
+func newSSESubscriber(returnType api_code_types.APIReturnType) *sseSubscriber {
+	return &sseSubscriber{
+		connection.NewUUID(),
+		returnType,
+		make(chan []byte, network_config.SSE_STREAM_BUFFER),
+		&atomic.Uint64{},
+		&atomic.Uint64{},
+		make(chan struct{}, 1),
+	}
+}
+
+func (this *sseSubscriber) GetUUID() advanced_connection_types.UUID {
+	return this.uuid
+}
+
+// push never blocks because it is called by the subscriptions processing loop
+func (this *sseSubscriber) push(name []byte, notification *sseNotification) error {
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	id := this.lastId.Add(1)
+
+	select {
+	case this.messages <- []byte("id: " + strconv.FormatUint(id, 10) + "\nevent: " + string(name) + "\ndata: " + string(data) + "\n\n"):
+		return nil
+	default:
+		this.dropped.Add(1)
+		select {
+		case this.droppedCn <- struct{}{}:
+		default:
+		}
+		return errors.New("SSE stream is full")
+	}
+}
+
+// stream writes the notifications until the client disconnects. A "dropped" event tells the client
+// how many notifications were lost because it didn't read them fast enough
+func (this *sseSubscriber) stream(ctx context.Context, w io.Writer, flusher http.Flusher) (err error) {
+
+	ping := time.NewTicker(network_config.WEBSOCKETS_PING_INTERVAL)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-this.messages:
+			if _, err = w.Write(message); err != nil {
+				return
+			}
+		case <-this.droppedCn:
+			if _, err = w.Write([]byte("event: dropped\ndata: {\"count\":" + strconv.FormatUint(this.dropped.Swap(0), 10) + "}\n\n")); err != nil {
+				return
+			}
+		case <-ping.C:
+			if _, err = w.Write([]byte(": ping\n\n")); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (this *sseSubscriber) Send(name []byte, data []byte, ctxDuration time.Duration) error {
+	return this.push([]byte("sub/notify"), &sseNotification{Key: name})
+}
+
+func (this *sseSubscriber) SendJSON(name []byte, data any, ctxDuration time.Duration) (err error) {
+
+	notification, ok := data.(*api_code_types.APISubscriptionNotification)
+	if !ok {
+		return errors.New("Invalid notification")
+	}
+
+	final := &sseNotification{notification.SubscriptionType, notification.Key, nil, nil}
+
+	//the element is msgpack marshalled for RETURN_JSON and serialized for RETURN_SERIALIZED
+	if len(notification.Data) > 0 {
+		if this.returnType == api_code_types.RETURN_JSON {
+			if err = msgpack.Unmarshal(notification.Data, &final.Data); err != nil {
+				return
+			}
+		} else {
+			final.Data = helpers.Base64(notification.Data)
+		}
+	}
+	if len(notification.Extra) > 0 {
+		if err = msgpack.Unmarshal(notification.Extra, &final.Extra); err != nil {
+			return
+		}
+	}
+
+	return this.push(name, final)
+}
+
+// parseSSESubscription parses "type" or "type:base64key"
+func parseSSESubscription(value string) (subscriptionType api_code_types.SubscriptionType, key []byte, err error) {
+
+	typeStr, keyStr, _ := strings.Cut(value, ":")
+
+	var n uint64
+	if n, err = strconv.ParseUint(typeStr, 10, 8); err != nil {
+		return
+	}
+	subscriptionType = api_code_types.SubscriptionType(n)
+
+	if key, err = base64.StdEncoding.DecodeString(keyStr); err != nil {
+		return
+	}
+	return
+}
+
+// sse streams the subscriptions notifications as Server-Sent Events.
+// Query parameters: sub=type or sub=type:base64key (can be repeated) and returnType=0|1
+func (this *httpServerType) sse(w http.ResponseWriter, req *http.Request) {
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	if this.sseStreams.Add(1) > network_config.SSE_MAX_STREAMS {
+		this.sseStreams.Add(-1)
+		http.Error(w, "Too many SSE streams", http.StatusServiceUnavailable)
+		return
+	}
+	defer this.sseStreams.Add(-1)
+
+	query := req.URL.Query()
+
+	returnType := api_code_types.RETURN_JSON
+	if query.Get("returnType") != "" {
+		n, err := strconv.ParseUint(query.Get("returnType"), 10, 8)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		returnType = api_code_types.APIReturnType(n)
+	}
+
+	if len(query["sub"]) == 0 {
+		http.Error(w, "No subscription", http.StatusBadRequest)
+		return
+	}
+
+	subscriber := newSSESubscriber(returnType)
+
+	subscriptions, err := websocks.Websockets.NewSubscriptions(subscriber)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer websocks.Websockets.ClosedSubscriber(subscriber)
+
+	for _, value := range query["sub"] {
+		subscriptionType, key, err := parseSSESubscription(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err = subscriptions.AddSubscription(subscriptionType, key, returnType); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	subscriber.stream(req.Context(), w, flusher)
+}
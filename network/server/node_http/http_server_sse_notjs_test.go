@@ -0,0 +1,107 @@
+//go:build !wasm
+// +build !wasm
+
+package node_http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/network/network_config"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpServer_SSE(t *testing.T) {
+
+	oldMaxStreams := network_config.SSE_MAX_STREAMS
+	network_config.SSE_MAX_STREAMS = 2
+	defer func() {
+		network_config.SSE_MAX_STREAMS = oldMaxStreams
+	}()
+
+	server := &httpServerType{sseStreams: &atomic.Int64{}}
+
+	request := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.sse(w, httptest.NewRequest(http.MethodGet, "/sse"+query, nil))
+		return w
+	}
+
+	w := request("")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "No subscription\n", w.Body.String())
+
+	w = request("?sub=1&returnType=abc")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	//the rejected requests released their streams
+	assert.Equal(t, int64(0), server.sseStreams.Load())
+
+	//all the streams are in use
+	server.sseStreams.Store(network_config.SSE_MAX_STREAMS)
+	w = request("?sub=1")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "Too many SSE streams\n", w.Body.String())
+	assert.Equal(t, network_config.SSE_MAX_STREAMS, server.sseStreams.Load())
+
+	server.sseStreams.Store(network_config.SSE_MAX_STREAMS - 1)
+	w = request("")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, network_config.SSE_MAX_STREAMS-1, server.sseStreams.Load())
+}
+
+func TestSSESubscriber_Stream(t *testing.T) {
+
+	subscriber := newSSESubscriber(api_code_types.RETURN_SERIALIZED)
+
+	key := helpers.RandomBytes(cryptography.HashSize)
+	notify := func() error {
+		return subscriber.SendJSON([]byte("sub/notify"), &api_code_types.APISubscriptionNotification{api_code_types.SUBSCRIPTION_TRANSACTION, key, []byte{1, 2, 3}, nil}, 0)
+	}
+
+	//the client doesn't read, so the notifications above the buffer are dropped
+	for i := 0; i < network_config.SSE_STREAM_BUFFER; i++ {
+		assert.NoError(t, notify())
+	}
+	assert.EqualError(t, notify(), "SSE stream is full")
+	assert.EqualError(t, notify(), "SSE stream is full")
+	assert.Equal(t, uint64(2), subscriber.dropped.Load())
+
+	w := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, subscriber.stream(ctx, w, w))
+		close(done)
+	}()
+
+	for len(subscriber.messages) > 0 || len(subscriber.droppedCn) > 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "event: dropped\ndata: {\"count\":2}\n\n"))
+	assert.Equal(t, network_config.SSE_STREAM_BUFFER, strings.Count(body, "event: sub/notify\n"))
+
+	//the ids are sequential and the dropped notifications leave a gap
+	for i := 1; i <= network_config.SSE_STREAM_BUFFER; i++ {
+		assert.Contains(t, body, "id: "+strconv.Itoa(i)+"\nevent: sub/notify\ndata: {\"type\":")
+	}
+	assert.Equal(t, uint64(0), subscriber.dropped.Load())
+
+	assert.NoError(t, notify())
+	message := <-subscriber.messages
+	assert.True(t, strings.HasPrefix(string(message), "id: "+strconv.Itoa(network_config.SSE_STREAM_BUFFER+3)+"\n"))
+}
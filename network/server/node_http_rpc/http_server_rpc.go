@@ -1,16 +1,29 @@
 package node_http_rpc
 
 import (
+	"context"
 	"github.com/gorilla/rpc"
 	"net/http"
+	"pandora-pay/network/api_code/api_code_types"
 	"pandora-pay/network/api_implementation/api_common"
+	"time"
 )
 
+type rpcStartKey struct{}
+
 func InitializeRPC(apiCommon *api_common.APICommon) (err error) {
 
 	s := rpc.NewServer()
 
 	s.RegisterCodec(NewUpCodec(), "application/json")
+	s.RegisterInterceptFunc(func(i *rpc.RequestInfo) *http.Request {
+		return i.Request.WithContext(context.WithValue(i.Request.Context(), rpcStartKey{}, time.Now()))
+	})
+	s.RegisterAfterFunc(func(i *rpc.RequestInfo) {
+		if start, ok := i.Request.Context().Value(rpcStartKey{}).(time.Time); ok {
+			api_code_types.APIRequestsDurationMetric.Observe(time.Since(start).Seconds(), i.Method, "rpc")
+		}
+	})
 	if err = s.RegisterService(apiCommon, "api"); err != nil {
 		return
 	}
@@ -0,0 +1,92 @@
+package webhooks
+
+import (
+	"errors"
+	"net/url"
+	"pandora-pay/addresses"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"time"
+)
+
+type WebhookType string
+
+const (
+	WEBHOOK_ACCOUNT     WebhookType = "account"
+	WEBHOOK_ASSET       WebhookType = "asset"
+	WEBHOOK_TRANSACTION WebhookType = "tx"
+)
+
+type Webhook struct {
+	Id        string         `json:"id" msgpack:"id"`
+	Type      WebhookType    `json:"type" msgpack:"type"`
+	Key       helpers.Base64 `json:"key" msgpack:"key"` //public key, asset hash or tx hash
+	URL       string         `json:"url" msgpack:"url"`
+	Secret    string         `json:"secret,omitempty" msgpack:"secret"`
+	Timestamp time.Time      `json:"timestamp" msgpack:"timestamp"`
+	Static    bool           `json:"static" msgpack:"static"` //loaded from --webhooks-config and not stored
+}
+
+// WebhookConfig is the format used by the API and by the --webhooks-config file
+type WebhookConfig struct {
+	Type    WebhookType    `json:"type" msgpack:"type"`
+	Address string         `json:"address,omitempty" msgpack:"address,omitempty"`
+	Key     helpers.Base64 `json:"key,omitempty" msgpack:"key,omitempty"`
+	URL     string         `json:"url" msgpack:"url"`
+	Secret  string         `json:"secret" msgpack:"secret"`
+}
+
+func (this *WebhookConfig) getKey() ([]byte, error) {
+	switch this.Type {
+	case WEBHOOK_ACCOUNT:
+		if this.Address != "" {
+			address, err := addresses.DecodeAddr(this.Address)
+			if err != nil {
+				return nil, errors.New("Invalid address")
+			}
+			return address.PublicKey, nil
+		}
+		if len(this.Key) != cryptography.PublicKeySize {
+			return nil, errors.New("Invalid address or publicKey")
+		}
+	case WEBHOOK_ASSET:
+		if len(this.Key) != config_coins.ASSET_LENGTH {
+			return nil, errors.New("Invalid asset")
+		}
+	case WEBHOOK_TRANSACTION:
+		if len(this.Key) != cryptography.HashSize {
+			return nil, errors.New("Invalid tx hash")
+		}
+	default:
+		return nil, errors.New("Invalid webhook type")
+	}
+	return this.Key, nil
+}
+
+func (this *WebhookConfig) newWebhook(id string, static bool) (*Webhook, error) {
+
+	key, err := this.getKey()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(this.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("URL must be http or https")
+	}
+
+	if this.Secret == "" {
+		return nil, errors.New("Secret is empty")
+	}
+
+	return &Webhook{id, this.Type, key, this.URL, this.Secret, time.Now(), static}, nil
+}
+
+// Public returns a copy without the secret
+func (this *Webhook) Public() *Webhook {
+	return &Webhook{this.Id, this.Type, this.Key, this.URL, "", this.Timestamp, this.Static}
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"encoding/hex"
+	"errors"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"sort"
+	"sync"
+)
+
+type WebhooksType struct {
+	webhooksMap   *generics.Map[string, *Webhook]
+	index         *generics.Value[map[string][]*Webhook] //type:key
+	indexLock     *sync.Mutex
+	queue         *webhooksQueue
+	newDeliveryCn chan struct{}
+}
+
+func getIndexKey(webhookType WebhookType, key string) string {
+	return string(webhookType) + ":" + key
+}
+
+func (this *WebhooksType) updateIndex() {
+	this.indexLock.Lock()
+	defer this.indexLock.Unlock()
+
+	index := make(map[string][]*Webhook)
+	this.webhooksMap.Range(func(id string, webhook *Webhook) bool {
+		indexKey := getIndexKey(webhook.Type, string(webhook.Key))
+		index[indexKey] = append(index[indexKey], webhook)
+		return true
+	})
+	this.index.Store(index)
+}
+
+func (this *WebhooksType) getWebhooks(webhookType WebhookType, key string) []*Webhook {
+	return this.index.Load()[getIndexKey(webhookType, key)]
+}
+
+func (this *WebhooksType) Add(webhookConfig *WebhookConfig) (*Webhook, error) {
+
+	webhook, err := webhookConfig.newWebhook(hex.EncodeToString(helpers.RandomBytes(16)), false)
+	if err != nil {
+		return nil, err
+	}
+
+	this.webhooksMap.Store(webhook.Id, webhook)
+	this.updateIndex()
+
+	if err = this.save(); err != nil {
+		gui.GUI.Error("Error saving webhooks", err)
+	}
+
+	return webhook, nil
+}
+
+func (this *WebhooksType) Remove(id string) (bool, error) {
+
+	webhook, found := this.webhooksMap.Load(id)
+	if !found {
+		return false, nil
+	}
+	if webhook.Static {
+		return false, errors.New("Webhook was loaded from --webhooks-config and can't be removed")
+	}
+
+	this.webhooksMap.Delete(id)
+	this.updateIndex()
+
+	if err := this.save(); err != nil {
+		gui.GUI.Error("Error saving webhooks", err)
+	}
+	return true, nil
+}
+
+// GetList returns the registered webhooks without their secrets
+func (this *WebhooksType) GetList() []*Webhook {
+
+	list := make([]*Webhook, 0)
+	this.webhooksMap.Range(func(id string, webhook *Webhook) bool {
+		list = append(list, webhook.Public())
+		return true
+	})
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
+	})
+
+	return list
+}
+
+// GetPendingDeliveries returns the number of events waiting to be delivered
+func (this *WebhooksType) GetPendingDeliveries() int {
+	return this.queue.count()
+}
+
+var Webhooks *WebhooksType
+
+func init() {
+	Webhooks = &WebhooksType{
+		&generics.Map[string, *Webhook]{},
+		&generics.Value[map[string][]*Webhook]{},
+		&sync.Mutex{},
+		newWebhooksQueue(),
+		make(chan struct{}, 1),
+	}
+	Webhooks.index.Store(map[string][]*Webhook{})
+}
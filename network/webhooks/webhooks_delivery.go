@@ -0,0 +1,123 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"pandora-pay/gui"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/network_config"
+	"strconv"
+	"time"
+)
+
+var logger = gui.NewSubsystemLogger("network")
+
+// Sign returns the signature sent in the X-Pandora-Signature header. It is the HMAC-SHA256 of the body using the webhook secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (this *WebhooksType) post(webhook *Webhook, delivery *webhookDelivery) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), network_config.WEBHOOKS_DELIVERY_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Pandora-Webhook", webhook.Id)
+	req.Header.Set("X-Pandora-Delivery", strconv.FormatUint(delivery.Id, 10))
+	req.Header.Set("X-Pandora-Signature", Sign(webhook.Secret, delivery.Body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("Webhook returned status " + strconv.Itoa(resp.StatusCode))
+	}
+
+	return nil
+}
+
+func getRetryDelay(attempts uint64) time.Duration {
+	delay := network_config.WEBHOOKS_RETRY_INITIAL
+	for i := uint64(1); i < attempts && delay < network_config.WEBHOOKS_RETRY_MAX; i++ {
+		delay *= 2
+	}
+	if delay > network_config.WEBHOOKS_RETRY_MAX {
+		delay = network_config.WEBHOOKS_RETRY_MAX
+	}
+	return delay
+}
+
+func (this *WebhooksType) deliver(delivery *webhookDelivery) {
+
+	webhook, found := this.webhooksMap.Load(delivery.WebhookId)
+	if !found { //the webhook was removed
+		if err := this.queue.remove(delivery); err != nil {
+			logger.Error("Error removing webhook delivery", "delivery", delivery.Id, "err", err)
+		}
+		return
+	}
+
+	err := this.post(webhook, delivery)
+	if err == nil {
+		if err = this.queue.remove(delivery); err != nil {
+			logger.Error("Error removing webhook delivery", "delivery", delivery.Id, "err", err)
+		}
+		return
+	}
+
+	delivery.Attempts += 1
+	if delivery.Attempts >= network_config.WEBHOOKS_MAX_ATTEMPTS {
+		logger.Warn("Webhook delivery dropped", "url", webhook.URL, "delivery", delivery.Id, "attempts", delivery.Attempts, "err", err)
+		if err = this.queue.remove(delivery); err != nil {
+			logger.Error("Error removing webhook delivery", "delivery", delivery.Id, "err", err)
+		}
+		return
+	}
+
+	delivery.NextAttempt = time.Now().Add(getRetryDelay(delivery.Attempts)).UnixNano()
+	if err = this.queue.retry(delivery); err != nil {
+		logger.Error("Error storing webhook delivery", "delivery", delivery.Id, "err", err)
+	}
+}
+
+func (this *WebhooksType) processDeliveries() {
+
+	threads := make(chan struct{}, network_config.WEBHOOKS_CONCURRENT_DELIVERIES)
+
+	for {
+
+		select {
+		case <-this.newDeliveryCn:
+		case <-time.After(1 * time.Second):
+		}
+
+		for _, delivery := range this.queue.getReady(time.Now().UnixNano(), network_config.WEBHOOKS_CONCURRENT_DELIVERIES) {
+			threads <- struct{}{}
+			it := delivery
+			recovery.SafeGo(func() {
+				defer func() { <-threads }()
+				this.deliver(it)
+			})
+		}
+
+	}
+}
@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+
+	body := []byte(`{"delivery":1}`)
+
+	//RFC 4231 test case 2
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", Sign("Jefe", []byte("what do ya want for nothing?")))
+	assert.Equal(t, Sign("secret", body), Sign("secret", body))
+	assert.NotEqual(t, Sign("secret", body), Sign("other secret", body))
+	assert.NotEqual(t, Sign("secret", body), Sign("secret", []byte(`{"delivery":2}`)))
+}
+
+func TestWebhooks_DeliverSigned(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(nil)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.NoError(t, err)
+	store.StoreSettings = &store.Store{"settings", true, db}
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	receivedCn := make(chan *received, 10)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedCn <- &received{r.Header, body}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	webhooks := &WebhooksType{
+		&generics.Map[string, *Webhook]{},
+		&generics.Value[map[string][]*Webhook]{},
+		&sync.Mutex{},
+		newWebhooksQueue(),
+		make(chan struct{}, 1),
+	}
+	webhooks.index.Store(map[string][]*Webhook{})
+
+	txHash := helpers.RandomBytes(cryptography.HashSize)
+
+	webhook, err := webhooks.Add(&WebhookConfig{WEBHOOK_TRANSACTION, "", txHash, server.URL, "secret"})
+	assert.NoError(t, err)
+
+	webhooks.enqueue(webhooks.collect(nil, WEBHOOK_TRANSACTION, string(txHash), "transaction", nil, txHash, nil))
+	assert.Equal(t, 1, webhooks.GetPendingDeliveries())
+
+	ready := webhooks.queue.getReady(time.Now().UnixNano(), 10)
+	if !assert.Equal(t, 1, len(ready)) {
+		return
+	}
+	webhooks.deliver(ready[0])
+
+	it := <-receivedCn
+	assert.Equal(t, webhook.Id, it.header.Get("X-Pandora-Webhook"))
+	assert.Equal(t, "0", it.header.Get("X-Pandora-Delivery"))
+
+	//the receiver verifies the signature with the shared secret
+	assert.True(t, hmac.Equal([]byte(Sign("secret", it.body)), []byte(it.header.Get("X-Pandora-Signature"))))
+	assert.False(t, hmac.Equal([]byte(Sign("wrong secret", it.body)), []byte(it.header.Get("X-Pandora-Signature"))))
+
+	event := &WebhookEvent{}
+	assert.NoError(t, json.Unmarshal(it.body, event))
+	assert.Equal(t, webhook.Id, event.WebhookId)
+	assert.Equal(t, "transaction", event.Event)
+	assert.Equal(t, txHash, []byte(event.TxHash))
+
+	assert.Equal(t, 0, webhooks.GetPendingDeliveries())
+
+	//a rejected delivery is kept and retried later with the same signature
+	status = http.StatusUnauthorized
+
+	webhooks.enqueue(webhooks.collect(nil, WEBHOOK_TRANSACTION, string(txHash), "transaction", nil, txHash, nil))
+	ready = webhooks.queue.getReady(time.Now().UnixNano(), 10)
+	if !assert.Equal(t, 1, len(ready)) {
+		return
+	}
+	webhooks.deliver(ready[0])
+
+	it = <-receivedCn
+	assert.Equal(t, Sign("secret", it.body), it.header.Get("X-Pandora-Signature"))
+	assert.Equal(t, ready[0].Body, it.body)
+
+	assert.Equal(t, 1, webhooks.GetPendingDeliveries())
+	assert.Equal(t, uint64(1), ready[0].Attempts)
+	assert.Equal(t, 0, len(webhooks.queue.getReady(time.Now().UnixNano(), 10)))
+}
@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"pandora-pay/blockchain"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/mempool"
+	"pandora-pay/network/network_config"
+	"time"
+)
+
+// WebhookEvent is the JSON body POSTed to the webhook URL
+type WebhookEvent struct {
+	Delivery  uint64         `json:"delivery"`
+	WebhookId string         `json:"webhookId"`
+	Type      WebhookType    `json:"type"`
+	Key       helpers.Base64 `json:"key"`
+	Event     string         `json:"event"`             //account, plainAccount, registration, asset, accountTransaction, transaction
+	Element   helpers.Base64 `json:"element,omitempty"` //serialized element. Empty when it was deleted
+	TxHash    helpers.Base64 `json:"txHash,omitempty"`
+	Extra     any            `json:"extra,omitempty"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+type WebhookEventBlockchainExtra struct {
+	Inserted       bool   `json:"inserted"`
+	BlockHeight    uint64 `json:"blockHeight"`
+	BlockTimestamp uint64 `json:"blockTimestamp"`
+	Height         uint64 `json:"height"`
+}
+
+type WebhookEventMempoolExtra struct {
+	Inserted                         bool           `json:"inserted"`
+	IncludedInBlockchainNotification bool           `json:"included"`
+	Evicted                          bool           `json:"evicted,omitempty"`
+	ReplacedBy                       helpers.Base64 `json:"replacedBy,omitempty"`
+}
+
+type WebhookEventElementExtra struct {
+	Asset helpers.Base64 `json:"asset,omitempty"`
+	Index uint64         `json:"index"`
+}
+
+type pendingEvent struct {
+	webhook *Webhook
+	event   *WebhookEvent
+}
+
+func (this *WebhooksType) collect(pending []*pendingEvent, webhookType WebhookType, key string, event string, element helpers.SerializableInterface, txHash []byte, extra any) []*pendingEvent {
+
+	for _, webhook := range this.getWebhooks(webhookType, key) {
+
+		var elementBytes []byte
+		if element != nil {
+			elementBytes = helpers.SerializeToBytes(element)
+		}
+
+		pending = append(pending, &pendingEvent{webhook, &WebhookEvent{
+			WebhookId: webhook.Id,
+			Type:      webhookType,
+			Key:       []byte(key),
+			Event:     event,
+			Element:   elementBytes,
+			TxHash:    txHash,
+			Extra:     extra,
+		}})
+	}
+
+	return pending
+}
+
+func (this *WebhooksType) enqueue(pending []*pendingEvent) {
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if this.queue.count()+len(pending) > network_config.WEBHOOKS_QUEUE_MAX {
+		gui.GUI.Error("Webhooks queue is full. Events dropped", len(pending))
+		return
+	}
+
+	timestamp := time.Now().Unix()
+	if err := this.queue.add(len(pending), func(index int, id uint64) (*webhookDelivery, error) {
+		it := pending[index]
+		it.event.Delivery = id
+		it.event.Timestamp = timestamp
+		body, err := json.Marshal(it.event)
+		if err != nil {
+			return nil, err
+		}
+		return &webhookDelivery{id, it.webhook.Id, body, 0, 0}, nil
+	}); err != nil {
+		gui.GUI.Error("Error storing webhooks deliveries", err)
+		return
+	}
+
+	select {
+	case this.newDeliveryCn <- struct{}{}:
+	default:
+	}
+}
+
+func (this *WebhooksType) processEvents() {
+
+	updateNotificationsCn := blockchain.Blockchain.UpdateSocketsSubscriptionsNotifications.AddListener()
+	defer blockchain.Blockchain.UpdateSocketsSubscriptionsNotifications.RemoveChannel(updateNotificationsCn)
+
+	updateTransactionsCn := blockchain.Blockchain.UpdateSocketsSubscriptionsTransactions.AddListener()
+	defer blockchain.Blockchain.UpdateSocketsSubscriptionsTransactions.RemoveChannel(updateTransactionsCn)
+
+	updateMempoolTransactionsCn := mempool.Mempool.Txs.UpdateMempoolTransactions.AddListener()
+	defer mempool.Mempool.Txs.UpdateMempoolTransactions.RemoveChannel(updateMempoolTransactionsCn)
+
+	for {
+
+		var pending []*pendingEvent
+
+		select {
+		case dataStorage, ok := <-updateNotificationsCn:
+			if !ok {
+				return
+			}
+
+			for _, accs := range dataStorage.AccsCollection.GetAllMaps() {
+				for k, v := range accs.HashMap.Committed {
+					extra := &WebhookEventElementExtra{Asset: accs.Asset}
+					var element helpers.SerializableInterface
+					if v.Element != nil {
+						extra.Index = v.Element.GetIndex()
+						element = v.Element
+					}
+					pending = this.collect(pending, WEBHOOK_ACCOUNT, k, "account", element, nil, extra)
+				}
+			}
+
+			for k, v := range dataStorage.PlainAccs.HashMap.Committed {
+				extra := &WebhookEventElementExtra{}
+				var element helpers.SerializableInterface
+				if v.Element != nil {
+					extra.Index = v.Element.GetIndex()
+					element = v.Element
+				}
+				pending = this.collect(pending, WEBHOOK_ACCOUNT, k, "plainAccount", element, nil, extra)
+			}
+
+			for k, v := range dataStorage.Regs.HashMap.Committed {
+				extra := &WebhookEventElementExtra{}
+				var element helpers.SerializableInterface
+				if v.Element != nil {
+					extra.Index = v.Element.GetIndex()
+					element = v.Element
+				}
+				pending = this.collect(pending, WEBHOOK_ACCOUNT, k, "registration", element, nil, extra)
+			}
+
+			for k, v := range dataStorage.Asts.HashMap.Committed {
+				extra := &WebhookEventElementExtra{}
+				var element helpers.SerializableInterface
+				if v.Element != nil {
+					extra.Index = v.Element.GetIndex()
+					element = v.Element
+				}
+				pending = this.collect(pending, WEBHOOK_ASSET, k, "asset", element, nil, extra)
+			}
+
+		case txsUpdates, ok := <-updateTransactionsCn:
+			if !ok {
+				return
+			}
+
+			for _, v := range txsUpdates {
+				extra := &WebhookEventBlockchainExtra{v.Inserted, v.BlockHeight, v.BlockTimestamp, v.Height}
+				for _, key := range v.Keys {
+					pending = this.collect(pending, WEBHOOK_ACCOUNT, string(key.PublicKey), "accountTransaction", nil, v.TxHash, extra)
+				}
+				pending = this.collect(pending, WEBHOOK_TRANSACTION, v.TxHashStr, "transaction", nil, v.TxHash, extra)
+			}
+
+		case txUpdate, ok := <-updateMempoolTransactionsCn:
+			if !ok {
+				return
+			}
+
+			extra := &WebhookEventMempoolExtra{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.Evicted, nil}
+			if txUpdate.ReplacedBy != nil {
+				extra.ReplacedBy = txUpdate.ReplacedBy.Bloom.Hash
+			}
+
+			for key := range txUpdate.Keys {
+				pending = this.collect(pending, WEBHOOK_ACCOUNT, key, "accountTransaction", nil, txUpdate.Tx.Bloom.Hash, extra)
+			}
+			pending = this.collect(pending, WEBHOOK_TRANSACTION, txUpdate.Tx.Bloom.HashStr, "transaction", nil, txUpdate.Tx.Bloom.Hash, extra)
+		}
+
+		this.enqueue(pending)
+	}
+}
+
+func (this *WebhooksType) Start() {
+	recovery.SafeGo(this.processEvents)
+	recovery.SafeGo(this.processDeliveries)
+}
@@ -0,0 +1,183 @@
+package webhooks
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type webhookDelivery struct {
+	Id          uint64 `msgpack:"id"`
+	WebhookId   string `msgpack:"webhookId"`
+	Body        []byte `msgpack:"body"`
+	Attempts    uint64 `msgpack:"attempts"`
+	NextAttempt int64  `msgpack:"nextAttempt"` //unix nano
+}
+
+// webhooksQueue is persisted in StoreSettings. Every delivery is stored under webhooksQueue:<id>
+// and the ids are sequential between webhooksQueueFirst and webhooksQueueNext
+type webhooksQueue struct {
+	lock       *sync.Mutex
+	deliveries map[uint64]*webhookDelivery
+	inFlight   map[uint64]bool
+	first      uint64
+	next       uint64
+}
+
+func getDeliveryKey(id uint64) string {
+	return "webhooksQueue:" + strconv.FormatUint(id, 10)
+}
+
+func (this *webhooksQueue) count() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return len(this.deliveries)
+}
+
+func (this *webhooksQueue) load() error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if data := reader.Get("webhooksQueueFirst"); data != nil {
+			if this.first, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+				return
+			}
+		}
+		if data := reader.Get("webhooksQueueNext"); data != nil {
+			if this.next, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+				return
+			}
+		}
+
+		for id := this.first; id < this.next; id++ {
+			data := reader.Get(getDeliveryKey(id))
+			if data == nil {
+				continue
+			}
+			delivery := &webhookDelivery{}
+			if err = msgpack.Unmarshal(data, delivery); err != nil {
+				return
+			}
+			this.deliveries[id] = delivery
+		}
+
+		return
+	})
+}
+
+// add stores the new deliveries. The body is generated after the id is assigned
+func (this *webhooksQueue) add(count int, create func(index int, id uint64) (*webhookDelivery, error)) error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	list := make([]*webhookDelivery, 0, count)
+	for i := 0; i < count; i++ {
+		delivery, err := create(i, this.next+uint64(i))
+		if err != nil {
+			return err
+		}
+		list = append(list, delivery)
+	}
+
+	if err := store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		for _, delivery := range list {
+			marshal, err := msgpack.Marshal(delivery)
+			if err != nil {
+				return err
+			}
+			writer.Put(getDeliveryKey(delivery.Id), marshal)
+		}
+		writer.Put("webhooksQueueNext", []byte(strconv.FormatUint(this.next+uint64(len(list)), 10)))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, delivery := range list {
+		this.deliveries[delivery.Id] = delivery
+	}
+	this.next += uint64(len(list))
+
+	return nil
+}
+
+// getReady returns the deliveries that should be sent now and marks them in flight
+func (this *webhooksQueue) getReady(now int64, limit int) []*webhookDelivery {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	list := make([]*webhookDelivery, 0)
+	for id, delivery := range this.deliveries {
+		if !this.inFlight[id] && delivery.NextAttempt <= now {
+			list = append(list, delivery)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+
+	for _, delivery := range list {
+		this.inFlight[delivery.Id] = true
+	}
+
+	return list
+}
+
+func (this *webhooksQueue) retry(delivery *webhookDelivery) error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	delete(this.inFlight, delivery.Id)
+
+	marshal, err := msgpack.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put(getDeliveryKey(delivery.Id), marshal)
+		return nil
+	})
+}
+
+func (this *webhooksQueue) remove(delivery *webhookDelivery) error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	delete(this.inFlight, delivery.Id)
+	delete(this.deliveries, delivery.Id)
+
+	for this.first < this.next && this.deliveries[this.first] == nil {
+		this.first++
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Delete(getDeliveryKey(delivery.Id))
+		writer.Put("webhooksQueueFirst", []byte(strconv.FormatUint(this.first, 10)))
+		return nil
+	})
+}
+
+func newWebhooksQueue() *webhooksQueue {
+	return &webhooksQueue{
+		&sync.Mutex{},
+		make(map[uint64]*webhookDelivery),
+		make(map[uint64]bool),
+		0,
+		0,
+	}
+}
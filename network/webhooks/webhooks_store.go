@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"os"
+	"pandora-pay/config/arguments"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+func (this *WebhooksType) save() error {
+
+	list := make([]*Webhook, 0)
+	this.webhooksMap.Range(func(id string, webhook *Webhook) bool {
+		if !webhook.Static {
+			list = append(list, webhook)
+		}
+		return true
+	})
+
+	marshal, err := msgpack.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("webhooks", marshal)
+		return nil
+	})
+}
+
+// loadConfigFile reads the webhooks given via --webhooks-config. These are not stored
+func (this *WebhooksType) loadConfigFile() error {
+
+	if arguments.Arguments["--webhooks-config"] == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(arguments.Arguments["--webhooks-config"].(string))
+	if err != nil {
+		return err
+	}
+
+	var list []*WebhookConfig
+	if err = json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	for i, webhookConfig := range list {
+		webhook, err := webhookConfig.newWebhook("config-"+strconv.Itoa(i), true)
+		if err != nil {
+			return err
+		}
+		this.webhooksMap.Store(webhook.Id, webhook)
+	}
+
+	return nil
+}
+
+// Load restores the webhooks and the pending deliveries stored on the disk
+func (this *WebhooksType) Load() error {
+
+	var list []*Webhook
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("webhooks")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &list)
+	}); err != nil {
+		return err
+	}
+
+	for _, webhook := range list {
+		this.webhooksMap.Store(webhook.Id, webhook)
+	}
+
+	if err := this.loadConfigFile(); err != nil {
+		return err
+	}
+
+	this.updateIndex()
+
+	return this.queue.load()
+}
@@ -9,8 +9,10 @@ import (
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/api_code/api_code_types"
 	"pandora-pay/network/known_nodes/known_node"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/rate_limiter"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/network/websocks/websock"
 	"sync"
@@ -29,7 +31,8 @@ const (
 var uuidGenerator uint32 //use atomic
 
 type AdvancedConnection struct {
-	Authenticated            *abool.AtomicBool
+	AuthenticatedScopes      *atomic.Uint32          //scopes granted by login
+	ApiKeyId                 *generics.Value[string] //api key used to login. Used by the rate limiter
 	UUID                     advanced_connection_types.UUID
 	Conn                     *websock.Conn
 	Handshake                *ConnectionHandshake
@@ -51,6 +54,10 @@ type AdvancedConnection struct {
 	onIncreaseKnownNodeScore func(knownNode *known_node.KnownNodeScored, delta int32, isServer bool) bool
 }
 
+func (c *AdvancedConnection) GetUUID() advanced_connection_types.UUID {
+	return c.UUID
+}
+
 func (c *AdvancedConnection) GetTimeout() time.Duration {
 	return network_config.WEBSOCKETS_TIMEOUT
 }
@@ -178,6 +185,14 @@ func SendJSONAwaitAnswer[T any](c *AdvancedConnection, name []byte, data any, ct
 	return final, nil
 }
 
+// limit applies the rate limiter to the clients. Only the outgoing connections are not limited as the node opened them. Incoming full nodes are limited too, as the consensus type is reported by the peer, but the sync routes use their own bucket
+func (c *AdvancedConnection) limit(route string) error {
+	if !c.ConnectionType || rate_limiter.RateLimiter == nil {
+		return nil
+	}
+	return rate_limiter.RateLimiter.Allow(c.RemoteAddr, c.ApiKeyId.Load(), route)
+}
+
 func (c *AdvancedConnection) get(message *advanced_connection_types.AdvancedConnectionMessage) (final []byte, err error) {
 
 	defer func() {
@@ -190,9 +205,14 @@ func (c *AdvancedConnection) get(message *advanced_connection_types.AdvancedConn
 
 	route := string(message.Name)
 	if callback := c.getMap[route]; callback != nil {
+		if err = c.limit(route); err != nil {
+			return
+		}
+		start := time.Now()
 		output, err = callback(c, message.Data)
+		api_code_types.APIRequestsDurationMetric.Observe(time.Since(start).Seconds(), route, "websocket")
 	} else {
-		err = errors.New("Unknown request")
+		err = advanced_connection_types.ErrUnknownRequest
 	}
 
 	if err != nil {
@@ -231,6 +251,8 @@ func (c *AdvancedConnection) processRead(message *advanced_connection_types.Adva
 		output := &advanced_connection_types.AdvancedConnectionReply{}
 		if len(message.Name) == 1 && message.Name[0] == 1 {
 			output.Out = message.Data
+		} else if string(message.Data) == advanced_connection_types.ErrUnknownRequest.Error() {
+			output.Err = advanced_connection_types.ErrUnknownRequest
 		} else {
 			output.Err = errors.New(string(message.Data))
 		}
@@ -331,15 +353,10 @@ func (c *AdvancedConnection) IncreaseKnownNodeScore() {
 
 func NewAdvancedConnection(conn *websock.Conn, remoteAddr string, knownNode *known_node.KnownNodeScored, getMap map[string]func(conn *AdvancedConnection, values []byte) (any, error), connectionType bool, newSubscriptionCn, removeSubscriptionCn chan<- *SubscriptionNotification, onClosedConnection func(*AdvancedConnection), onIncreaseKnownNodeScore func(*known_node.KnownNodeScored, int32, bool) bool) (*AdvancedConnection, error) {
 
-	//making sure u is not collided with UUID_ALL and UUID_SKIP_ALL
-	uuid := advanced_connection_types.UUID(atomic.AddUint32(&uuidGenerator, 1))
-	for uuid <= advanced_connection_types.UUID_SKIP_ALL {
-		uuid = advanced_connection_types.UUID(atomic.AddUint32(&uuidGenerator, 1))
-	}
-
 	advancedConnection := &AdvancedConnection{
-		abool.New(),
-		uuid,
+		&atomic.Uint32{},
+		&generics.Value[string]{},
+		NewUUID(),
 		conn,
 		nil,
 		nil,
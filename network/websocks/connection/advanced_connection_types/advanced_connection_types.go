@@ -1,5 +1,10 @@
 package advanced_connection_types
 
+import "errors"
+
+// ErrUnknownRequest is replied when the route doesn't exist. The reply returns the same value, so the callers can detect peers running an older version
+var ErrUnknownRequest = errors.New("Unknown request")
+
 type AdvancedConnectionMessage struct {
 	ReplyId     uint32
 	ReplyStatus bool
@@ -2,8 +2,18 @@ package connection
 
 import (
 	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"sync/atomic"
+	"time"
 )
 
+// Subscriber receives the subscriptions notifications. Implemented by AdvancedConnection and by the HTTP SSE streams
+type Subscriber interface {
+	GetUUID() advanced_connection_types.UUID
+	Send(name []byte, data []byte, ctxDuration time.Duration) error
+	SendJSON(name []byte, data any, ctxDuration time.Duration) error
+}
+
 type Subscription struct {
 	Type       api_code_types.SubscriptionType
 	Key        []byte
@@ -12,5 +22,14 @@ type Subscription struct {
 
 type SubscriptionNotification struct {
 	Subscription *Subscription
-	Conn         *AdvancedConnection
+	Conn         Subscriber
+}
+
+// NewUUID generates an UUID making sure it doesn't collide with UUID_ALL and UUID_SKIP_ALL
+func NewUUID() advanced_connection_types.UUID {
+	uuid := advanced_connection_types.UUID(atomic.AddUint32(&uuidGenerator, 1))
+	for uuid <= advanced_connection_types.UUID_SKIP_ALL {
+		uuid = advanced_connection_types.UUID(atomic.AddUint32(&uuidGenerator, 1))
+	}
+	return uuid
 }
@@ -12,7 +12,7 @@ import (
 )
 
 type Subscriptions struct {
-	conn                 *AdvancedConnection
+	conn                 Subscriber
 	list                 []*Subscription
 	newSubscriptionCn    chan<- *SubscriptionNotification
 	removeSubscriptionCn chan<- *SubscriptionNotification
@@ -29,6 +29,10 @@ func checkSubscriptionLength(key []byte, subscriptionType api_code_types.Subscri
 		length = config_coins.ASSET_LENGTH
 	case api_code_types.SUBSCRIPTION_TRANSACTION:
 		length = cryptography.HashSize
+	case api_code_types.SUBSCRIPTION_BLOCKS, api_code_types.SUBSCRIPTION_MEMPOOL:
+		length = 0
+	case api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT:
+		length = cryptography.HashSize + 1
 	}
 	if len(key) != length {
 		return errors.New("Key is invalid")
@@ -89,7 +93,7 @@ func (s *Subscriptions) RemoveSubscription(subscriptionType api_code_types.Subsc
 	return errors.New("Subscription not found")
 }
 
-func NewSubscriptions(conn *AdvancedConnection, newSubscriptionCn, removeSubscriptionCn chan<- *SubscriptionNotification) (s *Subscriptions) {
+func NewSubscriptions(conn Subscriber, newSubscriptionCn, removeSubscriptionCn chan<- *SubscriptionNotification) (s *Subscriptions) {
 	return &Subscriptions{
 		conn:                 conn,
 		newSubscriptionCn:    newSubscriptionCn,
@@ -25,6 +25,8 @@ import (
 	"time"
 )
 
+var logger = gui.NewSubsystemLogger("network")
+
 type SocketEvent struct {
 	Type         string
 	Conn         *connection.AdvancedConnection
@@ -99,7 +101,7 @@ func (this *websocketsType) BroadcastAwaitAnswer(name, data []byte, consensusTyp
 
 	t := time.Now().Unix()
 	index := rand.Int()
-	gui.GUI.Log("Propagating", index, len(all), string(name), t)
+	logger.Debug("Propagating", "index", index, "sockets", len(all), "name", string(name))
 
 	chans := make(chan *advanced_connection_types.AdvancedConnectionReply, len(all)+1)
 	for i, conn := range all {
@@ -117,7 +119,7 @@ func (this *websocketsType) BroadcastAwaitAnswer(name, data []byte, consensusTyp
 	for i := range all {
 		out[i] = <-chans
 		if out[i] != nil && out[i].Err != nil {
-			gui.GUI.Error("Error propagating", index, out[i].Err, len(all), string(name), all[i].RemoteAddr, all[i].UUID, time.Now().Unix()-t)
+			logger.Warn("Error propagating", "index", index, "name", string(name), "remoteAddr", all[i].RemoteAddr, "uuid", all[i].UUID, "duration", time.Now().Unix()-t, "err", out[i].Err)
 		}
 	}
 
@@ -133,6 +135,22 @@ func (this *websocketsType) BroadcastJSONAwaitAnswer(name []byte, data interface
 	out, _ := msgpack.Marshal(data)
 	return this.BroadcastAwaitAnswer(name, out, consensusTypeAccepted, exceptSocketUUID, ctx, ctxDuration)
 }
+
+// NewSubscriptions creates the subscriptions for a subscriber which is not a websocket, like an SSE stream
+func (this *websocketsType) NewSubscriptions(subscriber connection.Subscriber) (*connection.Subscriptions, error) {
+	if !network_config.NETWORK_ENABLE_SUBSCRIPTIONS {
+		return nil, errors.New("Subscriptions are disabled")
+	}
+	return connection.NewSubscriptions(subscriber, this.subscriptions.newSubscriptionCn, this.subscriptions.removeSubscriptionCn), nil
+}
+
+// ClosedSubscriber removes all the subscriptions of a subscriber created via NewSubscriptions
+func (this *websocketsType) ClosedSubscriber(subscriber connection.Subscriber) {
+	if network_config.NETWORK_ENABLE_SUBSCRIPTIONS {
+		this.subscriptions.websocketClosedCn <- subscriber
+	}
+}
+
 func (this *websocketsType) closedConnection(conn *connection.AdvancedConnection) {
 
 	if conn.KnownNode != nil {
@@ -2,6 +2,7 @@ package websocks
 
 import (
 	"pandora-pay/blockchain"
+	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/helpers/recovery"
@@ -11,28 +12,35 @@ import (
 	"pandora-pay/network/network_config"
 	"pandora-pay/network/websocks/connection"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"strconv"
 )
 
 type WebsocketSubscriptions struct {
-	websocketClosedCn                 chan *connection.AdvancedConnection
+	websocketClosedCn                 chan connection.Subscriber
 	newSubscriptionCn                 chan *connection.SubscriptionNotification
 	removeSubscriptionCn              chan *connection.SubscriptionNotification
 	accountsSubscriptions             map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	accountsTransactionsSubscriptions map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	assetsSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	transactionsSubscriptions         map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	blocksSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	mempoolSubscriptions              map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	conditionalPaymentsSubscriptions  map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 }
 
 func newWebsocketSubscriptions() (subs *WebsocketSubscriptions) {
 
 	subs = &WebsocketSubscriptions{
-		make(chan *connection.AdvancedConnection),
+		make(chan connection.Subscriber),
 		make(chan *connection.SubscriptionNotification),
 		make(chan *connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 	}
 
 	if network_config.NETWORK_ENABLE_SUBSCRIPTIONS {
@@ -101,18 +109,24 @@ func (this *WebsocketSubscriptions) getSubsMap(subscriptionType api_code_types.S
 		subsMap = this.assetsSubscriptions
 	case api_code_types.SUBSCRIPTION_TRANSACTION:
 		subsMap = this.transactionsSubscriptions
+	case api_code_types.SUBSCRIPTION_BLOCKS:
+		subsMap = this.blocksSubscriptions
+	case api_code_types.SUBSCRIPTION_MEMPOOL:
+		subsMap = this.mempoolSubscriptions
+	case api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT:
+		subsMap = this.conditionalPaymentsSubscriptions
 	}
 	return
 }
 
-func (this *WebsocketSubscriptions) removeConnection(conn *connection.AdvancedConnection, subscriptionType api_code_types.SubscriptionType) {
+func (this *WebsocketSubscriptions) removeConnection(conn connection.Subscriber, subscriptionType api_code_types.SubscriptionType) {
 
 	subsMap := this.getSubsMap(subscriptionType)
 
 	var deleted []string
 	for key, value := range subsMap {
-		if value[conn.UUID] != nil {
-			delete(value, conn.UUID)
+		if value[conn.GetUUID()] != nil {
+			delete(value, conn.GetUUID())
 		}
 		if len(value) == 0 {
 			deleted = append(deleted, key)
@@ -131,6 +145,9 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 	updateTransactionsCn := blockchain.Blockchain.UpdateSocketsSubscriptionsTransactions.AddListener()
 	defer blockchain.Blockchain.UpdateSocketsSubscriptionsTransactions.RemoveChannel(updateTransactionsCn)
 
+	updateBlocksCn := blockchain.Blockchain.UpdateSocketsSubscriptionsBlocks.AddListener()
+	defer blockchain.Blockchain.UpdateSocketsSubscriptionsBlocks.RemoveChannel(updateBlocksCn)
+
 	updateMempoolTransactionsCn := mempool.Mempool.Txs.UpdateMempoolTransactions.AddListener()
 	defer mempool.Mempool.Txs.UpdateMempoolTransactions.RemoveChannel(updateMempoolTransactionsCn)
 
@@ -149,7 +166,7 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			if subsMap[keyStr] == nil {
 				subsMap[keyStr] = make(map[advanced_connection_types.UUID]*connection.SubscriptionNotification)
 			}
-			subsMap[keyStr][subscription.Conn.UUID] = subscription
+			subsMap[keyStr][subscription.Conn.GetUUID()] = subscription
 
 		case subscription := <-this.removeSubscriptionCn:
 
@@ -159,7 +176,7 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 
 			keyStr := string(subscription.Subscription.Key)
 			if subsMap[keyStr] != nil {
-				delete(subsMap[keyStr], subscription.Conn.UUID)
+				delete(subsMap[keyStr], subscription.Conn.GetUUID())
 				if len(subsMap[keyStr]) == 0 {
 					delete(subsMap, keyStr)
 				}
@@ -228,6 +245,36 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 				}
 			}
 
+			for _, conditionalPayments := range dataStorage.ConditionalPaymentsCollection.GetAllMaps() {
+				for k, v := range conditionalPayments.HashMap.Committed {
+
+					if v.Stored == "view" || len(k) <= cryptography.HashSize+1 {
+						continue
+					}
+
+					payloadIndex, err := strconv.Atoi(k[cryptography.HashSize+1:])
+					if err != nil {
+						continue
+					}
+
+					key := append([]byte(k[:cryptography.HashSize]), byte(payloadIndex))
+					if list := this.conditionalPaymentsSubscriptions[string(key)]; list != nil {
+
+						var index uint64
+						var element helpers.SerializableInterface
+						if v.Element != nil { //nil when the payment expired
+							index = v.Element.GetIndex()
+							element = v.Element
+						}
+
+						this.send(api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT, []byte("sub/notify"), key, list, element, nil, &api_types.APISubscriptionNotificationConditionalPaymentExtra{
+							conditionalPayments.BlockHeight,
+							index,
+						})
+					}
+				}
+			}
+
 		case txsUpdates, ok := <-updateTransactionsCn:
 			if !ok {
 				return
@@ -253,25 +300,58 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 				}
 			}
 
+		case blocksUpdate, ok := <-updateBlocksCn:
+			if !ok {
+				return
+			}
+
+			if list := this.blocksSubscriptions[""]; list != nil {
+
+				extra := &api_types.APISubscriptionNotificationBlocksExtra{
+					blocksUpdate.Height,
+					blocksUpdate.Hash,
+					make([]*api_types.APISubscriptionNotificationBlock, len(blocksUpdate.InsertedBlocks)),
+					uint64(len(blocksUpdate.RemovedBlocksHashes)),
+					make([]helpers.Base64, len(blocksUpdate.RemovedBlocksHashes)),
+				}
+				for i, blk := range blocksUpdate.InsertedBlocks {
+					extra.Inserted[i] = &api_types.APISubscriptionNotificationBlock{blk.Height, blk.Hash, blk.Timestamp, blk.TxsCount}
+				}
+				for i, hash := range blocksUpdate.RemovedBlocksHashes {
+					extra.RemovedHashes[i] = hash
+				}
+
+				this.send(api_code_types.SUBSCRIPTION_BLOCKS, []byte("sub/notify"), blocksUpdate.Hash, list, nil, nil, extra)
+			}
+
 		case txUpdate, ok := <-updateMempoolTransactionsCn:
 			if !ok {
 				return
 			}
 
+			var replacedBy []byte
+			if txUpdate.ReplacedBy != nil {
+				replacedBy = txUpdate.ReplacedBy.Bloom.Hash
+			}
+
 			for key := range txUpdate.Keys {
 				if list := this.accountsTransactionsSubscriptions[key]; list != nil {
 					this.send(api_code_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS, []byte("sub/notify"), []byte(key), list, nil, txUpdate.Tx.Bloom.Hash, &api_types.APISubscriptionNotificationAccountTxExtra{
-						Mempool: &api_types.APISubscriptionNotificationAccountTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification},
+						Mempool: &api_types.APISubscriptionNotificationAccountTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.Evicted, replacedBy},
 					})
 				}
 			}
 
 			if list := this.transactionsSubscriptions[txUpdate.Tx.Bloom.HashStr]; list != nil {
 				this.send(api_code_types.SUBSCRIPTION_TRANSACTION, []byte("sub/notify"), txUpdate.Tx.Bloom.Hash, list, nil, nil, &api_types.APISubscriptionNotificationTxExtra{
-					Mempool: &api_types.APISubscriptionNotificationTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification},
+					Mempool: &api_types.APISubscriptionNotificationTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.Evicted, replacedBy},
 				})
 			}
 
+			if list := this.mempoolSubscriptions[""]; list != nil {
+				this.send(api_code_types.SUBSCRIPTION_MEMPOOL, []byte("sub/notify"), txUpdate.Tx.Bloom.Hash, list, nil, nil, &api_types.APISubscriptionNotificationTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.Evicted, replacedBy})
+			}
+
 		case conn, ok := <-this.websocketClosedCn:
 			if !ok {
 				return
@@ -281,6 +361,9 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS)
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_ASSET)
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_TRANSACTION)
+			this.removeConnection(conn, api_code_types.SUBSCRIPTION_BLOCKS)
+			this.removeConnection(conn, api_code_types.SUBSCRIPTION_MEMPOOL)
+			this.removeConnection(conn, api_code_types.SUBSCRIPTION_CONDITIONAL_PAYMENT)
 
 		}
 
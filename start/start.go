@@ -114,8 +114,8 @@ func StartMainNow() (err error) {
 		}()
 	}
 
-	wallet.Wallet.InitializeWallet(blockchain.Blockchain.UpdateNewChainUpdate)
-	if err = wallet.Wallet.StartWallet(); err != nil {
+	wallet.Wallets.InitializeWallets(blockchain.Blockchain.UpdateNewChainUpdate)
+	if err = wallet.Wallets.StartWallets(); err != nil {
 		return
 	}
 
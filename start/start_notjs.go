@@ -4,9 +4,16 @@
 package start
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"pandora-pay/app"
+	"pandora-pay/blockchain"
+	"pandora-pay/config/arguments"
 	"pandora-pay/gui"
+	"pandora-pay/gui/gui_script"
 	"pandora-pay/helpers/recovery"
 )
 
@@ -26,12 +33,57 @@ func saveError(err error) {
 	panic(err)
 }
 
+// runCommand executes "pandorapay cmd <name>", prints the result as JSON on stdout and exits
+func runCommand(startErr error) {
+
+	name := arguments.Arguments["<name>"].(string)
+
+	var result *gui_script.ScriptResult
+	if startErr != nil {
+		result = &gui_script.ScriptResult{name, []string{}, startErr.Error()}
+	} else if script, ok := gui.GUI.(*gui_script.GUIScript); !ok {
+		result = &gui_script.ScriptResult{name, []string{}, "GUI is not scriptable"}
+	} else {
+
+		if arguments.Arguments["--cmd-wait-sync"] == true {
+			syncCn := blockchain.Blockchain.Sync.UpdateSyncMulticast.AddListener()
+			for !blockchain.Blockchain.Sync.GetSyncData().Sync {
+				<-syncCn
+			}
+			blockchain.Blockchain.Sync.UpdateSyncMulticast.RemoveChannel(syncCn)
+		}
+
+		result = script.Execute(name, context.Background())
+	}
+
+	if startErr == nil {
+		app.Close()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+
+	if result.Error != "" {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
 func startMain() {
 
+	err := errors.New("Initialization failed") //kept when StartMainNow panics
 	recovery.Safe(func() {
-		if err := StartMainNow(); err != nil {
+		if err = StartMainNow(); err != nil {
 			gui.GUI.Error(err)
 		}
 	})
 
+	if arguments.Arguments["cmd"] == true {
+		runCommand(err)
+	}
+
 }
@@ -7,6 +7,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -359,6 +360,10 @@ func (hashMap *HashMap[T]) CommitChanges() (err error) {
 					hashMap.Tx.Delete(hashMap.name + ":map:" + k)
 					hashMap.Tx.Delete(hashMap.name + ":exists:" + k)
 
+					if err = state_tree.Update(hashMap.Tx, hashMap.name+":map:"+k, nil); err != nil {
+						return
+					}
+
 					if hashMap.Indexable && v.indexProcess {
 						hashMap.Tx.Delete(hashMap.name + ":list:" + strconv.FormatUint(v.index, 10))
 						hashMap.Tx.Delete(hashMap.name + ":listKeys:" + k)
@@ -423,6 +428,10 @@ func (hashMap *HashMap[T]) CommitChanges() (err error) {
 			if hashMap.Tx.IsWritable() {
 				//clone required because the element could change later on
 				hashMap.Tx.Put(hashMap.name+":map:"+k, committed.serialized)
+
+				if err = state_tree.Update(hashMap.Tx, hashMap.name+":map:"+k, committed.serialized); err != nil {
+					return
+				}
 			}
 
 			committed.Status = "view"
@@ -0,0 +1,284 @@
+package state_tree
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+// Compact Sparse Merkle Tree over the hash maps stored in the blockchain store.
+// A subtree without elements hashes to EmptyHash, a subtree with a single element hashes to the leaf hash and any
+// other subtree hashes to SHA3( 1 | left | right ). The root depends only on the stored elements, not on the order
+// they were inserted or removed, so reverting the hash maps during a reorg reverts the root as well.
+
+const TREE_DEPTH = cryptography.HashSize * 8
+
+const (
+	nodeLeaf     byte = 0
+	nodeInternal byte = 1
+)
+
+var EmptyHash = make([]byte, cryptography.HashSize)
+
+type node struct {
+	leaf      bool
+	hash      []byte
+	keyHash   []byte //only leaf
+	valueHash []byte //only leaf
+}
+
+func ComputeLeafHash(keyHash, valueHash []byte) []byte {
+	return cryptography.SHA3(append(append([]byte{nodeLeaf}, keyHash...), valueHash...))
+}
+
+func computeInternalHash(left, right []byte) []byte {
+	return cryptography.SHA3(append(append([]byte{nodeInternal}, left...), right...))
+}
+
+func getBit(keyHash []byte, index int) byte {
+	return (keyHash[index/8] >> (7 - uint(index%8))) & 1
+}
+
+// getNodeKey returns the store key of the node found at depth on the path of keyHash
+func getNodeKey(keyHash []byte, depth int) string {
+
+	prefix := make([]byte, (depth+7)/8)
+	copy(prefix, keyHash)
+
+	if depth%8 != 0 {
+		prefix[len(prefix)-1] &= byte(0xFF) << (8 - uint(depth%8))
+	}
+
+	return "stateTree:" + strconv.Itoa(depth) + ":" + string(prefix)
+}
+
+// getChildKey returns the store key of the child (selected by bit) of the node found at depth on the path of keyHash
+func getChildKey(keyHash []byte, depth int, bit byte) string {
+
+	path := make([]byte, cryptography.HashSize)
+	copy(path, keyHash)
+
+	mask := byte(1) << (7 - uint(depth%8))
+	if bit == 1 {
+		path[depth/8] |= mask
+	} else {
+		path[depth/8] &^= mask
+	}
+
+	return getNodeKey(path, depth+1)
+}
+
+func readNode(reader store_db_interface.StoreDBTransactionInterface, key string) (*node, error) {
+
+	data := reader.Get(key)
+	if data == nil {
+		return nil, nil
+	}
+
+	switch {
+	case len(data) == 1+cryptography.HashSize && data[0] == nodeInternal:
+		return &node{false, data[1:], nil, nil}, nil
+	case len(data) == 1+2*cryptography.HashSize && data[0] == nodeLeaf:
+		keyHash, valueHash := data[1:1+cryptography.HashSize], data[1+cryptography.HashSize:]
+		return &node{true, ComputeLeafHash(keyHash, valueHash), keyHash, valueHash}, nil
+	default:
+		return nil, errors.New("State tree node is invalid")
+	}
+}
+
+func writeNode(writer store_db_interface.StoreDBTransactionInterface, key string, n *node) {
+	if n == nil {
+		writer.Delete(key)
+	} else if n.leaf {
+		writer.Put(key, append(append([]byte{nodeLeaf}, n.keyHash...), n.valueHash...))
+	} else {
+		writer.Put(key, append([]byte{nodeInternal}, n.hash...))
+	}
+}
+
+func nodeHash(n *node) []byte {
+	if n == nil {
+		return EmptyHash
+	}
+	return n.hash
+}
+
+// update sets (or removes if valueHash is nil) the element in the subtree found at depth and returns the new subtree
+func update(writer store_db_interface.StoreDBTransactionInterface, depth int, keyHash, valueHash []byte) (*node, error) {
+
+	if depth > TREE_DEPTH {
+		return nil, errors.New("State tree is too deep")
+	}
+
+	key := getNodeKey(keyHash, depth)
+
+	current, err := readNode(writer, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil || current.leaf {
+
+		var newNode *node
+
+		switch {
+		case current == nil || bytes.Equal(current.keyHash, keyHash):
+			if valueHash != nil {
+				newNode = &node{true, ComputeLeafHash(keyHash, valueHash), keyHash, valueHash}
+			}
+		case valueHash == nil: //the element doesn't exist
+			return current, nil
+		default:
+			//split the leaf by moving it one level down
+			writeNode(writer, getChildKey(current.keyHash, depth, getBit(current.keyHash, depth)), current)
+			writer.Delete(key)
+			return updateInternal(writer, depth, key, keyHash, valueHash)
+		}
+
+		writeNode(writer, key, newNode)
+		return newNode, nil
+	}
+
+	return updateInternal(writer, depth, key, keyHash, valueHash)
+}
+
+func updateInternal(writer store_db_interface.StoreDBTransactionInterface, depth int, key string, keyHash, valueHash []byte) (*node, error) {
+
+	bit := getBit(keyHash, depth)
+
+	child, err := update(writer, depth+1, keyHash, valueHash)
+	if err != nil {
+		return nil, err
+	}
+
+	siblingKey := getChildKey(keyHash, depth, 1-bit)
+	sibling, err := readNode(writer, siblingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var newNode *node
+
+	switch {
+	case child == nil && sibling == nil:
+	case child == nil && sibling.leaf: //collapse the leaf one level up
+		writer.Delete(siblingKey)
+		newNode = sibling
+	case sibling == nil && child.leaf:
+		writer.Delete(getChildKey(keyHash, depth, bit))
+		newNode = child
+	default:
+		left, right := nodeHash(child), nodeHash(sibling)
+		if bit == 1 {
+			left, right = right, left
+		}
+		newNode = &node{false, computeInternalHash(left, right), nil, nil}
+	}
+
+	writeNode(writer, key, newNode)
+	return newNode, nil
+}
+
+// Update stores the element identified by the storeKey. A nil value removes the element
+func Update(writer store_db_interface.StoreDBTransactionInterface, storeKey string, value []byte) (err error) {
+
+	var valueHash []byte
+	if value != nil {
+		valueHash = cryptography.SHA3(value)
+	}
+
+	_, err = update(writer, 0, cryptography.SHA3([]byte(storeKey)), valueHash)
+	return
+}
+
+func GetRoot(reader store_db_interface.StoreDBTransactionInterface) ([]byte, error) {
+	root, err := readNode(reader, getNodeKey(nil, 0))
+	if err != nil {
+		return nil, err
+	}
+	return nodeHash(root), nil
+}
+
+type StateProof struct {
+	Siblings      [][]byte `json:"siblings" msgpack:"siblings"`                               //from the root down to the element
+	LeafKeyHash   []byte   `json:"leafKeyHash,omitempty" msgpack:"leafKeyHash,omitempty"`     //only for missing elements, the leaf found instead
+	LeafValueHash []byte   `json:"leafValueHash,omitempty" msgpack:"leafValueHash,omitempty"` //only for missing elements, the leaf found instead
+}
+
+// GetProof returns the inclusion proof of the element identified by storeKey or the proof that it is missing
+func GetProof(reader store_db_interface.StoreDBTransactionInterface, storeKey string) (*StateProof, error) {
+
+	keyHash := cryptography.SHA3([]byte(storeKey))
+	proof := &StateProof{Siblings: [][]byte{}}
+
+	for depth := 0; depth <= TREE_DEPTH; depth++ {
+
+		n, err := readNode(reader, getNodeKey(keyHash, depth))
+		if err != nil {
+			return nil, err
+		}
+
+		if n == nil {
+			return proof, nil
+		}
+
+		if n.leaf {
+			if !bytes.Equal(n.keyHash, keyHash) {
+				proof.LeafKeyHash = n.keyHash
+				proof.LeafValueHash = n.valueHash
+			}
+			return proof, nil
+		}
+
+		sibling, err := readNode(reader, getChildKey(keyHash, depth, 1-getBit(keyHash, depth)))
+		if err != nil {
+			return nil, err
+		}
+		proof.Siblings = append(proof.Siblings, nodeHash(sibling))
+	}
+
+	return nil, errors.New("State tree is too deep")
+}
+
+// VerifyProof verifies that the element identified by storeKey has the given value. A nil value verifies that the element is missing
+func VerifyProof(root []byte, storeKey string, value []byte, proof *StateProof) bool {
+
+	if proof == nil || len(proof.Siblings) > TREE_DEPTH {
+		return false
+	}
+
+	keyHash := cryptography.SHA3([]byte(storeKey))
+
+	var hash []byte
+	switch {
+	case value != nil:
+		hash = ComputeLeafHash(keyHash, cryptography.SHA3(value))
+	case proof.LeafKeyHash != nil:
+		if len(proof.LeafKeyHash) != cryptography.HashSize || len(proof.LeafValueHash) != cryptography.HashSize || bytes.Equal(proof.LeafKeyHash, keyHash) {
+			return false
+		}
+		for i := range proof.Siblings {
+			if getBit(proof.LeafKeyHash, i) != getBit(keyHash, i) {
+				return false
+			}
+		}
+		hash = ComputeLeafHash(proof.LeafKeyHash, proof.LeafValueHash)
+	default:
+		hash = EmptyHash
+	}
+
+	for i := len(proof.Siblings) - 1; i >= 0; i-- {
+		if len(proof.Siblings[i]) != cryptography.HashSize {
+			return false
+		}
+		if getBit(keyHash, i) == 0 {
+			hash = computeInternalHash(hash, proof.Siblings[i])
+		} else {
+			hash = computeInternalHash(proof.Siblings[i], hash)
+		}
+	}
+
+	return bytes.Equal(hash, root)
+}
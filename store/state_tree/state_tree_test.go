@@ -0,0 +1,91 @@
+package state_tree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+func TestStateTree(t *testing.T) {
+
+	count := 200
+	keys := make([]string, count)
+	values := make([][]byte, count)
+	for i := range keys {
+		keys[i] = "accounts:map:" + strconv.Itoa(i)
+		values[i] = helpers.RandomBytes(rand.Intn(100) + 1)
+	}
+
+	store1, _ := store_db_memory.CreateStoreDBMemory("test1")
+	store2, _ := store_db_memory.CreateStoreDBMemory("test2")
+
+	var root1, root2 []byte
+
+	err := store1.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for i := range keys {
+			if err = Update(writer, keys[i], values[i]); err != nil {
+				return
+			}
+		}
+		root1, err = GetRoot(writer)
+		return
+	})
+	assert.NoError(t, err)
+
+	err = store2.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		//insert in a different order and add some elements that will be removed
+		for _, i := range rand.Perm(count) {
+			if err = Update(writer, keys[i], helpers.RandomBytes(10)); err != nil {
+				return
+			}
+			if err = Update(writer, "extra:map:"+keys[i], values[i]); err != nil {
+				return
+			}
+		}
+		for _, i := range rand.Perm(count) {
+			if err = Update(writer, keys[i], values[i]); err != nil {
+				return
+			}
+			if err = Update(writer, "extra:map:"+keys[i], nil); err != nil {
+				return
+			}
+		}
+		root2, err = GetRoot(writer)
+		return
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root1, root2, "The root depends on the order")
+
+	err = store1.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		for i := range keys {
+			proof, err := GetProof(reader, keys[i])
+			assert.NoError(t, err)
+			assert.True(t, VerifyProof(root1, keys[i], values[i], proof), "Proof should be valid")
+			assert.False(t, VerifyProof(root1, keys[i], helpers.RandomBytes(10), proof), "Proof should be invalid for a different value")
+			assert.False(t, VerifyProof(root1, keys[i], nil, proof), "Element should not be missing")
+		}
+
+		proof, err := GetProof(reader, "missing")
+		assert.NoError(t, err)
+		assert.True(t, VerifyProof(root1, "missing", nil, proof), "Missing proof should be valid")
+		return
+	})
+	assert.NoError(t, err)
+
+	err = store1.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for _, i := range rand.Perm(count) {
+			if err = Update(writer, keys[i], nil); err != nil {
+				return
+			}
+		}
+		root1, err = GetRoot(writer)
+		return
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, EmptyHash, root1, "The tree should be empty")
+}
@@ -36,3 +36,12 @@ func (tx *StoreDBBoltTransaction) Exists(key string) bool {
 func (tx *StoreDBBoltTransaction) Delete(key string) {
 	tx.bucket.Delete([]byte(key))
 }
+
+func (tx *StoreDBBoltTransaction) Range(callback func(key string, value []byte) bool) {
+	cursor := tx.bucket.Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if !callback(string(k), helpers.CloneBytes(v)) {
+			return
+		}
+	}
+}
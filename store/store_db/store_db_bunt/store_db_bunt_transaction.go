@@ -45,3 +45,11 @@ func (tx *StoreDBBuntTransaction) Delete(key string) {
 		panic(err)
 	}
 }
+
+func (tx *StoreDBBuntTransaction) Range(callback func(key string, value []byte) bool) {
+	if err := tx.buntTx.Ascend("", func(key, value string) bool {
+		return callback(key, []byte(value))
+	}); err != nil {
+		panic(err)
+	}
+}
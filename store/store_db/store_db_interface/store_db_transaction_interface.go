@@ -7,3 +7,8 @@ type StoreDBTransactionInterface interface {
 	Delete(key string)
 	IsWritable() bool
 }
+
+// StoreDBIterableTransactionInterface is implemented by the stores which are able to enumerate all their keys
+type StoreDBIterableTransactionInterface interface {
+	Range(callback func(key string, value []byte) bool)
+}
@@ -78,3 +78,26 @@ func (tx *StoreDBMemoryTransaction) writeTx() error {
 
 	return nil
 }
+
+func (tx *StoreDBMemoryTransaction) Range(callback func(key string, value []byte) bool) {
+
+	for key, value := range tx.store {
+		if data, ok := tx.local.Load(key); ok && data.operation != "get" {
+			if data.operation == "del" {
+				continue
+			}
+			value = data.value
+		}
+		if !callback(key, helpers.CloneBytes(value)) {
+			return
+		}
+	}
+
+	stop := false
+	tx.local.Range(func(key string, data *StoreDBMemoryTransactionData) bool {
+		if _, ok := tx.store[key]; !ok && data.operation == "put" {
+			stop = !callback(key, helpers.CloneBytes(data.value))
+		}
+		return !stop
+	})
+}
@@ -48,13 +48,13 @@ func (builder *TxsBuilderType) convertFloatAmounts(amounts []float64, ast *asset
 	return amountsFinal, nil
 }
 
-func (builder *TxsBuilderType) getWalletAddresses(senders []string) ([]*wallet_address.WalletAddress, error) {
+func (builder *TxsBuilderType) getWalletAddresses(walletName string, senders []string) ([]*wallet_address.WalletAddress, error) {
 
 	sendersWalletAddress := make([]*wallet_address.WalletAddress, len(senders))
 	var err error
 
 	for i, senderAddress := range senders {
-		if sendersWalletAddress[i], err = wallet.Wallet.GetWalletAddressByEncodedAddress(senderAddress, true); err != nil {
+		if sendersWalletAddress[i], err = wallet.Wallets.GetWalletAddress(walletName, senderAddress); err != nil {
 			return nil, err
 		}
 		if sendersWalletAddress[i].PrivateKey == nil {
@@ -68,7 +68,7 @@ func (builder *TxsBuilderType) getWalletAddresses(senders []string) ([]*wallet_a
 func (builder *TxsBuilderType) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propagateTx, awaitAnswer, awaitBroadcast, validateTx bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
 
 	if txData.Data == nil {
-		txData.Data = &wizard.WizardTransactionData{nil, false}
+		txData.Data = &wizard.WizardTransactionData{nil, false, nil, nil}
 	}
 	if txData.Fee == nil {
 		txData.Fee = &wizard.WizardTransactionFee{0, 0, 0, true}
@@ -77,7 +77,7 @@ func (builder *TxsBuilderType) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, p
 	var sendersWalletAddresses []*wallet_address.WalletAddress
 	var err error
 	if txData.Sender != "" {
-		if sendersWalletAddresses, err = builder.getWalletAddresses([]string{txData.Sender}); err != nil {
+		if sendersWalletAddresses, err = builder.getWalletAddresses(txData.Wallet, []string{txData.Sender}); err != nil {
 			return nil, err
 		}
 	}
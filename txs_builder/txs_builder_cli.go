@@ -44,6 +44,28 @@ func (builder *TxsBuilderType) readData() (out *wizard.WizardTransactionData) {
 	return data
 }
 
+func (builder *TxsBuilderType) readSimpleData() (out *wizard.WizardTransactionData, err error) {
+
+	out = builder.readData()
+
+	if out.Encrypt {
+		var addr *addresses.Address
+		if addr, err = builder.readAddress("Recipient address to encrypt the message", false); err != nil {
+			return
+		}
+		out.PublicKeyToEncrypt = addr.PublicKey
+
+		if addr, err = builder.readAddress("Sender address for a copy of the message. Leave empty for none", true); err != nil {
+			return
+		}
+		if addr != nil {
+			out.SenderPublicKeyToEncrypt = addr.PublicKey
+		}
+	}
+
+	return
+}
+
 func (builder *TxsBuilderType) readAmount(assetId []byte, text string) (amount uint64, err error) {
 
 	amountFloat := gui.GUI.OutputReadFloat64(text, false, 0, nil)
@@ -193,10 +215,11 @@ func (builder *TxsBuilderType) initCLI() {
 		builder.showWarningIfNotSyncCLI()
 
 		txData := &TxBuilderCreateZetherTxData{
+			Wallet:   wallet.Wallets.GetSelected().GetName(),
 			Payloads: []*TxBuilderCreateZetherTxPayload{{}},
 		}
 
-		if _, txData.Payloads[0].Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address to Transfer", ctx); err != nil {
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address to Transfer", ctx); err != nil {
 			return
 		}
 
@@ -227,13 +250,14 @@ func (builder *TxsBuilderType) initCLI() {
 
 		extra := &wizard.WizardZetherPayloadExtraAssetCreate{}
 		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
 			Payloads: []*TxBuilderCreateZetherTxPayload{{
 				Extra: extra,
 				Asset: config_coins.NATIVE_ASSET_FULL,
 			}},
 		}
 
-		if _, txData.Payloads[0].Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address which will create the asset", ctx); err != nil {
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address which will create the asset", ctx); err != nil {
 			return
 		}
 
@@ -305,13 +329,14 @@ func (builder *TxsBuilderType) initCLI() {
 
 		extra := &wizard.WizardZetherPayloadExtraAssetSupplyIncrease{}
 		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
 			Payloads: []*TxBuilderCreateZetherTxPayload{{
 				Extra: extra,
 				Asset: config_coins.NATIVE_ASSET_FULL,
 			}},
 		}
 
-		if _, txData.Payloads[0].Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address which will increase the supply of asset", ctx); err != nil {
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address which will increase the supply of asset", ctx); err != nil {
 			return
 		}
 
@@ -348,18 +373,159 @@ func (builder *TxsBuilderType) initCLI() {
 		return
 	}
 
+	cliPrivateAssetSupplyDecrease := func(cmd string, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		extra := &wizard.WizardZetherPayloadExtraAssetSupplyDecrease{}
+		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+			}},
+		}
+
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address which will burn the asset", ctx); err != nil {
+			return
+		}
+
+		txData.Payloads[0].Asset = builder.readAsset("Asset", false)
+
+		extra.AssetSupplyPrivateKey = gui.GUI.OutputReadBytes("Asset Supply Private Key", func(value []byte) bool {
+			return len(value) == cryptography.PrivateKeySize
+		})
+
+		if txData.Payloads[0].Burn, err = builder.readAmount(txData.Payloads[0].Asset, "Burn Amount"); err != nil {
+			return
+		}
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Transfer Address", txData.Payloads[0].Asset, true); err != nil {
+			return
+		}
+
+		builder.readZetherRingConfiguration(txData.Payloads[0])
+		txData.Payloads[0].Data = builder.readData()
+		txData.Payloads[0].Fee = builder.readZetherFee(txData.Payloads[0].Asset)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+
+		return
+	}
+
+	//used by all asset commands signed by the Asset Update Private Key
+	cliPrivateAssetUpdateTx := func(cmd string, extra wizard.WizardZetherPayloadExtra, readExtra func() error, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+				Asset: config_coins.NATIVE_ASSET_FULL,
+			}},
+		}
+
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address which will pay the fee", ctx); err != nil {
+			return
+		}
+
+		if err = readExtra(); err != nil {
+			return
+		}
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Transfer Address", config_coins.NATIVE_ASSET_FULL, true); err != nil {
+			return
+		}
+
+		builder.readZetherRingConfiguration(txData.Payloads[0])
+		txData.Payloads[0].Data = builder.readData()
+		txData.Payloads[0].Fee = builder.readZetherFee(config_coins.NATIVE_ASSET_FULL)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+
+		return
+	}
+
+	readAssetUpdatePrivateKey := func() []byte {
+		return gui.GUI.OutputReadBytes("Asset Update Private Key", func(value []byte) bool {
+			return len(value) == cryptography.PrivateKeySize
+		})
+	}
+
+	cliPrivateAssetPause := func(cmd string, ctx context.Context) (err error) {
+		extra := &wizard.WizardZetherPayloadExtraAssetPause{}
+		return cliPrivateAssetUpdateTx(cmd, extra, func() error {
+			extra.AssetId = builder.readAsset("Asset", false)
+			extra.AssetUpdatePrivateKey = readAssetUpdatePrivateKey()
+			extra.Paused = gui.GUI.OutputReadBool("Pause? y - pause, n - unpause", false, false)
+			return nil
+		}, ctx)
+	}
+
+	cliPrivateAssetFreeze := func(cmd string, ctx context.Context) (err error) {
+		extra := &wizard.WizardZetherPayloadExtraAssetFreeze{}
+		return cliPrivateAssetUpdateTx(cmd, extra, func() error {
+			extra.AssetId = builder.readAsset("Asset", false)
+			extra.AssetUpdatePrivateKey = readAssetUpdatePrivateKey()
+			if !gui.GUI.OutputReadBool("Freezing the supply can not be reverted. Are you sure? y/n", false, false) {
+				return errors.New("Freeze was canceled")
+			}
+			return nil
+		}, ctx)
+	}
+
+	cliPrivateAssetChangePublicKey := func(cmd string, ctx context.Context) (err error) {
+		extra := &wizard.WizardZetherPayloadExtraAssetChangePublicKey{}
+		return cliPrivateAssetUpdateTx(cmd, extra, func() error {
+			extra.AssetId = builder.readAsset("Asset", false)
+			extra.AssetUpdatePrivateKey = readAssetUpdatePrivateKey()
+			extra.ChangeSupplyPublicKey = gui.GUI.OutputReadBool("Key to change: y - Supply Public Key, n - Update Public Key", false, false)
+			extra.NewPublicKey = gui.GUI.OutputReadBytes("New Public Key", func(value []byte) bool {
+				return len(value) == cryptography.PublicKeySize
+			})
+			return nil
+		}, ctx)
+	}
+
+	cliPrivateAssetUpdate := func(cmd string, ctx context.Context) (err error) {
+		extra := &wizard.WizardZetherPayloadExtraAssetUpdate{}
+		return cliPrivateAssetUpdateTx(cmd, extra, func() error {
+			extra.AssetId = builder.readAsset("Asset", false)
+			extra.AssetUpdatePrivateKey = readAssetUpdatePrivateKey()
+			extra.Name = gui.GUI.OutputReadString("New Name")
+			extra.Description = gui.GUI.OutputReadString("New Description. Leave empty for none")
+			extra.Data = gui.GUI.OutputReadBytes("New Data. Leave empty for none", nil)
+			return asset.ValidateDetails(extra.Name, extra.Description, extra.Data)
+		}, ctx)
+	}
+
 	cliPrivatePlainAccountFund := func(cmd string, ctx context.Context) (err error) {
 		builder.showWarningIfNotSyncCLI()
 
 		extra := &wizard.WizardZetherPayloadExtraPlainAccountFund{}
 		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
 			Payloads: []*TxBuilderCreateZetherTxPayload{{
 				Extra: extra,
 				Asset: config_coins.NATIVE_ASSET_FULL,
 			}},
 		}
 
-		if _, txData.Payloads[0].Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address which will fund a plain account", ctx); err != nil {
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address which will fund a plain account", ctx); err != nil {
 			return
 		}
 
@@ -397,12 +563,13 @@ func (builder *TxsBuilderType) initCLI() {
 
 		extra := &wizard.WizardZetherPayloadExtraConditionalPayment{}
 		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
 			Payloads: []*TxBuilderCreateZetherTxPayload{{
 				Extra: extra,
 			}, {}},
 		}
 
-		if _, txData.Payloads[0].Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address to Transfer", ctx); err != nil {
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address to Transfer", ctx); err != nil {
 			return
 		}
 		txData.Payloads[1].Sender = txData.Payloads[0].Sender
@@ -475,17 +642,90 @@ func (builder *TxsBuilderType) initCLI() {
 		return
 	}
 
+	cliPrivateHashlockConditionalPayment := func(cmd string, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		extra := &wizard.WizardZetherPayloadExtraConditionalPaymentHashlock{}
+		txData := &TxBuilderCreateZetherTxData{
+			Wallet: wallet.Wallets.GetSelected().GetName(),
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+			}, {}},
+		}
+
+		if _, txData.Payloads[0].Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address to Transfer", ctx); err != nil {
+			return
+		}
+		txData.Payloads[1].Sender = txData.Payloads[0].Sender
+
+		txData.Payloads[0].Asset = builder.readAsset("Asset. Leave empty for Native Asset", true)
+		txData.Payloads[1].Asset = txData.Payloads[0].Asset
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Recipient Address", txData.Payloads[0].Asset, false); err != nil {
+			return
+		}
+
+		extra.Deadline = gui.GUI.OutputReadUint64("Deadline. After it, the payment is refunded", true, 10, func(val uint64) bool {
+			return val >= 10 && val <= 100000
+		})
+
+		extra.Hashlock = gui.GUI.OutputReadBytes("Hashlock (SHA256 of the preimage). Leave empty to generate a new preimage", func(val []byte) bool {
+			return len(val) == 0 || len(val) == cryptography.HashlockSize
+		})
+		if len(extra.Hashlock) == 0 {
+			preimage := helpers.RandomBytes(cryptography.HashSize)
+			extra.Hashlock = cryptography.SHA256(preimage)
+			gui.GUI.OutputWrite("Preimage. Keep it secret until the payment is claimed", base64.StdEncoding.EncodeToString(preimage))
+			gui.GUI.OutputWrite("Hashlock", base64.StdEncoding.EncodeToString(extra.Hashlock))
+		}
+
+		if _, txData.Payloads[1].Recipient, txData.Payloads[1].Amount, err = builder.readAddressOptional("Transfer Address (optional)", config_coins.NATIVE_ASSET_FULL, true); err != nil {
+			return
+		}
+
+		builder.readZetherRingConfiguration(txData.Payloads[0])
+		if err = builder.presetZetherRing(txData.Payloads[0]); err != nil {
+			return err
+		}
+
+		txData.Payloads[0].RingConfiguration.SenderRingType.AvoidStakedAccounts = true
+		txData.Payloads[0].RingConfiguration.RecipientRingType.AvoidStakedAccounts = true
+
+		txData.Payloads[1].RingSize = txData.Payloads[0].RingSize
+		txData.Payloads[1].RingConfiguration = &ZetherRingConfiguration{
+			&ZetherSenderRingType{false, true, []string{}, 0},
+			&ZetherRecipientRingType{false, true, []string{}, txData.Payloads[0].RingConfiguration.RecipientRingType.NewAccounts},
+		}
+
+		txData.Payloads[0].Data = builder.readData()
+
+		txData.Payloads[0].Fee = builder.readZetherFee(txData.Payloads[0].Asset)
+		txData.Payloads[1].Fee = txData.Payloads[0].Fee
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+		return
+	}
+
 	cliUpdateAssetFeeLiquidity := func(cmd string, ctx context.Context) (err error) {
 
 		builder.showWarningIfNotSyncCLI()
 
 		txExtra := &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{}
 		txData := &TxBuilderCreateSimpleTx{
+			Wallet:     wallet.Wallets.GetSelected().GetName(),
 			Extra:      txExtra,
 			FeeVersion: true,
 		}
 
-		if _, txData.Sender, _, err = wallet.Wallet.CliSelectAddress("Select Address to Publicly Update Asset Fee Liquidity", ctx); err != nil {
+		if _, txData.Sender, _, err = wallet.Wallets.GetSelected().CliSelectAddress("Select Address to Publicly Update Asset Fee Liquidity", ctx); err != nil {
 			return
 		}
 
@@ -513,7 +753,9 @@ func (builder *TxsBuilderType) initCLI() {
 		}
 
 		txData.Nonce = gui.GUI.OutputReadUint64("Nonce. Leave empty for automatically detection", true, 0, nil)
-		txData.Data = builder.readData()
+		if txData.Data, err = builder.readSimpleData(); err != nil {
+			return
+		}
 		txData.Fee = builder.readFee(config_coins.NATIVE_ASSET_FULL)
 
 		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
@@ -538,6 +780,7 @@ func (builder *TxsBuilderType) initCLI() {
 			Signatures:         make([][]byte, 0),
 		}
 		txData := &TxBuilderCreateSimpleTx{
+			Wallet:     wallet.Wallets.GetSelected().GetName(),
 			Extra:      txExtra,
 			Fee:        &wizard.WizardTransactionFee{0, 0, 0, false},
 			FeeVersion: true,
@@ -586,7 +829,51 @@ func (builder *TxsBuilderType) initCLI() {
 		}
 
 		txData.Nonce = 0
-		txData.Data = builder.readData()
+		if txData.Data, err = builder.readSimpleData(); err != nil {
+			return
+		}
+
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateSimpleTx(txData, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+		return
+	}
+
+	cliClaimConditionalPayment := func(cmd string, ctx context.Context) (err error) {
+
+		builder.showWarningIfNotSyncCLI()
+
+		txExtra := &wizard.WizardTxSimpleExtraClaimConditionalPayment{}
+		txData := &TxBuilderCreateSimpleTx{
+			Wallet:     wallet.Wallets.GetSelected().GetName(),
+			Extra:      txExtra,
+			Fee:        &wizard.WizardTransactionFee{0, 0, 0, false},
+			FeeVersion: true,
+		}
+
+		txExtra.TxId = gui.GUI.OutputReadBytes("Provide TxId", func(val []byte) bool {
+			return len(val) == cryptography.HashSize
+		})
+
+		txExtra.PayloadIndex = byte(gui.GUI.OutputReadInt("Payload index", false, 0, func(val int) bool {
+			return val >= 0 && val < 255
+		}))
+
+		txExtra.Preimage = gui.GUI.OutputReadBytes("Preimage", func(val []byte) bool {
+			return len(val) > 0 && len(val) <= cryptography.HashlockPreimageMaxSize
+		})
+
+		txData.Nonce = 0
+		if txData.Data, err = builder.readSimpleData(); err != nil {
+			return
+		}
 
 		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
 
@@ -604,9 +891,16 @@ func (builder *TxsBuilderType) initCLI() {
 	gui.GUI.CommandDefineCallback("Private Transfer", cliPrivateTransfer, true)
 	gui.GUI.CommandDefineCallback("Private Asset Create", cliPrivateAssetCreate, true)
 	gui.GUI.CommandDefineCallback("Private Asset Supply Increase", cliPrivateAssetSupplyIncrease, true)
+	gui.GUI.CommandDefineCallback("Private Asset Supply Decrease", cliPrivateAssetSupplyDecrease, true)
+	gui.GUI.CommandDefineCallback("Private Asset Pause", cliPrivateAssetPause, true)
+	gui.GUI.CommandDefineCallback("Private Asset Freeze Supply", cliPrivateAssetFreeze, true)
+	gui.GUI.CommandDefineCallback("Private Asset Change Public Key", cliPrivateAssetChangePublicKey, true)
+	gui.GUI.CommandDefineCallback("Private Asset Update", cliPrivateAssetUpdate, true)
 	gui.GUI.CommandDefineCallback("Private Plain Account Fund", cliPrivatePlainAccountFund, true)
 	gui.GUI.CommandDefineCallback("Private Conditional Payment", cliPrivateConditionalPayment, true)
+	gui.GUI.CommandDefineCallback("Private Hashlock Conditional Payment", cliPrivateHashlockConditionalPayment, true)
 	gui.GUI.CommandDefineCallback("Public Update Asset Fee Liquidity", cliUpdateAssetFeeLiquidity, true)
 	gui.GUI.CommandDefineCallback("Public Resolution Conditional Payment", cliResolutionConditionalPayment, true)
+	gui.GUI.CommandDefineCallback("Public Claim Conditional Payment", cliClaimConditionalPayment, true)
 
 }
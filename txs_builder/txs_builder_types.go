@@ -9,4 +9,5 @@ type TxBuilderCreateSimpleTx struct {
 	Fee        *wizard.WizardTransactionFee  `json:"fee" msgpack:"fee"`
 	FeeVersion bool                          `json:"feeVersion" msgpack:"feeVersion"`
 	Extra      wizard.WizardTxSimpleExtra    `json:"extra" msgpack:"sender"`
+	Wallet     string                        `json:"wallet,omitempty" msgpack:"wallet,omitempty"` //the sender is searched only in this wallet. Empty for the default wallet
 }
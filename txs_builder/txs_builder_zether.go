@@ -284,7 +284,7 @@ func (builder *TxsBuilderType) prebuild(txData *TxBuilderCreateZetherTxData, pen
 			payload.Asset = config_coins.NATIVE_ASSET_FULL
 		}
 		if payload.Data == nil {
-			payload.Data = &wizard.WizardTransactionData{[]byte{}, false}
+			payload.Data = &wizard.WizardTransactionData{[]byte{}, false, nil, nil}
 		}
 		if payload.RingConfiguration == nil {
 			payload.RingConfiguration = &ZetherRingConfiguration{&ZetherSenderRingType{false, false, nil, 0}, &ZetherRecipientRingType{false, false, nil, 0}}
@@ -305,7 +305,7 @@ func (builder *TxsBuilderType) prebuild(txData *TxBuilderCreateZetherTxData, pen
 
 		} else {
 
-			addr, err := wallet.Wallet.GetWalletAddressByEncodedAddress(payload.Sender, true)
+			addr, err := wallet.Wallets.GetWalletAddress(txData.Wallet, payload.Sender)
 			if err != nil {
 				return nil, nil, nil, nil, nil, nil, 0, nil, err
 			}
@@ -665,6 +665,12 @@ func (builder *TxsBuilderType) CreateForgingTransactions(blkComplete *block_comp
 		return nil, err
 	}
 
+	//the forger can be an address of any wallet of the node
+	forgerWallet, err := wallet.Wallets.GetWalletNameByPublicKey(forgerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
 	chainHeight := blkComplete.Height
 	if chainHeight > 0 {
 		chainHeight--
@@ -709,6 +715,7 @@ func (builder *TxsBuilderType) CreateForgingTransactions(blkComplete *block_comp
 				&wizard.WizardZetherPayloadExtraStakingReward{nil, finalForgerReward},
 			},
 		},
+		Wallet: forgerWallet,
 	}
 
 	transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, publicKeyIndexes, _, _, err := builder.prebuild(txData, pendingTxs, blkComplete.Height, blkComplete.PrevKernelHash, context.Background(), func(string) {})
@@ -38,4 +38,5 @@ type TxBuilderCreateZetherTxPayload struct {
 
 type TxBuilderCreateZetherTxData struct {
 	Payloads []*TxBuilderCreateZetherTxPayload `json:"payloads" msgpack:"payloads"`
+	Wallet   string                            `json:"wallet,omitempty" msgpack:"wallet,omitempty"` //the senders are searched only in this wallet. Empty for the default wallet
 }
@@ -57,6 +57,14 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 		}
 		txBase.TxScript = transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT
 		transfer.Fee = &WizardTransactionFee{0, 0, 0, false}
+	case *WizardTxSimpleExtraClaimConditionalPayment:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraClaimConditionalPayment{nil,
+			txExtra.TxId,
+			txExtra.PayloadIndex,
+			txExtra.Preimage,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT
+		transfer.Fee = &WizardTransactionFee{0, 0, 0, false}
 	}
 
 	var privateKey *addresses.PrivateKey
@@ -71,7 +79,7 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 			PublicKey: privateKey.GeneratePublicKey(),
 		}
 
-	case transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
+	case transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT, transaction_simple.SCRIPT_CLAIM_CONDITIONAL_PAYMENT:
 	default:
 		return nil, errors.New("Invalid Tx Script")
 	}
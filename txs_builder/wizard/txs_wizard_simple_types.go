@@ -23,6 +23,13 @@ type WizardTxSimpleExtraResolutionConditionalPayment struct {
 	Signatures          [][]byte `json:"signatures" msgpack:"signatures"`
 }
 
+type WizardTxSimpleExtraClaimConditionalPayment struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	TxId                []byte `json:"txId" msgpack:"txId"`
+	PayloadIndex        byte   `json:"payloadIndex" msgpack:"payloadIndex"`
+	Preimage            []byte `json:"preimage" msgpack:"preimage"`
+}
+
 type WizardTxSimpleTransfer struct {
 	Extra WizardTxSimpleExtra    `json:"extra" msgpack:"extra"`
 	Data  *WizardTransactionData `json:"data" msgpack:"data"`
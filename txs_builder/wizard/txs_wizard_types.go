@@ -2,7 +2,12 @@ package wizard
 
 import (
 	"errors"
+	"pandora-pay/addresses"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
 )
 
 type WizardTransactionFee struct {
@@ -22,8 +27,10 @@ func (fee *WizardTransactionFee) Clone() *WizardTransactionFee {
 }
 
 type WizardTransactionData struct {
-	Data    []byte `json:"data,omitempty" msgpack:"data,omitempty"`
-	Encrypt bool   `json:"encrypt,omitempty" msgpack:"encrypt,omitempty"`
+	Data                     []byte `json:"data,omitempty" msgpack:"data,omitempty"`
+	Encrypt                  bool   `json:"encrypt,omitempty" msgpack:"encrypt,omitempty"`
+	PublicKeyToEncrypt       []byte `json:"publicKeyToEncrypt,omitempty" msgpack:"publicKeyToEncrypt,omitempty"`             //only for simple txs, Zether txs encrypt the data to the recipient
+	SenderPublicKeyToEncrypt []byte `json:"senderPublicKeyToEncrypt,omitempty" msgpack:"senderPublicKeyToEncrypt,omitempty"` //optional, a copy of the message for the sender
 }
 
 func (data *WizardTransactionData) getDataVersion() transaction_data.TransactionDataVersion {
@@ -36,19 +43,37 @@ func (data *WizardTransactionData) getDataVersion() transaction_data.Transaction
 	return transaction_data.TX_DATA_PLAIN_TEXT
 }
 
+// getData returns the data. Encrypted data is a list of encrypted copies, one for each public key
 func (data *WizardTransactionData) getData() ([]byte, error) {
 	if len(data.Data) == 0 {
 		return nil, nil
 	}
 	if !data.Encrypt {
 		return data.Data, nil
-	} else {
-		return nil, errors.New("Not supported right now")
-		//pub, err := ecdsa.DecompressPubkey(data.PublicKeyToEncrypt)
-		//if err != nil {
-		//	return nil, err
-		//}
-		//
-		//return ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(pub), data.Data, nil, nil)
 	}
+
+	if len(data.PublicKeyToEncrypt) == 0 {
+		return nil, errors.New("Public Key to encrypt is missing")
+	}
+
+	publicKeys := [][]byte{data.PublicKeyToEncrypt}
+	if len(data.SenderPublicKeyToEncrypt) > 0 {
+		publicKeys = append(publicKeys, data.SenderPublicKeyToEncrypt)
+	}
+
+	encryptedLength := cryptography.PublicKeySize + len(data.Data) + cryptography.ChecksumSize
+	if len(publicKeys)*(helpers.BytesLengthSerialized(uint64(encryptedLength))+encryptedLength) > config.TRANSACTIONS_MAX_DATA_LENGTH {
+		return nil, errors.New("Encrypted data exceeds the maximum length")
+	}
+
+	w := advanced_buffers.NewBufferWriter()
+	for _, publicKey := range publicKeys {
+		encrypted, err := (&addresses.Address{PublicKey: publicKey}).EncryptMessage(data.Data)
+		if err != nil {
+			return nil, err
+		}
+		w.WriteVariableBytes(encrypted)
+	}
+
+	return w.Bytes(), nil
 }
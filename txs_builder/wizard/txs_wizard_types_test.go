@@ -0,0 +1,45 @@
+package wizard
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+func TestWizardTransactionData_GetData(t *testing.T) {
+
+	recipient := addresses.GenerateNewPrivateKey()
+	sender := addresses.GenerateNewPrivateKey()
+
+	data := &WizardTransactionData{helpers.RandomBytes(100), true, recipient.GeneratePublicKey(), sender.GeneratePublicKey()}
+
+	encrypted, err := data.getData()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(encrypted), config.TRANSACTIONS_MAX_DATA_LENGTH)
+
+	r := advanced_buffers.NewBufferReader(encrypted)
+	for _, privateKey := range []*addresses.PrivateKey{recipient, sender} {
+		copy, err := r.ReadVariableBytes(config.TRANSACTIONS_MAX_DATA_LENGTH)
+		assert.NoError(t, err)
+		decrypted, err := privateKey.Decrypt(copy)
+		assert.NoError(t, err)
+		assert.Equal(t, data.Data, decrypted)
+	}
+
+	//one copy fits, but the copy for the sender exceeds the limit
+	data.Data = helpers.RandomBytes(300)
+	_, err = data.getData()
+	assert.EqualError(t, err, "Encrypted data exceeds the maximum length")
+
+	data.SenderPublicKeyToEncrypt = nil
+	encrypted, err = data.getData()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(encrypted), config.TRANSACTIONS_MAX_DATA_LENGTH)
+
+	data.Data = helpers.RandomBytes(config.TRANSACTIONS_MAX_DATA_LENGTH)
+	_, err = data.getData()
+	assert.EqualError(t, err, "Encrypted data exceeds the maximum length")
+}
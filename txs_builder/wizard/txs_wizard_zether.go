@@ -318,6 +318,70 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 					payloadExtra.Threshold,
 					payloadExtra.MultisigPublicKeys,
 				}
+			case *WizardZetherPayloadExtraConditionalPaymentHashlock:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPaymentHashlock{
+					nil,
+					payloadExtra.Deadline,
+					payloadExtra.Hashlock,
+				}
+			case *WizardZetherPayloadExtraAssetSupplyDecrease:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetSupplyPrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{nil,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+			case *WizardZetherPayloadExtraAssetPause:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_PAUSE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetUpdatePrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetPause{nil,
+					payloadExtra.AssetId,
+					payloadExtra.Paused,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+			case *WizardZetherPayloadExtraAssetFreeze:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_FREEZE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetUpdatePrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetFreeze{nil,
+					payloadExtra.AssetId,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+			case *WizardZetherPayloadExtraAssetChangePublicKey:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetUpdatePrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetChangePublicKey{nil,
+					payloadExtra.AssetId,
+					payloadExtra.ChangeSupplyPublicKey,
+					payloadExtra.NewPublicKey,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+			case *WizardZetherPayloadExtraAssetUpdate:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_UPDATE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetUpdatePrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{nil,
+					payloadExtra.AssetId,
+					payloadExtra.Name,
+					payloadExtra.Description,
+					payloadExtra.Data,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+
+				spaceExtra += len(payloadExtra.Name) + len(payloadExtra.Description) + len(payloadExtra.Data)
 			default:
 				return errors.New("Invalid payload")
 			}
@@ -432,7 +496,7 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 			payload.FeeLeadingZeros = transfers[t].FeeLeadingZeros
 		}
 
-		if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT {
+		if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK {
 			otherFee = fee
 			fee = 0
 			payload.FeeRate = 0
@@ -487,11 +551,9 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 				}
 
 				if payload.DataVersion == transaction_data.TX_DATA_ENCRYPTED {
-					if len(dataFinal) > transaction_zether_payload.PAYLOAD_LIMIT {
-						return errors.New("Data final exceeds")
+					if payload.Data, err = transaction_zether_payload.PadEncryptedData(dataFinal); err != nil {
+						return
 					}
-					dataFinal = append(dataFinal, make([]byte, transaction_zether_payload.PAYLOAD_LIMIT-len(dataFinal))...)
-					payload.Data = append([]byte{}, dataFinal...)
 
 					// make sure used data encryption is optional, just in case we would like to play together with ring members
 					if err = crypto.EncryptDecryptUserData(cryptography.SHA3(append(shared_key[:], publickeylist[i].EncodeCompressed()...)), payload.Data); err != nil {
@@ -564,7 +626,7 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 
 				} else { //receiver
 					if (bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) && hasRollovers[publickeylist[i].String()]) ||
-						payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT {
+						payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT_HASHLOCK {
 						update = false
 					}
 				}
@@ -648,6 +710,16 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyIncrease).AssetSignature = signature
 			case transaction_zether_payload_script.SCRIPT_SPEND:
 				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend).SenderSpendSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_PAUSE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetPause).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_FREEZE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetFreeze).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetChangePublicKey).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate).AssetSignature = signature
 			}
 
 		}
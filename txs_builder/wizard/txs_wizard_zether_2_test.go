@@ -64,7 +64,7 @@ func TestCreateZetherTx2(t *testing.T) {
 			Recipient:              recipientAddress.EncodeAddr(),
 			Amount:                 diff,
 			Burn:                   0,
-			Data:                   &WizardTransactionData{[]byte{}, false},
+			Data:                   &WizardTransactionData{[]byte{}, false, nil, nil},
 			WitnessIndexes:         helpers.ShuffleArray_for_Zether(ringSize),
 		}
 		amount -= diff
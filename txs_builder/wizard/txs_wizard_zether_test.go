@@ -81,7 +81,7 @@ func TestCreateZetherTx(t *testing.T) {
 			Recipient:              recipientAddress.EncodeAddr(),
 			Amount:                 diff,
 			Burn:                   0,
-			Data:                   &WizardTransactionData{[]byte{}, false},
+			Data:                   &WizardTransactionData{[]byte{}, false, nil, nil},
 			WitnessIndexes:         helpers.ShuffleArray_for_Zether(ringSize),
 		}
 		amount -= diff
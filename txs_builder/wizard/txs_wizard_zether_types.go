@@ -43,6 +43,47 @@ type WizardZetherPayloadExtraConditionalPayment struct {
 	MultisigPublicKeys       [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
 }
 
+type WizardZetherPayloadExtraConditionalPaymentHashlock struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	Deadline                 uint64 `json:"deadline" msgpack:"deadline"`
+	Hashlock                 []byte `json:"hashlock" msgpack:"hashlock"`
+}
+
+type WizardZetherPayloadExtraAssetSupplyDecrease struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetSupplyPrivateKey    []byte `json:"assetSupplyPrivateKey" msgpack:"assetSupplyPrivateKey"`
+}
+
+type WizardZetherPayloadExtraAssetPause struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetId                  []byte `json:"assetId" msgpack:"assetId"`
+	Paused                   bool   `json:"paused" msgpack:"paused"`
+	AssetUpdatePrivateKey    []byte `json:"assetUpdatePrivateKey" msgpack:"assetUpdatePrivateKey"`
+}
+
+type WizardZetherPayloadExtraAssetFreeze struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetId                  []byte `json:"assetId" msgpack:"assetId"`
+	AssetUpdatePrivateKey    []byte `json:"assetUpdatePrivateKey" msgpack:"assetUpdatePrivateKey"`
+}
+
+type WizardZetherPayloadExtraAssetChangePublicKey struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetId                  []byte `json:"assetId" msgpack:"assetId"`
+	ChangeSupplyPublicKey    bool   `json:"changeSupplyPublicKey" msgpack:"changeSupplyPublicKey"`
+	NewPublicKey             []byte `json:"newPublicKey" msgpack:"newPublicKey"`
+	AssetUpdatePrivateKey    []byte `json:"assetUpdatePrivateKey" msgpack:"assetUpdatePrivateKey"`
+}
+
+type WizardZetherPayloadExtraAssetUpdate struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetId                  []byte `json:"assetId" msgpack:"assetId"`
+	Name                     string `json:"name" msgpack:"name"`
+	Description              string `json:"description" msgpack:"description"`
+	Data                     []byte `json:"data" msgpack:"data"`
+	AssetUpdatePrivateKey    []byte `json:"assetUpdatePrivateKey" msgpack:"assetUpdatePrivateKey"`
+}
+
 type WizardZetherPayloadExtra interface {
 }
 
@@ -140,6 +140,7 @@ func NewTxsValidator() error {
 	}
 
 	go TxsValidator.runRemoveExpiredTransactions()
+	TxsValidator.initMetrics()
 
 	return nil
 }
@@ -0,0 +1,24 @@
+package txs_validator
+
+import (
+	"pandora-pay/helpers/metrics"
+	"sync/atomic"
+)
+
+var (
+	validatedTxsMetric = metrics.NewCounter("pandora_txs_validator_validated_total", "Number of transactions validated")
+	invalidTxsMetric   = metrics.NewCounter("pandora_txs_validator_invalid_total", "Number of transactions which failed the validation")
+)
+
+func (validator *TxsValidatorType) initMetrics() {
+	metrics.NewGaugeFunc("pandora_txs_validator_queue", "Number of transactions waiting to be validated", func() float64 {
+		count := 0
+		validator.all.Range(func(key string, work *txValidatedWork) bool {
+			if atomic.LoadInt32(&work.status) != TX_VALIDATED_PROCCESSED {
+				count += 1
+			}
+			return true
+		})
+		return float64(count)
+	})
+}
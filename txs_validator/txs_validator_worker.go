@@ -41,7 +41,9 @@ func (worker *TxsValidatorWorker) verifyTx(foundWork *txValidatedWork) error {
 
 		for _, payload := range base.Payloads {
 			switch payload.PayloadScript {
-			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_SPEND:
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_SPEND,
+				transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE, transaction_zether_payload_script.SCRIPT_ASSET_PAUSE, transaction_zether_payload_script.SCRIPT_ASSET_FREEZE,
+				transaction_zether_payload_script.SCRIPT_ASSET_CHANGE_PUBLIC_KEY, transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
 				if payload.Extra.VerifyExtraSignature(hashForSignature, payload.Statement) == false {
 					return errors.New("Extra signature failed")
 				}
@@ -68,6 +70,11 @@ func (worker *TxsValidatorWorker) run() {
 			}
 		}
 
+		validatedTxsMetric.Inc()
+		if foundWork.result != nil {
+			invalidTxsMetric.Inc()
+		}
+
 		foundWork.tx = nil
 		foundWork.time = time.Now().Add(EXPIRE_TIME_MS).Unix()
 		atomic.StoreInt32(&foundWork.status, TX_VALIDATED_PROCCESSED)
@@ -22,9 +22,12 @@ type wallet struct {
 	addressesMap         map[string]*wallet_address.WalletAddress
 	updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]
 	nonHardening         bool         `json:"nonHardening" msgpack:"nonHardening"`
+	name                 string       //empty for the default wallet
+	prefix               string       //prefix of the keys in StoreWallet
 	Lock                 sync.RWMutex `json:"-" msgpack:"-"`
 }
 
+// Wallet is the default wallet. The named wallets are managed by Wallets
 var Wallet *wallet
 
 // must be locked before
@@ -45,37 +48,49 @@ func (self *wallet) clearWallet() {
 // must be locked before
 func (self *wallet) setLoaded(newValue bool) {
 	self.Loaded = newValue
-	self.initWalletCLI()
+	if Wallets.isSelected(self) {
+		self.initWalletCLI()
+	}
+}
+
+func (self *wallet) GetName() string {
+	if self.name == "" {
+		return DEFAULT_WALLET_NAME
+	}
+	return self.name
 }
 
-func createWalletInstance(updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]) *wallet {
+func createWalletInstance(updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates], name string) *wallet {
 	w := &wallet{
 		updateNewChainUpdate: updateNewChainUpdate,
+		name:                 name,
+	}
+	if name != "" {
+		w.prefix = "wallets:" + name + ":"
 	}
 	w.clearWallet()
 	return w
 }
 
-func createWallet() (*wallet, error) {
-
-	w := createWalletInstance(nil)
+// load reads the stored wallet or creates a new empty one
+func (self *wallet) load() error {
 
-	if err := w.loadWallet("", true); err != nil {
+	if err := self.loadWallet("", true); err != nil {
 		if err.Error() == "cipher: message authentication failed" {
-			return w, nil
+			return nil
 		}
 		if err.Error() != "Wallet doesn't exist" {
-			return nil, err
+			return err
 		}
-		if err = w.CreateEmptyWallet(); err != nil {
-			return nil, err
+		if err = self.CreateEmptyWallet(); err != nil {
+			return err
 		}
 	}
 
-	return w, nil
+	return nil
 }
 
-func (self *wallet) InitializeWallet(updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]) {
+func (self *wallet) initializeWallet(updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]) {
 
 	self.Lock.Lock()
 	self.updateNewChainUpdate = updateNewChainUpdate
@@ -87,6 +102,25 @@ func (self *wallet) InitializeWallet(updateNewChainUpdate *multicast.MulticastCh
 }
 
 func Initialize() (err error) {
-	Wallet, err = createWallet()
-	return err
+
+	Wallet = createWalletInstance(nil, "")
+
+	Wallets = &walletsType{
+		map[string]*wallet{},
+		Wallet,
+		nil,
+		sync.RWMutex{},
+	}
+
+	if err = Wallet.load(); err != nil {
+		return
+	}
+
+	if err = Wallets.loadNamedWallets(); err != nil {
+		return
+	}
+
+	Wallets.initCLI()
+
+	return
 }
@@ -24,6 +24,11 @@ type WalletAddress struct {
 	SharedStaked               *shared_staked.WalletAddressSharedStaked `json:"sharedStaked,omitempty" msgpack:"sharedStaked,omitempty"`
 	AddressEncoded             string                                   `json:"addressEncoded" msgpack:"addressEncoded"`
 	AddressRegistrationEncoded string                                   `json:"addressRegistrationEncoded" msgpack:"addressRegistrationEncoded"`
+	WatchOnly                  bool                                     `json:"watchOnly,omitempty" msgpack:"watchOnly,omitempty"` //no key is stored. The key decrypting the balances is also the spending key
+}
+
+func (self *WalletAddress) CanDecrypt() bool {
+	return self.PrivateKey != nil
 }
 
 func (self *WalletAddress) DeriveSharedStaked() (*shared_staked.WalletAddressSharedStaked, error) {
@@ -97,5 +102,6 @@ func (self *WalletAddress) Clone() *WalletAddress {
 		sharedStaked,
 		self.AddressEncoded,
 		self.AddressRegistrationEncoded,
+		self.WatchOnly,
 	}
 }
@@ -45,6 +45,8 @@ type address struct {
 	name                    string
 	addressString           string
 	addressRegisteredString string
+	watchOnly               bool
+	canDecrypt              bool
 }
 
 func (self *wallet) exportSharedStakedAddress(addr *wallet_address.WalletAddress, path string, print bool) (*shared_staked.WalletAddressSharedStakedAddressExported, error) {
@@ -90,7 +92,7 @@ func (self *wallet) cliGetAddresses() (addresses []*address, err error) {
 	addresses = make([]*address, len(self.Addresses))
 
 	for i, walletAddress := range self.Addresses {
-		addresses[i] = &address{publicKey: helpers.CloneBytes(walletAddress.PublicKey), name: walletAddress.Name, addressString: walletAddress.GetAddress(false), addressRegisteredString: walletAddress.GetAddress(true)}
+		addresses[i] = &address{publicKey: helpers.CloneBytes(walletAddress.PublicKey), name: walletAddress.Name, addressString: walletAddress.GetAddress(false), addressRegisteredString: walletAddress.GetAddress(true), watchOnly: walletAddress.WatchOnly, canDecrypt: walletAddress.CanDecrypt()}
 	}
 	self.Lock.RUnlock()
 
@@ -160,10 +162,15 @@ func (self *wallet) cliListAddresses(cmd string, ctx context.Context) (err error
 	var decrypted uint64
 	for i, address := range addresses {
 
+		watchOnly := ""
+		if address.watchOnly {
+			watchOnly = " [WATCH-ONLY]"
+		}
+
 		if addresses[i].registration != nil {
-			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s :: %s", i, address.name, address.addressRegisteredString))
+			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s%s :: %s", i, address.name, watchOnly, address.addressRegisteredString))
 		} else {
-			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s :: %s", i, address.name, address.addressString))
+			gui.GUI.OutputWrite(fmt.Sprintf("%d) %s%s :: %s", i, address.name, watchOnly, address.addressString))
 		}
 
 		if len(addresses[i].assetsList) == 0 && addresses[i].plainAcc == nil {
@@ -197,6 +204,11 @@ func (self *wallet) cliListAddresses(cmd string, ctx context.Context) (err error
 				gui.GUI.OutputWrite(fmt.Sprintf("%18s: %64s", data.ast.Name, base64.StdEncoding.EncodeToString(data.balance.Serialize())))
 			}
 
+			if !address.canDecrypt {
+				gui.GUI.OutputWrite(fmt.Sprintf("%18s", "Watch-only address. Balances can't be decrypted"))
+				continue
+			}
+
 			gui.GUI.OutputWrite(fmt.Sprintf("%18s", "Decrypting...."))
 
 			for _, data := range addresses[i].assetsList {
@@ -278,7 +290,20 @@ func (self *wallet) initWalletCLI() {
 	}
 
 	cliScanAddresses := func(cmd string, ctx context.Context) (err error) {
-		return self.ScanAddresses()
+
+		watchOnly, err := self.ScanAddresses()
+		if err != nil {
+			return
+		}
+
+		for _, status := range watchOnly {
+			gui.GUI.OutputWrite(fmt.Sprintf("%s [WATCH-ONLY] :: %s", status.Name, status.Address))
+			gui.GUI.OutputWrite(fmt.Sprintf("%18s: %v Staked: %v", "Registered", status.Registered, status.Staked))
+			gui.GUI.OutputWrite(fmt.Sprintf("%18s: %s", "Unclaimed", strconv.FormatFloat(config_coins.ConvertToBase(status.Unclaimed), 'f', config_coins.DECIMAL_SEPARATOR, 64)))
+			gui.GUI.OutputWrite(fmt.Sprintf("%18s: %d Can be decrypted: %v", "Assets", len(status.Assets), status.CanDecrypt))
+		}
+
+		return
 	}
 
 	cliExportWalletBalancesJSON := func(cmd string, ctx context.Context) (err error) {
@@ -296,6 +321,7 @@ func (self *wallet) initWalletCLI() {
 		}
 		type exportAddress struct {
 			Name         string                      `json:"name,omitempty"`
+			WatchOnly    bool                        `json:"watchOnly,omitempty"`
 			Address      string                      `json:"address"`
 			Assets       []*exportAddressAsset       `json:"assets,omitempty"`
 			Registration *registration.Registration  `json:"registration,omitempty"`
@@ -307,10 +333,16 @@ func (self *wallet) initWalletCLI() {
 		var decrypted uint64
 		for i, addr := range addresses {
 
+			assetsList := addr.assetsList
+			if !addr.canDecrypt { //watch-only address
+				assetsList = nil
+			}
+
 			exportedAddresses[i] = &exportAddress{
 				addr.name,
+				addr.watchOnly,
 				addr.addressString,
-				make([]*exportAddressAsset, len(addr.assetsList)),
+				make([]*exportAddressAsset, len(assetsList)),
 				addr.registration,
 				addr.plainAcc,
 			}
@@ -319,7 +351,7 @@ func (self *wallet) initWalletCLI() {
 				exportedAddresses[i].Address = addr.addressRegisteredString
 			}
 
-			for j, data := range addresses[i].assetsList {
+			for j, data := range assetsList {
 
 				gui.GUI.Info2Update("Decrypting", "")
 
@@ -615,6 +647,21 @@ func (self *wallet) initWalletCLI() {
 		return
 	}
 
+	cliImportWatchOnlyAddress := func(cmd string, ctx context.Context) (err error) {
+
+		address := gui.GUI.OutputReadString("Address to watch")
+		name := gui.GUI.OutputReadString("Write Name of the watch-only address. Leave empty for default name")
+
+		var adr *wallet_address.WalletAddress
+		if adr, err = self.ImportWatchOnlyAddress(name, address); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Watch-only address was imported: " + adr.AddressEncoded)
+
+		return
+	}
+
 	cliEncryptWallet := func(cmd string, ctx context.Context) (err error) {
 
 		password := gui.GUI.OutputReadString("Password for encrypting wallet")
@@ -786,6 +833,7 @@ func (self *wallet) initWalletCLI() {
 	gui.GUI.CommandDefineCallback("Import Entropy", cliImportEntropy, self.Loaded)
 	gui.GUI.CommandDefineCallback("Show Address Secret Key", cliShowAddressSecretKey, self.Loaded)
 	gui.GUI.CommandDefineCallback("Import Address Secret Key", cliImportAddressSecretKey, self.Loaded)
+	gui.GUI.CommandDefineCallback("Import Watch-only Address", cliImportWatchOnlyAddress, self.Loaded)
 	gui.GUI.CommandDefineCallback("Remove Address", cliRemoveAddress, self.Loaded)
 	gui.GUI.CommandDefineCallback("Export Shared Staked Address", cliExportSharedStakedAddress, self.Loaded)
 	gui.GUI.CommandDefineCallback("Export Addresses", cliExportAddresses, self.Loaded)
@@ -6,12 +6,16 @@ import (
 	"math/big"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/config"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/bn256"
 	"pandora-pay/cryptography/crypto"
 	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
 )
 
 type decryptZetherPayloadOutput struct {
@@ -29,9 +33,42 @@ type decryptTxZether struct {
 	Payloads []*decryptZetherPayloadOutput `json:"payloads" msgpack:"payloads"`
 }
 
+type decryptTxSimple struct {
+	Message   []byte `json:"message" msgpack:"message"`
+	PublicKey []byte `json:"publicKey" msgpack:"publicKey"`
+}
+
 type DecryptedTx struct {
 	Type     transaction_type.TransactionVersion `json:"type" msgpack:"type"`
-	ZetherTx *decryptTxZether                    `json:"zetherTx" msgpack:"zetherTx"`
+	ZetherTx *decryptTxZether                    `json:"zetherTx,omitempty" msgpack:"zetherTx,omitempty"`
+	SimpleTx *decryptTxSimple                    `json:"simpleTx,omitempty" msgpack:"simpleTx,omitempty"`
+}
+
+// decryptSimpleData tries to decrypt every encrypted copy of the message with the wallet addresses
+func (self *wallet) decryptSimpleData(data []byte, walletPublicKey []byte) *decryptTxSimple {
+
+	self.Lock.RLock()
+	defer self.Lock.RUnlock()
+
+	r := advanced_buffers.NewBufferReader(data)
+	for r.Position < len(r.Buf) {
+
+		encrypted, err := r.ReadVariableBytes(config.TRANSACTIONS_MAX_DATA_LENGTH)
+		if err != nil {
+			return nil
+		}
+
+		for _, addr := range self.Addresses {
+			if len(walletPublicKey) > 0 && !bytes.Equal(addr.PublicKey, walletPublicKey) {
+				continue
+			}
+			if message, err := addr.DecryptMessage(encrypted); err == nil {
+				return &decryptTxSimple{message, addr.PublicKey}
+			}
+		}
+	}
+
+	return nil
 }
 
 func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byte) (*DecryptedTx, error) {
@@ -48,6 +85,13 @@ func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byt
 	}
 
 	switch tx.Version {
+	case transaction_type.TX_SIMPLE:
+		txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+		if txBase.DataVersion == transaction_data.TX_DATA_ENCRYPTED {
+			output.SimpleTx = self.decryptSimpleData(txBase.Data, walletPublicKey)
+		} else if txBase.DataVersion == transaction_data.TX_DATA_PLAIN_TEXT {
+			output.SimpleTx = &decryptTxSimple{txBase.Data, nil}
+		}
 	case transaction_type.TX_ZETHER:
 		txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
 
@@ -62,7 +106,7 @@ func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byt
 					continue
 				}
 
-				if addr := self.GetWalletAddressByPublicKey(publicKey, true); addr != nil {
+				if addr := self.GetWalletAddressByPublicKey(publicKey, true); addr != nil && addr.CanDecrypt() {
 
 					decyptedZetherPayload := &decryptZetherPayloadOutput{
 						RecipientIndex: -1,
@@ -130,6 +174,9 @@ func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byt
 									continue
 								}
 
+								if data, err = transaction_zether_payload.UnpadEncryptedData(data); err != nil {
+									continue
+								}
 								decyptedZetherPayload.Message = data
 								decyptedZetherPayload.RecipientIndex = k
 								break
@@ -157,7 +204,9 @@ func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byt
 										continue
 									}
 
-									decyptedZetherPayload.Message = data
+									if data, err = transaction_zether_payload.UnpadEncryptedData(data); err == nil {
+										decyptedZetherPayload.Message = data
+									}
 									decyptedZetherPayload.RecipientIndex = k
 									break
 								}
@@ -187,6 +236,9 @@ func (self *wallet) DecryptTx(tx *transaction.Transaction, walletPublicKey []byt
 							if err = crypto.EncryptDecryptUserData(cryptography.SHA3(append(shared_key, addr.PublicKey...)), data); err != nil {
 								continue
 							}
+							if data, err = transaction_zether_payload.UnpadEncryptedData(data); err != nil {
+								continue
+							}
 							decyptedZetherPayload.Message = data
 						} else if payload.DataVersion == transaction_data.TX_DATA_PLAIN_TEXT {
 							decyptedZetherPayload.Message = payload.Data
@@ -23,7 +23,7 @@ func (self *wallet) processRefreshWallets() {
 
 		for {
 
-			if config_forging.FORGING_ENABLED {
+			if config_forging.FORGING_ENABLED && self.GetAddressesCount() > 0 {
 
 				accsList := []*account.Account{}
 				regsList := []*registration.Registration{}
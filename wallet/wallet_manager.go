@@ -48,7 +48,7 @@ func (self *wallet) GetFirstStakedAddress(lock bool) (*wallet_address.WalletAddr
 
 	var found *wallet_address.WalletAddress
 	for _, addr := range self.Addresses {
-		if addr.Staked {
+		if addr.Staked && !addr.WatchOnly {
 			found = addr
 			break
 		}
@@ -162,6 +162,82 @@ func (self *wallet) ImportSecretKey(name string, secret []byte, staked, spendReq
 	return addr.Clone(), nil
 }
 
+// ImportWatchOnlyAddress adds an address without any key. There is no separate view key, the key decrypting the balances is also the spending key, so its balances remain encrypted
+func (self *wallet) ImportWatchOnlyAddress(name, addressEncoded string) (*wallet_address.WalletAddress, error) {
+
+	address, err := addresses.DecodeAddr(addressEncoded)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := &wallet_address.WalletAddress{
+		Name:           name,
+		IsImported:     true,
+		PublicKey:      address.PublicKey,
+		Staked:         address.Staked,
+		SpendRequired:  len(address.SpendPublicKey) > 0,
+		SpendPublicKey: address.SpendPublicKey,
+		WatchOnly:      true,
+	}
+
+	if err = self.addWatchOnlyAddress(addr, address.Registration, true); err != nil {
+		return nil, err
+	}
+
+	return addr.Clone(), nil
+}
+
+func (self *wallet) addWatchOnlyAddress(addr *wallet_address.WalletAddress, registration []byte, lock bool) (err error) {
+
+	if lock {
+		self.Lock.Lock()
+		defer self.Lock.Unlock()
+	}
+
+	if !self.Loaded {
+		return errors.New("Wallet was not loaded!")
+	}
+
+	if addr.PrivateKey != nil || addr.SpendPrivateKey != nil || len(addr.SecretKey) > 0 {
+		return errors.New("Watch-only address can't have keys")
+	}
+
+	var addr1, addr2 *addresses.Address
+	if addr1, err = addresses.CreateAddr(addr.PublicKey, addr.Staked, addr.SpendPublicKey, nil, nil, 0, nil); err != nil {
+		return
+	}
+	if addr2, err = addresses.CreateAddr(addr.PublicKey, addr.Staked, addr.SpendPublicKey, registration, nil, 0, nil); err != nil {
+		return
+	}
+
+	addr.AddressEncoded = addr1.EncodeAddr()
+	addr.AddressRegistrationEncoded = addr2.EncodeAddr()
+	addr.Registration = registration
+
+	if self.addressesMap[string(addr.PublicKey)] != nil {
+		return errors.New("Address exists")
+	}
+
+	if addr.Name == "" {
+		addr.Name = "Watch-only Address " + strconv.Itoa(self.CountImportedIndex)
+		self.CountImportedIndex += 1
+	}
+
+	self.Addresses = append(self.Addresses, addr)
+	self.addressesMap[string(addr.PublicKey)] = addr
+
+	self.Count += 1
+
+	self.updateWallet()
+
+	if err = self.saveWallet(len(self.Addresses)-1, len(self.Addresses), -1, false); err != nil {
+		return
+	}
+	globals.MainEvents.BroadcastEvent("wallet/added", addr)
+
+	return
+}
+
 func (self *wallet) AddSharedStakedAddress(addr *wallet_address.WalletAddress, lock, hasAccount bool, account *account.Account, reg *registration.Registration, chainHeight uint64) (err error) {
 
 	if lock {
@@ -531,6 +607,10 @@ func (self *wallet) ImportWalletAddressJSON(data []byte) (*wallet_address.Wallet
 		return nil, errors.New("Error unmarshaling wallet")
 	}
 
+	if addr.WatchOnly {
+		return self.ImportWatchOnlyAddress(addr.Name, addr.AddressRegistrationEncoded)
+	}
+
 	if addr.PrivateKey == nil {
 		return nil, errors.New("Private Key is missing")
 	}
@@ -565,6 +645,10 @@ func (self *wallet) DecryptBalance(addr *wallet_address.WalletAddress, encrypted
 		return 0, errors.New("Encrypted Balance is nil")
 	}
 
+	if addr.PrivateKey == nil {
+		return 0, errors.New("Address is watch-only and its balance can't be decrypted")
+	}
+
 	return address_balance_decrypter.Decrypter.DecryptBalance("wallet", addr.PublicKey, addr.PrivateKey.Key, encryptedBalance, asset, useNewPreviousValue, newPreviousValue, store, ctx, statusCallback)
 }
 
@@ -598,12 +682,16 @@ func (self *wallet) TryDecryptBalance(addr *wallet_address.WalletAddress, encryp
 		return false, err
 	}
 
+	if addr.PrivateKey == nil {
+		return false, errors.New("Address is watch-only and its balance can't be decrypted")
+	}
+
 	return addr.PrivateKey.TryDecryptBalance(balance, matchValue), nil
 }
 
 func (self *wallet) ImportWalletJSON(data []byte) (err error) {
 
-	wallet2 := createWalletInstance(self.updateNewChainUpdate)
+	wallet2 := createWalletInstance(self.updateNewChainUpdate, self.name)
 	if err = json.Unmarshal(data, wallet2); err != nil {
 		return errors.New("Error unmarshaling wallet")
 	}
@@ -640,12 +728,24 @@ func (self *wallet) SetNonHardening(value bool) {
 	self.nonHardening = value
 }
 
-func (self *wallet) ScanAddresses() error {
+// WatchOnlyAddressStatus is what ScanAddresses can find out about a watch-only address without its private key
+type WatchOnlyAddressStatus struct {
+	Name       string   `json:"name" msgpack:"name"`
+	Address    string   `json:"address" msgpack:"address"`
+	Registered bool     `json:"registered" msgpack:"registered"`
+	Staked     bool     `json:"staked" msgpack:"staked"`
+	Unclaimed  uint64   `json:"unclaimed" msgpack:"unclaimed"`
+	Assets     [][]byte `json:"assets" msgpack:"assets"` //assets having an encrypted balance
+	CanDecrypt bool     `json:"canDecrypt" msgpack:"canDecrypt"`
+}
+
+// ScanAddresses adds the used addresses derived from the seed and removes the unused ones. Watch-only addresses are kept and their status is returned
+func (self *wallet) ScanAddresses() (watchOnly []*WatchOnlyAddressStatus, err error) {
 
 	self.Lock.Lock()
 	defer self.Lock.Unlock()
 
-	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
 		dataStorage := data_storage.NewDataStorage(reader)
 
@@ -689,6 +789,9 @@ func (self *wallet) ScanAddresses() error {
 
 		for i := len(self.Addresses) - 1; i > 0; i-- {
 			addr := self.Addresses[i]
+			if addr.WatchOnly {
+				continue
+			}
 
 			if reg, err = dataStorage.Regs.Get(string(addr.PublicKey)); err != nil {
 				return
@@ -703,10 +806,40 @@ func (self *wallet) ScanAddresses() error {
 			}
 		}
 
+		for _, addr := range self.Addresses {
+			if !addr.WatchOnly {
+				continue
+			}
+
+			if reg, err = dataStorage.Regs.Get(string(addr.PublicKey)); err != nil {
+				return
+			}
+			if plainAcc, err = dataStorage.PlainAccs.Get(string(addr.PublicKey)); err != nil {
+				return
+			}
+
+			status := &WatchOnlyAddressStatus{
+				Name:       addr.Name,
+				Address:    addr.GetAddress(reg != nil),
+				Registered: reg != nil,
+				Staked:     reg != nil && reg.Staked,
+				CanDecrypt: addr.CanDecrypt(),
+			}
+			if plainAcc != nil {
+				status.Unclaimed = plainAcc.Unclaimed
+			}
+			if status.Assets, err = dataStorage.AccsCollection.GetAccountAssets(addr.PublicKey); err != nil {
+				return
+			}
+
+			watchOnly = append(watchOnly, status)
+		}
+
 		return
 
 	})
 
+	return
 }
 
 func (self *wallet) Close() {
@@ -145,6 +145,9 @@ func (self *wallet) ImportEntropy(entropy []byte) (err error) {
 }
 
 func (self *wallet) updateWallet() {
+	if !Wallets.isSelected(self) {
+		return
+	}
 	gui.GUI.InfoUpdate("Wallet Addrs", fmt.Sprintf("%d  %s", self.Count, self.Encryption.Encrypted))
 }
 
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/wallet/wallet_address"
+	"sync"
+	"testing"
+)
+
+func TestWallet_ImportWatchOnlyAddress(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(nil)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("wallet")
+	assert.NoError(t, err)
+	store.StoreWallet = &store.Store{"wallet", true, db}
+
+	Wallet = createWalletInstance(nil, "")
+	Wallet.Loaded = true
+	Wallets = &walletsType{map[string]*wallet{}, Wallet, nil, sync.RWMutex{}}
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	address, err := privateKey.GenerateAddress(false, nil, true, nil, 0, nil)
+	assert.NoError(t, err)
+
+	addr, err := Wallet.ImportWatchOnlyAddress("treasury", address.EncodeAddr())
+	assert.NoError(t, err)
+	assert.True(t, addr.WatchOnly)
+	assert.Nil(t, addr.PrivateKey)
+	assert.False(t, addr.CanDecrypt())
+	assert.Equal(t, address.Registration, addr.Registration)
+
+	_, err = Wallet.ImportWatchOnlyAddress("treasury", address.EncodeAddr())
+	assert.EqualError(t, err, "Address exists")
+
+	//the watch-only address can't sign
+	addr, err = Wallets.GetWalletAddress("", address.EncodeAddr())
+	assert.NoError(t, err)
+	assert.Nil(t, addr.PrivateKey)
+
+	_, err = addr.SignMessage([]byte("message"))
+	assert.EqualError(t, err, "Private Key is missing")
+
+	_, err = addr.DeriveSharedStaked()
+	assert.EqualError(t, err, "Private Key is missing")
+
+	var point crypto.Point
+	assert.NoError(t, point.DecodeCompressed(address.PublicKey))
+	balance := crypto.CommitElGamal(point.G1(), big.NewInt(5))
+	_, err = Wallet.TryDecryptBalance(addr, balance.Serialize(), 0)
+	assert.EqualError(t, err, "Address is watch-only and its balance can't be decrypted")
+
+	//a watch-only address never stores a key, even if the exported JSON contains it
+	privateKey2 := addresses.GenerateNewPrivateKey()
+	address2, err := privateKey2.GenerateAddress(false, nil, true, nil, 0, nil)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(&wallet_address.WalletAddress{
+		Name:                       "exported",
+		PrivateKey:                 privateKey2,
+		PublicKey:                  address2.PublicKey,
+		AddressRegistrationEncoded: address2.EncodeAddr(),
+		WatchOnly:                  true,
+	})
+	assert.NoError(t, err)
+
+	addr, err = Wallet.ImportWalletAddressJSON(data)
+	assert.NoError(t, err)
+	assert.True(t, addr.WatchOnly)
+	assert.Nil(t, addr.PrivateKey)
+
+	privateKey3 := addresses.GenerateNewPrivateKey()
+	address3, err := privateKey3.GenerateAddress(false, nil, true, nil, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualError(t, Wallet.addWatchOnlyAddress(&wallet_address.WalletAddress{PublicKey: address3.PublicKey, PrivateKey: privateKey3, WatchOnly: true}, nil, true), "Watch-only address can't have keys")
+}
@@ -64,12 +64,12 @@ func (self *wallet) saveWallet(start, end, deleteIndex int, lock bool) error {
 
 		var marshal []byte
 
-		writer.Put("saved", []byte{0})
+		writer.Put(self.prefix+"saved", []byte{0})
 
 		if marshal, err = helpers.GetMarshalledDataExcept(self.Encryption); err != nil {
 			return
 		}
-		writer.Put("encryption", marshal)
+		writer.Put(self.prefix+"encryption", marshal)
 
 		if marshal, err = helpers.GetMarshalledDataExcept(self, "addresses", "encryption"); err != nil {
 			return
@@ -78,7 +78,7 @@ func (self *wallet) saveWallet(start, end, deleteIndex int, lock bool) error {
 			return
 		}
 
-		writer.Put("wallet", marshal)
+		writer.Put(self.prefix+"wallet", marshal)
 
 		for i := start; i < end; i++ {
 			if marshal, err = msgpack.Marshal(self.Addresses[i]); err != nil {
@@ -87,13 +87,13 @@ func (self *wallet) saveWallet(start, end, deleteIndex int, lock bool) error {
 			if marshal, err = self.Encryption.encryptData(marshal); err != nil {
 				return
 			}
-			writer.Put("wallet-address-"+strconv.Itoa(i), marshal)
+			writer.Put(self.prefix+"wallet-address-"+strconv.Itoa(i), marshal)
 		}
 		if deleteIndex != -1 {
-			writer.Delete("wallet-address-" + strconv.Itoa(deleteIndex))
+			writer.Delete(self.prefix + "wallet-address-" + strconv.Itoa(deleteIndex))
 		}
 
-		writer.Put("saved", []byte{1})
+		writer.Put(self.prefix+"saved", []byte{1})
 		return
 	})
 }
@@ -110,7 +110,7 @@ func (self *wallet) loadWallet(password string, firstTime bool) error {
 
 	return store.StoreWallet.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
-		saved := reader.Get("saved") //safe only internal
+		saved := reader.Get(self.prefix + "saved") //safe only internal
 		if saved == nil {
 			return errors.New("Wallet doesn't exist")
 		}
@@ -121,7 +121,7 @@ func (self *wallet) loadWallet(password string, firstTime bool) error {
 
 			var unmarshal []byte
 
-			unmarshal = reader.Get("encryption")
+			unmarshal = reader.Get(self.prefix + "encryption")
 			if unmarshal == nil {
 				return errors.New("encryption data was not found")
 			}
@@ -139,7 +139,7 @@ func (self *wallet) loadWallet(password string, firstTime bool) error {
 				}
 			}
 
-			if unmarshal, err = self.Encryption.decryptData(reader.Get("wallet")); err != nil {
+			if unmarshal, err = self.Encryption.decryptData(reader.Get(self.prefix + "wallet")); err != nil {
 				return
 			}
 			if err = msgpack.Unmarshal(unmarshal, self); err != nil {
@@ -151,7 +151,7 @@ func (self *wallet) loadWallet(password string, firstTime bool) error {
 
 			for i := 0; i < self.Count; i++ {
 
-				if unmarshal, err = self.Encryption.decryptData(reader.Get("wallet-address-" + strconv.Itoa(i))); err != nil {
+				if unmarshal, err = self.Encryption.decryptData(reader.Get(self.prefix + "wallet-address-" + strconv.Itoa(i))); err != nil {
 					return
 				}
 
@@ -215,6 +215,9 @@ func (self *wallet) InitForgingWallet() (err error) {
 	}
 
 	for _, addr := range self.Addresses {
+		if addr.WatchOnly {
+			continue
+		}
 		if err = forging.Forging.Wallet.AddWallet(addr.PublicKey, addr.SharedStaked, false, nil, nil, 0); err != nil {
 			return
 		}
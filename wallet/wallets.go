@@ -0,0 +1,317 @@
+package wallet
+
+import (
+	"errors"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/config/globals"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/helpers/multicast"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/wallet/wallet_address"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const DEFAULT_WALLET_NAME = "default"
+
+var walletNameRegexp = regexp.MustCompile("^[a-zA-Z0-9_-]{1,32}$")
+
+// walletsType manages the named wallets. Every named wallet has its own mnemonic and encryption and it is stored in StoreWallet using the prefix "wallets:<name>:"
+type walletsType struct {
+	named                map[string]*wallet
+	selected             *wallet //used by the CLI
+	updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]
+	lock                 sync.RWMutex
+}
+
+var Wallets *walletsType
+
+func (self *walletsType) isSelected(w *wallet) bool {
+	if self == nil {
+		return false
+	}
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	return self.selected == w
+}
+
+func (self *walletsType) GetSelected() *wallet {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	return self.selected
+}
+
+// GetWallet returns the wallet by name. An empty name returns the default wallet
+func (self *walletsType) GetWallet(name string) (*wallet, error) {
+
+	if name == "" || name == DEFAULT_WALLET_NAME {
+		return Wallet, nil
+	}
+
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	if w := self.named[name]; w != nil {
+		return w, nil
+	}
+	return nil, errors.New("Wallet " + name + " was not found")
+}
+
+// GetNames returns the names of all the wallets. The default wallet is the first
+func (self *walletsType) GetNames() []string {
+
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	return append([]string{DEFAULT_WALLET_NAME}, self.getNamedList()...)
+}
+
+// must be locked before
+func (self *walletsType) getNamedList() []string {
+	list := make([]string, 0, len(self.named))
+	for name := range self.named {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// getAll returns all the wallets. The default wallet is the first
+func (self *walletsType) getAll() []*wallet {
+
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	list := []*wallet{Wallet}
+	for _, name := range self.getNamedList() {
+		list = append(list, self.named[name])
+	}
+	return list
+}
+
+// must be locked before
+func (self *walletsType) saveNames() error {
+
+	data, err := msgpack.Marshal(self.getNamedList())
+	if err != nil {
+		return err
+	}
+
+	return store.StoreWallet.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("wallets", data)
+		return nil
+	})
+}
+
+func (self *walletsType) Select(name string) error {
+
+	w, err := self.GetWallet(name)
+	if err != nil {
+		return err
+	}
+
+	self.lock.Lock()
+	self.selected = w
+	self.lock.Unlock()
+
+	w.Lock.Lock()
+	w.initWalletCLI()
+	w.updateWallet()
+	w.Lock.Unlock()
+
+	globals.MainEvents.BroadcastEvent("wallets/selected", w.GetName())
+	return nil
+}
+
+// Create creates a new named wallet with a new mnemonic. When password is not empty the wallet is encrypted
+func (self *walletsType) Create(name, password string, difficulty int) (w *wallet, err error) {
+
+	if !walletNameRegexp.MatchString(name) || name == DEFAULT_WALLET_NAME {
+		return nil, errors.New("Invalid wallet name. Use up to 32 letters, digits, - or _")
+	}
+
+	if _, err = self.GetWallet(name); err == nil {
+		return nil, errors.New("Wallet " + name + " already exists")
+	}
+
+	self.lock.RLock()
+	updateNewChainUpdate := self.updateNewChainUpdate
+	self.lock.RUnlock()
+
+	//the wallet is created unlocked because setLoaded reads the selected wallet
+	w = createWalletInstance(updateNewChainUpdate, name)
+	if err = w.CreateEmptyWallet(); err != nil {
+		return
+	}
+
+	if password != "" {
+		if err = w.Encryption.Encrypt(password, difficulty); err != nil {
+			return
+		}
+	}
+
+	self.lock.Lock()
+	if self.named[name] != nil {
+		self.lock.Unlock()
+		return nil, errors.New("Wallet " + name + " already exists")
+	}
+	self.named[name] = w
+	if err = self.saveNames(); err != nil {
+		delete(self.named, name)
+		self.lock.Unlock()
+		return
+	}
+	self.lock.Unlock()
+
+	if updateNewChainUpdate != nil {
+		w.initializeWallet(updateNewChainUpdate)
+		if err = w.StartWallet(); err != nil {
+			return
+		}
+	}
+
+	globals.MainEvents.BroadcastEvent("wallets/created", name)
+	return
+}
+
+// Remove deletes a named wallet from the store. The default wallet can not be removed
+func (self *walletsType) Remove(name string) (err error) {
+
+	if name == "" || name == DEFAULT_WALLET_NAME {
+		return errors.New("The default wallet can not be removed")
+	}
+
+	self.lock.Lock()
+
+	w := self.named[name]
+	if w == nil {
+		self.lock.Unlock()
+		return errors.New("Wallet " + name + " was not found")
+	}
+
+	if self.selected == w {
+		self.lock.Unlock()
+		return errors.New("The selected wallet can not be removed. Select another wallet first")
+	}
+
+	delete(self.named, name)
+	if err = self.saveNames(); err != nil {
+		self.named[name] = w
+		self.lock.Unlock()
+		return
+	}
+
+	self.lock.Unlock()
+
+	w.Lock.Lock()
+	defer w.Lock.Unlock()
+
+	for _, addr := range w.Addresses {
+		forging.Forging.Wallet.RemoveWallet(addr.PublicKey, false, nil, nil, 0)
+	}
+	w.clearWallet()
+
+	if err = store.StoreWallet.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Delete(w.prefix + "saved")
+		writer.Delete(w.prefix + "encryption")
+		writer.Delete(w.prefix + "wallet")
+		for i := 0; ; i++ {
+			key := w.prefix + "wallet-address-" + strconv.Itoa(i)
+			if !writer.Exists(key) {
+				break
+			}
+			writer.Delete(key)
+		}
+		return
+	}); err != nil {
+		return
+	}
+
+	globals.MainEvents.BroadcastEvent("wallets/removed", name)
+	return
+}
+
+func (self *walletsType) loadNamedWallets() (err error) {
+
+	var names []string
+	if err = store.StoreWallet.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		if data := reader.Get("wallets"); data != nil {
+			return msgpack.Unmarshal(data, &names)
+		}
+		return
+	}); err != nil {
+		return
+	}
+
+	for _, name := range names {
+		w := createWalletInstance(nil, name)
+		if err = w.load(); err != nil {
+			return
+		}
+		self.lock.Lock()
+		self.named[name] = w
+		self.lock.Unlock()
+	}
+
+	return
+}
+
+func (self *walletsType) InitializeWallets(updateNewChainUpdate *multicast.MulticastChannel[*blockchain_types.BlockchainUpdates]) {
+
+	self.lock.Lock()
+	self.updateNewChainUpdate = updateNewChainUpdate
+	self.lock.Unlock()
+
+	for _, w := range self.getAll() {
+		w.initializeWallet(updateNewChainUpdate)
+	}
+}
+
+func (self *walletsType) StartWallets() (err error) {
+	for _, w := range self.getAll() {
+		if err = w.StartWallet(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// GetWalletAddress returns the address only from the given wallet. An empty name uses the default wallet. The other wallets are never searched
+func (self *walletsType) GetWalletAddress(walletName, addressEncoded string) (*wallet_address.WalletAddress, error) {
+
+	w, err := self.GetWallet(walletName)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := addresses.DecodeAddr(addressEncoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if addr := w.GetWalletAddressByPublicKey(address.PublicKey, true); addr != nil {
+		return addr, nil
+	}
+	return nil, errors.New("Address " + addressEncoded + " doesn't exist in the wallet " + w.GetName())
+}
+
+// GetWalletNameByPublicKey returns the name of the wallet owning the public key. It is used only for the addresses of the node itself, like the forgers
+func (self *walletsType) GetWalletNameByPublicKey(publicKey []byte) (string, error) {
+	for _, w := range self.getAll() {
+		if w.GetWalletAddressByPublicKey(publicKey, true) != nil {
+			return w.GetName(), nil
+		}
+	}
+	return "", errors.New("Address was not found")
+}
+
+func (self *walletsType) Close() {
+	for _, w := range self.getAll() {
+		w.Close()
+	}
+}
@@ -0,0 +1,92 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"pandora-pay/gui"
+)
+
+func (self *walletsType) initCLI() {
+
+	cliListWallets := func(cmd string, ctx context.Context) (err error) {
+
+		selected := self.GetSelected()
+		for _, w := range self.getAll() {
+
+			w.Lock.RLock()
+			line := fmt.Sprintf("%-32s %4d addresses %s", w.GetName(), w.Count, w.Encryption.Encrypted)
+			if !w.Loaded {
+				line += " LOCKED"
+			}
+			w.Lock.RUnlock()
+
+			if w == selected {
+				line = "* " + line
+			} else {
+				line = "  " + line
+			}
+			gui.GUI.OutputWrite(line)
+		}
+		return
+	}
+
+	cliCreateNamedWallet := func(cmd string, ctx context.Context) (err error) {
+
+		name := gui.GUI.OutputReadString("Wallet name")
+		password := gui.GUI.OutputReadString("Password for encrypting wallet. Leave empty for none")
+
+		difficulty := 0
+		if password != "" {
+			difficulty = gui.GUI.OutputReadInt("Difficulty for encryption", false, 0, func(value int) bool {
+				return value >= 1 && value <= 10
+			})
+		}
+
+		if _, err = self.Create(name, password, difficulty); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Wallet " + name + " was created")
+		return
+	}
+
+	cliSelectWallet := func(cmd string, ctx context.Context) (err error) {
+
+		if err = cliListWallets(cmd, ctx); err != nil {
+			return
+		}
+
+		name := gui.GUI.OutputReadString("Wallet name to be selected")
+		if err = self.Select(name); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Wallet " + self.GetSelected().GetName() + " was selected")
+		return
+	}
+
+	cliRemoveNamedWallet := func(cmd string, ctx context.Context) (err error) {
+
+		if err = cliListWallets(cmd, ctx); err != nil {
+			return
+		}
+
+		name := gui.GUI.OutputReadString("Wallet name to be removed")
+		if !gui.GUI.OutputReadBool("The mnemonic of the wallet is deleted. Are you sure? y/n", false, false) {
+			return
+		}
+
+		if err = self.Remove(name); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Wallet " + name + " was removed")
+		return
+	}
+
+	gui.GUI.CommandDefineCallback("List Wallets", cliListWallets, true)
+	gui.GUI.CommandDefineCallback("Create Named Wallet", cliCreateNamedWallet, true)
+	gui.GUI.CommandDefineCallback("Select Wallet", cliSelectWallet, true)
+	gui.GUI.CommandDefineCallback("Remove Named Wallet", cliRemoveNamedWallet, true)
+
+}
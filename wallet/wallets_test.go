@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/wallet/wallet_address"
+	"sync"
+	"testing"
+)
+
+func createTestWalletAddress(t *testing.T, w *wallet) *wallet_address.WalletAddress {
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	addr, err := privateKey.GenerateAddress(false, nil, true, nil, 0, nil)
+	assert.NoError(t, err)
+
+	walletAddress := &wallet_address.WalletAddress{
+		Version:        wallet_address.VERSION_NORMAL,
+		PrivateKey:     privateKey,
+		PublicKey:      addr.PublicKey,
+		AddressEncoded: addr.EncodeAddr(),
+	}
+	w.Addresses = append(w.Addresses, walletAddress)
+	w.addressesMap[string(walletAddress.PublicKey)] = walletAddress
+
+	return walletAddress
+}
+
+func TestWallets_GetWalletAddress(t *testing.T) {
+
+	Wallet = createWalletInstance(nil, "")
+	savings := createWalletInstance(nil, "savings")
+
+	Wallets = &walletsType{
+		map[string]*wallet{"savings": savings},
+		Wallet,
+		nil,
+		sync.RWMutex{},
+	}
+
+	defaultAddr := createTestWalletAddress(t, Wallet)
+	savingsAddr := createTestWalletAddress(t, savings)
+
+	addr, err := Wallets.GetWalletAddress("", defaultAddr.AddressEncoded)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAddr.PublicKey, addr.PublicKey)
+
+	addr, err = Wallets.GetWalletAddress("savings", savingsAddr.AddressEncoded)
+	assert.NoError(t, err)
+	assert.Equal(t, savingsAddr.PublicKey, addr.PublicKey)
+
+	//the address of another wallet is rejected
+	_, err = Wallets.GetWalletAddress("", savingsAddr.AddressEncoded)
+	assert.EqualError(t, err, "Address "+savingsAddr.AddressEncoded+" doesn't exist in the wallet default")
+
+	_, err = Wallets.GetWalletAddress(DEFAULT_WALLET_NAME, savingsAddr.AddressEncoded)
+	assert.Error(t, err)
+
+	_, err = Wallets.GetWalletAddress("savings", defaultAddr.AddressEncoded)
+	assert.EqualError(t, err, "Address "+defaultAddr.AddressEncoded+" doesn't exist in the wallet savings")
+
+	_, err = Wallets.GetWalletAddress("missing", defaultAddr.AddressEncoded)
+	assert.EqualError(t, err, "Wallet missing was not found")
+
+	//the forgers are searched in all the wallets of the node
+	name, err := Wallets.GetWalletNameByPublicKey(savingsAddr.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "savings", name)
+
+	name, err = Wallets.GetWalletNameByPublicKey(defaultAddr.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, DEFAULT_WALLET_NAME, name)
+
+	_, err = Wallets.GetWalletNameByPublicKey(addresses.GenerateNewPrivateKey().GeneratePublicKey())
+	assert.EqualError(t, err, "Address was not found")
+}